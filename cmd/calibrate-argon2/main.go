@@ -0,0 +1,39 @@
+// Команда подбора параметров argon2id под целевое время хеширования на текущем железе.
+//
+//	calibrate-argon2 [-target 500ms] [-memory 65536] [-min-memory 19456] [-parallelism 4]
+//
+// Печатает секцию password_hashing для config.yaml. Запускать на машине того же класса,
+// что и продакшен, без посторонней нагрузки
+package main
+
+import (
+	"auth-service/pkg/passhash"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+)
+
+func main() {
+	target := flag.Duration("target", 500*time.Millisecond, "target hashing latency")
+	memory := flag.Uint("memory", uint(passhash.DefaultArgon2id.Memory), "starting memory in KiB")
+	minMemory := flag.Uint("min-memory", 19*1024, "lowest memory in KiB (OWASP minimum is 19 MiB)")
+	parallelism := flag.Uint("parallelism", uint(min(runtime.NumCPU(), 4)), "number of threads")
+	flag.Parse()
+
+	if *parallelism == 0 || *parallelism > 255 || *memory < *minMemory || *target <= 0 {
+		fmt.Fprintln(os.Stderr, "invalid parameters")
+		os.Exit(2)
+	}
+
+	a, elapsed := passhash.CalibrateArgon2id(*target, uint32(*memory), uint32(*minMemory), uint8(*parallelism))
+
+	fmt.Printf("# argon2id: %s per hash on %d CPU\n", elapsed.Round(time.Millisecond), runtime.NumCPU())
+	fmt.Printf("password_hashing:\n")
+	fmt.Printf("  algorithm: argon2id\n")
+	fmt.Printf("  argon2id:\n")
+	fmt.Printf("    memory: %d # KiB\n", a.Memory)
+	fmt.Printf("    time: %d\n", a.Time)
+	fmt.Printf("    parallelism: %d\n", a.Parallelism)
+}
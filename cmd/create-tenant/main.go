@@ -0,0 +1,64 @@
+// Команда создания арендатора.
+//
+//	create-tenant -config config.yaml -slug acme [-name "ACME"] [-roles admin,moderator]
+//
+// Роль по умолчанию создается всегда. Созданный арендатор в JSON печатается в stdout;
+// его ID или slug клиенты передают в metadata x-tenant
+package main
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run создает арендатора и возвращает код выхода; отдельная функция нужна, чтобы отработали defer
+func run() int {
+	slug := flag.String("slug", "", "tenant slug used in x-tenant metadata")
+	name := flag.String("name", "", "tenant display name (slug if empty)")
+	roles := flag.String("roles", "admin,moderator", "comma-separated roles to create besides the default one")
+
+	// флаги разбирает config.MustLoad вместе с -config
+	cfg := config.MustLoad()
+
+	log, logFile := logger.Initial(cfg)
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	if *slug == "" {
+		fmt.Fprintln(os.Stderr, "-slug is required")
+		return 2
+	}
+
+	var roleList []string
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roleList = append(roleList, role)
+		}
+	}
+
+	authApp := auth.New(log, cfg)
+	defer authApp.Close()
+
+	t, err := authApp.CreateTenant(context.Background(), *slug, *name, roleList)
+	if err != nil {
+		log.Error("арендатор не создан", logger.Err(err))
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(t)
+	return 0
+}
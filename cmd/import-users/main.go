@@ -0,0 +1,95 @@
+// Команда массового импорта пользователей из CSV или JSONL.
+//
+//	import-users -config config.yaml -file users.csv [-format csv|jsonl] [-tenant slug] [-dry-run] [-overwrite]
+//
+// Существующие пользователи пропускаются; с -overwrite их пароли заменяются, а токены отзываются.
+// Открытые пароли проверяются по политике паролей из конфигурации.
+// Отчет в JSON печатается в stdout; код выхода 1, если хотя бы одна строка не импортирована
+package main
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/auth"
+	"auth-service/internal/services/validator"
+	"auth-service/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run выполняет импорт и возвращает код выхода; отдельная функция нужна, чтобы отработали defer
+func run() int {
+	file := flag.String("file", "", "path to CSV or JSONL file")
+	format := flag.String("format", "", "csv or jsonl (by file extension if empty)")
+	dryRun := flag.Bool("dry-run", false, "validate and report without writing")
+	overwrite := flag.Bool("overwrite", false, "replace passwords of existing users and revoke their tokens")
+	tenantRef := flag.String("tenant", "", "tenant ID or slug (default tenant if empty)")
+
+	// флаги разбирает config.MustLoad вместе с -config
+	cfg := config.MustLoad()
+
+	log, logFile := logger.Initial(cfg)
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		return 2
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	defer f.Close()
+
+	// прерывание останавливает импорт после текущего пакета; записанные пакеты остаются
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	authApp := auth.New(log, cfg)
+	defer authApp.Close()
+
+	v := validator.New(log, cfg)
+	defer v.Close()
+
+	ctx, err = authApp.TenantContext(ctx, *tenantRef, "")
+	if err != nil {
+		log.Error("арендатор не найден", logger.Err(err))
+		return 2
+	}
+
+	report, err := authApp.ImportUsers(ctx, f, *format, auth.ImportOptions{
+		DryRun:        *dryRun,
+		Overwrite:     *overwrite,
+		CheckPassword: v.ValidatePassword,
+	})
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	}
+	if err != nil {
+		log.Error("импорт прерван", logger.Err(err))
+		return 1
+	}
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
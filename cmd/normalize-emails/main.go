@@ -0,0 +1,65 @@
+// Команда пересчета канонического вида хранимых email текущими правилами нормализации.
+//
+//	normalize-emails -config config.yaml [-provider-rules] [-apply]
+//
+// Без -apply только печатает отчет. Перед включением email.provider_rules команду запускают
+// с -provider-rules: адреса, совпадающие по новым правилам, нужно объединить вручную, затем
+// записать канонический вид с -apply и только после этого включить правила в конфигурации,
+// иначе вход по адресам, записанным без правил, перестанет находить пользователей.
+// Адреса пользователей правила провайдеров не меняют.
+// Отчет в JSON печатается в stdout; код выхода 1, если найдены дубликаты
+package main
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run выполняет проверку и возвращает код выхода; отдельная функция нужна, чтобы отработали defer
+func run() int {
+	providerRules := flag.Bool("provider-rules", false, "check with provider-specific rules even if disabled in config")
+	apply := flag.Bool("apply", false, "write normalized emails and canonical forms if there are no duplicates")
+
+	// флаги разбирает config.MustLoad вместе с -config
+	cfg := config.MustLoad()
+	if *providerRules {
+		cfg.Email.ProviderRules = true
+	}
+
+	log, logFile := logger.Initial(cfg)
+	if logFile != nil {
+		defer logFile.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	authApp := auth.New(log, cfg)
+	defer authApp.Close()
+
+	report, err := authApp.NormalizeStoredEmails(ctx, *apply)
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	}
+	if err != nil {
+		log.Error("нормализация email прервана", logger.Err(err))
+		return 1
+	}
+	if len(report.Duplicates) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -11,8 +11,26 @@ type Config struct {
 	Env     string `yaml:"env" env-default:"local"`
 	LogFile `yaml:"logFile"`
 	GRPC    `yaml:"grpc"`
+	HTTP    HTTP        `yaml:"http"`
 	Storage StorageData `yaml:"storage"`
 	Cert    Cert        `yaml:"cert"`
+	OIDC    OIDC        `yaml:"oidc"`
+
+	ServiceClients    ServiceClients     `yaml:"service_clients"`
+	IdentityProviders []IdentityProvider `yaml:"identity_providers"`
+	Mail              Mail               `yaml:"mail"`
+	MagicLink         MagicLink          `yaml:"magic_link"`
+	EmailChange       EmailChange        `yaml:"email_change"`
+	AccountDeletion   AccountDeletion    `yaml:"account_deletion"`
+	PasswordHashing   PasswordHashing    `yaml:"password_hashing"`
+	BreachedPasswords BreachedPasswords  `yaml:"breached_passwords"`
+	PasswordPolicy    PasswordPolicy     `yaml:"password_policy"`
+	Email             Email              `yaml:"email"`
+
+	Registration          Registration          `yaml:"registration"`
+	RegistrationChallenge RegistrationChallenge `yaml:"registration_challenge"`
+
+	Organisations Organisations `yaml:"organisations"`
 }
 
 type LogFile struct {
@@ -25,6 +43,12 @@ type GRPC struct {
 	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
 }
 
+type HTTP struct {
+	Port    int           `yaml:"port" env-default:"50080"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	Metrics bool          `yaml:"metrics" env-default:"false"` // Отдавать метрики expvar на /debug/vars
+}
+
 type StorageData struct {
 	User     string `yaml:"user"`
 	Pass     string `yaml:"pass"`
@@ -39,6 +63,165 @@ type Cert struct {
 	EmailPepper string `yaml:"email_pepper"`
 }
 
+// OIDC настройки OpenID Connect провайдера
+type OIDC struct {
+	Issuer         string        `yaml:"issuer"`                        // Публичный URL издателя, совпадает с iss в ID токене
+	SigningKeyFile string        `yaml:"signing_key_file"`              // Путь к RSA ключу (PEM) для подписи ID токенов
+	IDTokenTTL     time.Duration `yaml:"id_token_ttl" env-default:"1h"` // Время жизни ID токена
+	ClientIDs      []string      `yaml:"client_ids"`                    // Разрешенные client_id партнерских приложений
+
+	// Authorization code flow. Сервис не показывает страниц: authorization endpoint перенаправляет
+	// на страницу входа фронтенда, а она после входа получает адрес возврата с кодом через RPC Authorize
+	LoginURL     string              `yaml:"login_url"`                     // Страница входа фронтенда, к ней добавляется ?request=
+	RedirectURIs map[string][]string `yaml:"redirect_uris"`                 // Разрешенные redirect_uri по client_id
+	RequestTTL   time.Duration       `yaml:"request_ttl" env-default:"10m"` // Сколько действует запрос авторизации на странице входа
+	CodeTTL      time.Duration       `yaml:"code_ttl" env-default:"1m"`     // Время жизни кода авторизации
+}
+
+// ServiceClients настройки машинных клиентов (client credentials grant)
+type ServiceClients struct {
+	TokenTTL time.Duration `yaml:"token_ttl" env-default:"1h"` // Время жизни токена сервиса
+}
+
+// IdentityProvider внешний OpenID Connect провайдер для входа через соцсети
+type IdentityProvider struct {
+	Name        string `yaml:"name"`          // Имя провайдера в API (google, yandex, vk)
+	Issuer      string `yaml:"issuer"`        // Ожидаемый iss в ID токене провайдера
+	ClientID    string `yaml:"client_id"`     // Наш client_id у провайдера, ожидаемый aud
+	JWKSURL     string `yaml:"jwks_url"`      // Адрес JWKS; если пустой, берется из discovery
+	LinkByEmail bool   `yaml:"link_by_email"` // Привязывать к существующему аккаунту по подтвержденному email
+}
+
+// Mail настройки SMTP для отправки писем. Пустой host - письма пишутся в лог, допустимо только в local и dev
+type Mail struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port" env-default:"587"`
+	User string `yaml:"user"`
+	Pass string `yaml:"pass"`
+	From string `yaml:"from"`
+}
+
+// MagicLink настройки входа по ссылке из письма
+type MagicLink struct {
+	URL        string        `yaml:"url"`                          // Адрес страницы фронтенда, к нему добавляется ?token=
+	TTL        time.Duration `yaml:"ttl" env-default:"15m"`        // Время жизни ссылки
+	RateLimit  int           `yaml:"rate_limit" env-default:"5"`   // Максимум запросов ссылки на один email за окно
+	RateWindow time.Duration `yaml:"rate_window" env-default:"1h"` // Окно ограничения запросов
+}
+
+// EmailChange настройки подтверждения смены email
+type EmailChange struct {
+	URL string        `yaml:"url"`                   // Адрес страницы подтверждения, к нему добавляется ?token=
+	TTL time.Duration `yaml:"ttl" env-default:"24h"` // Время жизни ссылки подтверждения
+}
+
+// AccountDeletion настройки удаления аккаунтов
+type AccountDeletion struct {
+	GracePeriod   time.Duration `yaml:"grace_period" env-default:"720h"` // Срок до окончательного удаления данных
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"` // Период запуска фоновой очистки
+	ReauthWindow  time.Duration `yaml:"reauth_window" env-default:"5m"`  // Давность входа, подтверждающая удаление аккаунта без пароля
+}
+
+// PasswordHashing алгоритм и параметры хеширования паролей. Хеши других алгоритмов
+// и с более слабыми параметрами пересчитываются при следующем успешном входе
+type PasswordHashing struct {
+	Algorithm  string   `yaml:"algorithm" env-default:"bcrypt"` // bcrypt, argon2id или scrypt
+	BcryptCost int      `yaml:"bcrypt_cost" env-default:"14"`
+	Argon2id   Argon2id `yaml:"argon2id"`
+	Scrypt     Scrypt   `yaml:"scrypt"`
+
+	Concurrency int `yaml:"concurrency" env-default:"0"`  // Одновременных хеширований; 0 - половина CPU (для argon2id еще деленная на parallelism)
+	QueueDepth  int `yaml:"queue_depth" env-default:"64"` // Ожидающих в очереди; сверх лимита запрос отклоняется с ResourceExhausted
+}
+
+// Argon2id параметры argon2id. Подобрать под железо можно командой cmd/calibrate-argon2
+type Argon2id struct {
+	Memory      uint32 `yaml:"memory" env-default:"65536"`  // Память в KiB
+	Time        uint32 `yaml:"time" env-default:"3"`        // Число проходов
+	Parallelism uint8  `yaml:"parallelism" env-default:"4"` // Число потоков
+}
+
+// Scrypt параметры scrypt
+type Scrypt struct {
+	LogN uint8 `yaml:"log_n" env-default:"15"` // log2 N: стоимость по CPU и памяти
+	R    int   `yaml:"r" env-default:"8"`      // Размер блока
+	P    int   `yaml:"p" env-default:"1"`      // Параллелизм
+}
+
+// BreachedPasswords проверка новых паролей по локальной базе утечек (SHA-1, формат Have I Been Pwned).
+// Список частых паролей и проверка на email работают всегда, база утечек - если задан source
+type BreachedPasswords struct {
+	Source   string `yaml:"source"`                    // dir, file или postgres; пусто - не проверять
+	Path     string `yaml:"path"`                      // Каталог диапазонов (dir) или отсортированный файл хешей (file)
+	MinCount int    `yaml:"min_count" env-default:"1"` // Сколько раз пароль должен встретиться в утечках
+}
+
+// PasswordPolicy требования к новым паролям. Отдается клиентам через GetPasswordPolicy
+type PasswordPolicy struct {
+	MinLength           int           `yaml:"min_length" env-default:"8"`
+	MaxLength           int           `yaml:"max_length" env-default:"0"` // В символах; 0 - без ограничения, bcrypt дополнительно ограничивает 72 байтами
+	MinUniqueChars      int           `yaml:"min_unique_chars" env-default:"0"`
+	RequireUpper        bool          `yaml:"require_upper" env-default:"true"`
+	RequireLower        bool          `yaml:"require_lower" env-default:"true"`
+	RequireNumber       bool          `yaml:"require_number" env-default:"true"`
+	RequireSpecial      bool          `yaml:"require_special" env-default:"true"`
+	DisallowedSequences []string      `yaml:"disallowed_sequences"`
+	SequenceLength      int           `yaml:"sequence_length" env-default:"0"` // Сколько подряд символов последовательности запрещено; 0 - не проверять
+	HistoryDepth        int           `yaml:"history_depth" env-default:"0"`   // Сколько прежних паролей нельзя использовать повторно
+	MaxAge              time.Duration `yaml:"max_age" env-default:"0"`         // Срок действия пароля; 0 - бессрочно
+}
+
+// Email правила приведения адресов к каноническому виду. Адрес хранится и ищется
+// в нижнем регистре с доменом в punycode
+type Email struct {
+	ProviderRules bool         `yaml:"provider_rules" env-default:"false"` // Учитывать точки и +метки Gmail, Яндекса и др. при поиске и проверке уникальности
+	Domains       EmailDomains `yaml:"domains"`
+}
+
+// EmailDomains политика доменов для регистрации и смены email. Домен в списке
+// распространяется и на поддомены; запрет приоритетнее разрешения
+type EmailDomains struct {
+	Allow           []string      `yaml:"allow"`                                // Если не пуст, разрешены только эти домены
+	Deny            []string      `yaml:"deny"`                                 // Запрещенные домены
+	File            string        `yaml:"file"`                                 // Файл со строками "allow домен" и "deny домен"
+	ReloadInterval  time.Duration `yaml:"reload_interval" env-default:"30s"`    // Как часто проверять изменение файла
+	BlockDisposable bool          `yaml:"block_disposable" env-default:"false"` // Запрещать домены одноразовой почты из встроенного списка
+	RequireMX       bool          `yaml:"require_mx" env-default:"false"`       // Запрещать домены без MX и A/AAAA записей
+	MXTimeout       time.Duration `yaml:"mx_timeout" env-default:"3s"`          // Таймаут DNS запроса; при сбое DNS домен не отклоняется
+}
+
+// Registration кто может создать аккаунт через Register и первый вход через внешнего провайдера.
+// Импорт пользователей администратором режимом не ограничивается
+type Registration struct {
+	Mode      string        `yaml:"mode" env-default:"open"`       // open, invite_only, closed или domain_restricted
+	Domains   []string      `yaml:"domains"`                       // Для domain_restricted: домены, с которых можно без приглашения
+	InviteTTL time.Duration `yaml:"invite_ttl" env-default:"168h"` // Срок приглашения, если не задан при создании; 0 - бессрочно
+}
+
+// RegistrationChallenge защита Register от автоматических регистраций. Если настроены капча
+// и proof-of-work, клиент проходит любое из испытаний; если ничего не настроено, проверки нет
+type RegistrationChallenge struct {
+	Captcha     Captcha     `yaml:"captcha"`
+	ProofOfWork ProofOfWork `yaml:"proof_of_work"`
+}
+
+// Captcha проверка ответа виджета капчи через siteverify провайдера
+type Captcha struct {
+	Provider  string        `yaml:"provider"`                    // hcaptcha или recaptcha; пусто - капча выключена
+	SiteKey   string        `yaml:"site_key"`                    // Отдается клиенту для виджета
+	Secret    string        `yaml:"secret"`                      // Секретный ключ для siteverify
+	VerifyURL string        `yaml:"verify_url"`                  // Адрес siteverify; пусто - адрес провайдера по умолчанию
+	MinScore  float64       `yaml:"min_score" env-default:"0.5"` // Минимальная оценка reCAPTCHA v3 и hCaptcha Enterprise
+	Timeout   time.Duration `yaml:"timeout" env-default:"5s"`    // Таймаут запроса к провайдеру
+}
+
+// ProofOfWork встроенное испытание для клиентов без виджета капчи
+type ProofOfWork struct {
+	Enabled    bool          `yaml:"enabled" env-default:"false"`
+	Difficulty int           `yaml:"difficulty" env-default:"20"` // Число ведущих нулевых бит хеша
+	TTL        time.Duration `yaml:"ttl" env-default:"5m"`        // Время на решение
+}
+
 var cfg *Config
 
 func MustLoad() *Config {
@@ -70,3 +253,11 @@ func fetchConfigPath() string {
 
 	return path
 }
+
+// Organisations настройки организаций и приглашений в них. Роль owner есть всегда:
+// владельцы и участники с ролью manager управляют участниками
+type Organisations struct {
+	Roles     []string      `yaml:"roles" env-default:"manager,staff"` // Роли, которые можно назначить в организации, кроме owner
+	InviteURL string        `yaml:"invite_url"`                        // Адрес страницы принятия приглашения, к нему добавляется ?token=
+	InviteTTL time.Duration `yaml:"invite_ttl" env-default:"168h"`     // Время жизни приглашения
+}
@@ -10,6 +10,7 @@ import (
 	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,101 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ListUsersRequest_SortBy int32
+
+const (
+	ListUsersRequest_CREATED_AT    ListUsersRequest_SortBy = 0
+	ListUsersRequest_EMAIL         ListUsersRequest_SortBy = 1
+	ListUsersRequest_LAST_LOGIN_AT ListUsersRequest_SortBy = 2
+)
+
+// Enum value maps for ListUsersRequest_SortBy.
+var (
+	ListUsersRequest_SortBy_name = map[int32]string{
+		0: "CREATED_AT",
+		1: "EMAIL",
+		2: "LAST_LOGIN_AT",
+	}
+	ListUsersRequest_SortBy_value = map[string]int32{
+		"CREATED_AT":    0,
+		"EMAIL":         1,
+		"LAST_LOGIN_AT": 2,
+	}
+)
+
+func (x ListUsersRequest_SortBy) Enum() *ListUsersRequest_SortBy {
+	p := new(ListUsersRequest_SortBy)
+	*p = x
+	return p
+}
+
+func (x ListUsersRequest_SortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListUsersRequest_SortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_service_auth_service_proto_enumTypes[0].Descriptor()
+}
+
+func (ListUsersRequest_SortBy) Type() protoreflect.EnumType {
+	return &file_auth_service_auth_service_proto_enumTypes[0]
+}
+
+func (x ListUsersRequest_SortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListUsersRequest_SortBy.Descriptor instead.
+func (ListUsersRequest_SortBy) EnumDescriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{59, 0}
+}
+
+type ImportUsersRequest_Format int32
+
+const (
+	ImportUsersRequest_CSV   ImportUsersRequest_Format = 0 // Заголовок: email,roles,password,password_hash,password_algo,salt
+	ImportUsersRequest_JSONL ImportUsersRequest_Format = 1 // Один объект с теми же полями на строку, roles - массив
+)
+
+// Enum value maps for ImportUsersRequest_Format.
+var (
+	ImportUsersRequest_Format_name = map[int32]string{
+		0: "CSV",
+		1: "JSONL",
+	}
+	ImportUsersRequest_Format_value = map[string]int32{
+		"CSV":   0,
+		"JSONL": 1,
+	}
+)
+
+func (x ImportUsersRequest_Format) Enum() *ImportUsersRequest_Format {
+	p := new(ImportUsersRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ImportUsersRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportUsersRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_auth_service_auth_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportUsersRequest_Format) Type() protoreflect.EnumType {
+	return &file_auth_service_auth_service_proto_enumTypes[1]
+}
+
+func (x ImportUsersRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportUsersRequest_Format.Descriptor instead.
+func (ImportUsersRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{69, 0}
+}
+
 type PingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -106,6 +202,8 @@ type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Challenge     *ChallengeSolution     `protobuf:"bytes,3,opt,name=challenge,proto3,oneof" json:"challenge,omitempty"`                     // Обязательно, если GetRegistrationChallenge вернул required
+	InviteCode    *string                `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode,proto3,oneof" json:"invite_code,omitempty"` // Код приглашения; роли из приглашения назначаются при регистрации
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -154,6 +252,20 @@ func (x *RegisterRequest) GetPassword() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetChallenge() *ChallengeSolution {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *RegisterRequest) GetInviteCode() string {
+	if x != nil && x.InviteCode != nil {
+		return *x.InviteCode
+	}
+	return ""
+}
+
 type RegisterResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	JwtToken      string                 `protobuf:"bytes,1,opt,name=jwt_token,json=jwtToken,proto3" json:"jwt_token,omitempty"`
@@ -210,6 +322,8 @@ type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	ClientId      *string                `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3,oneof" json:"client_id,omitempty"` // OIDC клиент, для которого нужен ID токен
+	Nonce         *string                `protobuf:"bytes,4,opt,name=nonce,proto3,oneof" json:"nonce,omitempty"`                       // OIDC nonce, возвращается в ID токене
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -258,11 +372,27 @@ func (x *LoginRequest) GetPassword() string {
 	return ""
 }
 
+func (x *LoginRequest) GetClientId() string {
+	if x != nil && x.ClientId != nil {
+		return *x.ClientId
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetNonce() string {
+	if x != nil && x.Nonce != nil {
+		return *x.Nonce
+	}
+	return ""
+}
+
 type LoginResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	JwtToken      string                 `protobuf:"bytes,2,opt,name=jwt_token,json=jwtToken,proto3" json:"jwt_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	JwtToken        string                 `protobuf:"bytes,2,opt,name=jwt_token,json=jwtToken,proto3" json:"jwt_token,omitempty"`
+	IdToken         *string                `protobuf:"bytes,3,opt,name=id_token,json=idToken,proto3,oneof" json:"id_token,omitempty"`                    // OIDC ID токен, если передан client_id
+	PasswordExpired bool                   `protobuf:"varint,4,opt,name=password_expired,json=passwordExpired,proto3" json:"password_expired,omitempty"` // Срок действия пароля истек: jwt_token годится только для ChangePassword
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *LoginResponse) Reset() {
@@ -302,27 +432,43 @@ func (x *LoginResponse) GetJwtToken() string {
 	return ""
 }
 
-type VerifyTokenRequest struct {
+func (x *LoginResponse) GetIdToken() string {
+	if x != nil && x.IdToken != nil {
+		return *x.IdToken
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetPasswordExpired() bool {
+	if x != nil {
+		return x.PasswordExpired
+	}
+	return false
+}
+
+type LoginWithProviderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`              // Имя провайдера из конфигурации (google, yandex, vk)
+	IdToken       string                 `protobuf:"bytes,2,opt,name=id_token,json=idToken,proto3" json:"id_token,omitempty"` // ID токен, полученный клиентом от провайдера
+	Nonce         *string                `protobuf:"bytes,3,opt,name=nonce,proto3,oneof" json:"nonce,omitempty"`              // nonce, переданный провайдеру при авторизации
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyTokenRequest) Reset() {
-	*x = VerifyTokenRequest{}
+func (x *LoginWithProviderRequest) Reset() {
+	*x = LoginWithProviderRequest{}
 	mi := &file_auth_service_auth_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTokenRequest) String() string {
+func (x *LoginWithProviderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTokenRequest) ProtoMessage() {}
+func (*LoginWithProviderRequest) ProtoMessage() {}
 
-func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *LoginWithProviderRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_service_auth_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -334,43 +480,53 @@ func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
-func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginWithProviderRequest.ProtoReflect.Descriptor instead.
+func (*LoginWithProviderRequest) Descriptor() ([]byte, []int) {
 	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *VerifyTokenRequest) GetToken() string {
+func (x *LoginWithProviderRequest) GetProvider() string {
 	if x != nil {
-		return x.Token
+		return x.Provider
 	}
 	return ""
 }
 
-type VerifyTokenResponse struct {
+func (x *LoginWithProviderRequest) GetIdToken() string {
+	if x != nil {
+		return x.IdToken
+	}
+	return ""
+}
+
+func (x *LoginWithProviderRequest) GetNonce() string {
+	if x != nil && x.Nonce != nil {
+		return *x.Nonce
+	}
+	return ""
+}
+
+type RequestMagicLinkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`                // Валиден ли токен
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ID пользователя из токена
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`                 // Email пользователя из токена
-	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`                 // Список ролей пользователя из токена
-	Error         *status.Status         `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`           // Ошибка, если есть
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyTokenResponse) Reset() {
-	*x = VerifyTokenResponse{}
+func (x *RequestMagicLinkRequest) Reset() {
+	*x = RequestMagicLinkRequest{}
 	mi := &file_auth_service_auth_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyTokenResponse) String() string {
+func (x *RequestMagicLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyTokenResponse) ProtoMessage() {}
+func (*RequestMagicLinkRequest) ProtoMessage() {}
 
-func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
+func (x *RequestMagicLinkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_service_auth_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -382,142 +538,4610 @@ func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
-func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RequestMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkRequest) Descriptor() ([]byte, []int) {
 	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *VerifyTokenResponse) GetValid() bool {
+func (x *RequestMagicLinkRequest) GetEmail() string {
 	if x != nil {
-		return x.Valid
+		return x.Email
 	}
-	return false
+	return ""
 }
 
-func (x *VerifyTokenResponse) GetUserId() string {
+// Ответ одинаковый для зарегистрированных и неизвестных email
+type RequestMagicLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMagicLinkResponse) Reset() {
+	*x = RequestMagicLinkResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMagicLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMagicLinkResponse) ProtoMessage() {}
+
+func (x *RequestMagicLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[8]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *VerifyTokenResponse) GetEmail() string {
+// Deprecated: Use RequestMagicLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestMagicLinkResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{8}
+}
+
+type ConsumeMagicLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Токен из ссылки в письме
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeMagicLinkRequest) Reset() {
+	*x = ConsumeMagicLinkRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeMagicLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeMagicLinkRequest) ProtoMessage() {}
+
+func (x *ConsumeMagicLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[9]
 	if x != nil {
-		return x.Email
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *VerifyTokenResponse) GetRoles() []string {
+// Deprecated: Use ConsumeMagicLinkRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeMagicLinkRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConsumeMagicLinkRequest) GetToken() string {
 	if x != nil {
-		return x.Roles
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *VerifyTokenResponse) GetError() *status.Status {
+type AuthorizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Request       string                 `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"` // Параметр request, с которым /authorize открыл страницу входа
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizeRequest) Reset() {
+	*x = AuthorizeRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeRequest) ProtoMessage() {}
+
+func (x *AuthorizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[10]
 	if x != nil {
-		return x.Error
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-var File_auth_service_auth_service_proto protoreflect.FileDescriptor
+// Deprecated: Use AuthorizeRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{10}
+}
 
-const file_auth_service_auth_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1fauth-service/auth-service.proto\x12\x0fapi.AuthService\x1a\x17google/rpc/status.proto\"\r\n" +
-	"\vPingRequest\"\x1e\n" +
-	"\fPingResponse\x12\x0e\n" +
-	"\x02ok\x18\x01 \x01(\bR\x02ok\"C\n" +
-	"\x0fRegisterRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"h\n" +
-	"\x10RegisterResponse\x12\x1b\n" +
-	"\tjwt_token\x18\x01 \x01(\tR\bjwtToken\x12-\n" +
-	"\x05error\x18\x02 \x01(\v2\x12.google.rpc.StatusH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"@\n" +
-	"\fLoginRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\",\n" +
-	"\rLoginResponse\x12\x1b\n" +
-	"\tjwt_token\x18\x02 \x01(\tR\bjwtToken\"*\n" +
-	"\x12VerifyTokenRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\xa9\x01\n" +
-	"\x13VerifyTokenResponse\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
-	"\x05roles\x18\x04 \x03(\tR\x05roles\x12-\n" +
-	"\x05error\x18\x05 \x01(\v2\x12.google.rpc.StatusH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error2\xcd\x02\n" +
-	"\vAuthService\x12E\n" +
-	"\x04Ping\x12\x1c.api.AuthService.PingRequest\x1a\x1d.api.AuthService.PingResponse\"\x00\x12Q\n" +
-	"\bRegister\x12 .api.AuthService.RegisterRequest\x1a!.api.AuthService.RegisterResponse\"\x00\x12H\n" +
-	"\x05Login\x12\x1d.api.AuthService.LoginRequest\x1a\x1e.api.AuthService.LoginResponse\"\x00\x12Z\n" +
-	"\vVerifyToken\x12#.api.AuthService.VerifyTokenRequest\x1a$.api.AuthService.VerifyTokenResponse\"\x00B?Z=github.com/mussyaroslav/auth-service/generate/api.authserviceb\x06proto3"
+func (x *AuthorizeRequest) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
 
-var (
-	file_auth_service_auth_service_proto_rawDescOnce sync.Once
-	file_auth_service_auth_service_proto_rawDescData []byte
-)
+type AuthorizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedirectUri   string                 `protobuf:"bytes,1,opt,name=redirect_uri,json=redirectUri,proto3" json:"redirect_uri,omitempty"` // Адрес возврата клиента с code и state, на него переходит браузер
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func file_auth_service_auth_service_proto_rawDescGZIP() []byte {
-	file_auth_service_auth_service_proto_rawDescOnce.Do(func() {
-		file_auth_service_auth_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_service_auth_service_proto_rawDesc), len(file_auth_service_auth_service_proto_rawDesc)))
-	})
-	return file_auth_service_auth_service_proto_rawDescData
+func (x *AuthorizeResponse) Reset() {
+	*x = AuthorizeResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-var file_auth_service_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_auth_service_auth_service_proto_goTypes = []any{
-	(*PingRequest)(nil),         // 0: api.AuthService.PingRequest
-	(*PingResponse)(nil),        // 1: api.AuthService.PingResponse
-	(*RegisterRequest)(nil),     // 2: api.AuthService.RegisterRequest
-	(*RegisterResponse)(nil),    // 3: api.AuthService.RegisterResponse
-	(*LoginRequest)(nil),        // 4: api.AuthService.LoginRequest
-	(*LoginResponse)(nil),       // 5: api.AuthService.LoginResponse
-	(*VerifyTokenRequest)(nil),  // 6: api.AuthService.VerifyTokenRequest
-	(*VerifyTokenResponse)(nil), // 7: api.AuthService.VerifyTokenResponse
-	(*status.Status)(nil),       // 8: google.rpc.Status
+func (x *AuthorizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
-var file_auth_service_auth_service_proto_depIdxs = []int32{
-	8, // 0: api.AuthService.RegisterResponse.error:type_name -> google.rpc.Status
-	8, // 1: api.AuthService.VerifyTokenResponse.error:type_name -> google.rpc.Status
-	0, // 2: api.AuthService.AuthService.Ping:input_type -> api.AuthService.PingRequest
-	2, // 3: api.AuthService.AuthService.Register:input_type -> api.AuthService.RegisterRequest
-	4, // 4: api.AuthService.AuthService.Login:input_type -> api.AuthService.LoginRequest
-	6, // 5: api.AuthService.AuthService.VerifyToken:input_type -> api.AuthService.VerifyTokenRequest
-	1, // 6: api.AuthService.AuthService.Ping:output_type -> api.AuthService.PingResponse
-	3, // 7: api.AuthService.AuthService.Register:output_type -> api.AuthService.RegisterResponse
-	5, // 8: api.AuthService.AuthService.Login:output_type -> api.AuthService.LoginResponse
-	7, // 9: api.AuthService.AuthService.VerifyToken:output_type -> api.AuthService.VerifyTokenResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (*AuthorizeResponse) ProtoMessage() {}
+
+func (x *AuthorizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func init() { file_auth_service_auth_service_proto_init() }
-func file_auth_service_auth_service_proto_init() {
-	if File_auth_service_auth_service_proto != nil {
-		return
+// Deprecated: Use AuthorizeResponse.ProtoReflect.Descriptor instead.
+func (*AuthorizeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AuthorizeResponse) GetRedirectUri() string {
+	if x != nil {
+		return x.RedirectUri
+	}
+	return ""
+}
+
+type VerifyTokenRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Token          string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	OrganisationId *string                `protobuf:"bytes,2,opt,name=organisation_id,json=organisationId,proto3,oneof" json:"organisation_id,omitempty"` // Вернуть роли владельца токена в этой организации
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyTokenRequest) Reset() {
+	*x = VerifyTokenRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenRequest) ProtoMessage() {}
+
+func (x *VerifyTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *VerifyTokenRequest) GetOrganisationId() string {
+	if x != nil && x.OrganisationId != nil {
+		return *x.OrganisationId
+	}
+	return ""
+}
+
+type VerifyTokenResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Valid             bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`                                                 // Валиден ли токен
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                  // ID пользователя из токена
+	Email             string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`                                                  // Email пользователя из токена
+	Roles             []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`                                                  // Список ролей пользователя из токена
+	Error             *status.Status         `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`                                            // Ошибка, если есть
+	IsService         bool                   `protobuf:"varint,6,opt,name=is_service,json=isService,proto3" json:"is_service,omitempty"`                        // Токен принадлежит машинному клиенту, user_id содержит client_id
+	TenantId          string                 `protobuf:"bytes,7,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`                            // Арендатор, которому выдан токен
+	OrganisationRoles []string               `protobuf:"bytes,8,rep,name=organisation_roles,json=organisationRoles,proto3" json:"organisation_roles,omitempty"` // Роли в организации из запроса; пусто, если владелец токена в ней не состоит
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *VerifyTokenResponse) Reset() {
+	*x = VerifyTokenResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTokenResponse) ProtoMessage() {}
+
+func (x *VerifyTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTokenResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VerifyTokenResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyTokenResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *VerifyTokenResponse) GetError() *status.Status {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *VerifyTokenResponse) GetIsService() bool {
+	if x != nil {
+		return x.IsService
+	}
+	return false
+}
+
+func (x *VerifyTokenResponse) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *VerifyTokenResponse) GetOrganisationRoles() []string {
+	if x != nil {
+		return x.OrganisationRoles
+	}
+	return nil
+}
+
+type IssueServiceTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueServiceTokenRequest) Reset() {
+	*x = IssueServiceTokenRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueServiceTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueServiceTokenRequest) ProtoMessage() {}
+
+func (x *IssueServiceTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueServiceTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueServiceTokenRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IssueServiceTokenRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenRequest) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+type IssueServiceTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	TokenType     string                 `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`  // Всегда "Bearer"
+	ExpiresIn     int64                  `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"` // Время жизни токена в секундах
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueServiceTokenResponse) Reset() {
+	*x = IssueServiceTokenResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueServiceTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueServiceTokenResponse) ProtoMessage() {}
+
+func (x *IssueServiceTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueServiceTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueServiceTokenResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IssueServiceTokenResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenResponse) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *IssueServiceTokenResponse) GetExpiresIn() int64 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+type CreateServiceClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceClientRequest) Reset() {
+	*x = CreateServiceClientRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceClientRequest) ProtoMessage() {}
+
+func (x *CreateServiceClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceClientRequest.ProtoReflect.Descriptor instead.
+func (*CreateServiceClientRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateServiceClientRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateServiceClientRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type CreateServiceClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // Возвращается единственный раз, хранится только хеш
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateServiceClientResponse) Reset() {
+	*x = CreateServiceClientResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateServiceClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateServiceClientResponse) ProtoMessage() {}
+
+func (x *CreateServiceClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateServiceClientResponse.ProtoReflect.Descriptor instead.
+func (*CreateServiceClientResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateServiceClientResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *CreateServiceClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+// Выданные клиенту токены перестают приниматься не позже чем через минуту
+type RevokeServiceClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeServiceClientRequest) Reset() {
+	*x = RevokeServiceClientRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeServiceClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeServiceClientRequest) ProtoMessage() {}
+
+func (x *RevokeServiceClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeServiceClientRequest.ProtoReflect.Descriptor instead.
+func (*RevokeServiceClientRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeServiceClientRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type RevokeServiceClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeServiceClientResponse) Reset() {
+	*x = RevokeServiceClientResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeServiceClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeServiceClientResponse) ProtoMessage() {}
+
+func (x *RevokeServiceClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeServiceClientResponse.ProtoReflect.Descriptor instead.
+func (*RevokeServiceClientResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{19}
+}
+
+type ApiKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"` // Публичная часть ключа для отображения
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`   // Пусто - все роли владельца
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiKey) Reset() {
+	*x = ApiKey{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiKey) ProtoMessage() {}
+
+func (x *ApiKey) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiKey.ProtoReflect.Descriptor instead.
+func (*ApiKey) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApiKey) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *ApiKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ApiKey) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ApiKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type CreateApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"` // Подмножество ролей пользователя, пусто - все
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyRequest) Reset() {
+	*x = CreateApiKeyRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyRequest) ProtoMessage() {}
+
+func (x *CreateApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateApiKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiKeyRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CreateApiKeyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           *ApiKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"` // Полный ключ, возвращается единственный раз
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiKeyResponse) Reset() {
+	*x = CreateApiKeyResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiKeyResponse) ProtoMessage() {}
+
+func (x *CreateApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateApiKeyResponse) GetKey() *ApiKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateApiKeyResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type ListApiKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysRequest) Reset() {
+	*x = ListApiKeysRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysRequest) ProtoMessage() {}
+
+func (x *ListApiKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListApiKeysRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{23}
+}
+
+type ListApiKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*ApiKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiKeysResponse) Reset() {
+	*x = ListApiKeysResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiKeysResponse) ProtoMessage() {}
+
+func (x *ListApiKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListApiKeysResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListApiKeysResponse) GetKeys() []*ApiKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RevokeApiKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         string                 `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyRequest) Reset() {
+	*x = RevokeApiKeyRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyRequest) ProtoMessage() {}
+
+func (x *RevokeApiKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokeApiKeyRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type RevokeApiKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiKeyResponse) Reset() {
+	*x = RevokeApiKeyResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiKeyResponse) ProtoMessage() {}
+
+func (x *RevokeApiKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiKeyResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{26}
+}
+
+type Invite struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InviteId      string                 `protobuf:"bytes,1,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // Пусто - для любого email
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"` // Назначаются сверх роли по умолчанию
+	MaxUses       int32                  `protobuf:"varint,4,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,6,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // Пусто, если создатель удален или это машинный клиент
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invite) Reset() {
+	*x = Invite{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invite) ProtoMessage() {}
+
+func (x *Invite) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invite.ProtoReflect.Descriptor instead.
+func (*Invite) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Invite) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+func (x *Invite) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Invite) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Invite) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *Invite) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *Invite) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Invite) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Invite) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         *string                `protobuf:"bytes,1,opt,name=email,proto3,oneof" json:"email,omitempty"` // Приглашение только для этого email
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	MaxUses       int32                  `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`            // 0 - одна регистрация
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"` // Не задано - срок из конфигурации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateInviteRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *CreateInviteRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CreateInviteRequest) GetMaxUses() int32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInviteRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invite        *Invite                `protobuf:"bytes,1,opt,name=invite,proto3" json:"invite,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // Код приглашения, возвращается единственный раз
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteResponse) Reset() {
+	*x = CreateInviteResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteResponse) ProtoMessage() {}
+
+func (x *CreateInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreateInviteResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateInviteResponse) GetInvite() *Invite {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+func (x *CreateInviteResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListInvitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesRequest) Reset() {
+	*x = ListInvitesRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesRequest) ProtoMessage() {}
+
+func (x *ListInvitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{30}
+}
+
+type ListInvitesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invites       []*Invite              `protobuf:"bytes,1,rep,name=invites,proto3" json:"invites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitesResponse) Reset() {
+	*x = ListInvitesResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitesResponse) ProtoMessage() {}
+
+func (x *ListInvitesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListInvitesResponse) GetInvites() []*Invite {
+	if x != nil {
+		return x.Invites
+	}
+	return nil
+}
+
+type RevokeInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InviteId      string                 `protobuf:"bytes,1,opt,name=invite_id,json=inviteId,proto3" json:"invite_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteRequest) Reset() {
+	*x = RevokeInviteRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteRequest) ProtoMessage() {}
+
+func (x *RevokeInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RevokeInviteRequest) GetInviteId() string {
+	if x != nil {
+		return x.InviteId
+	}
+	return ""
+}
+
+type RevokeInviteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteResponse) Reset() {
+	*x = RevokeInviteResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteResponse) ProtoMessage() {}
+
+func (x *RevokeInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInviteResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{33}
+}
+
+type Organisation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"` // Пусто, если создатель удален или это машинный клиент
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organisation) Reset() {
+	*x = Organisation{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organisation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organisation) ProtoMessage() {}
+
+func (x *Organisation) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organisation.ProtoReflect.Descriptor instead.
+func (*Organisation) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Organisation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *Organisation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organisation) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Organisation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type OrganisationMembership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organisation  *Organisation          `protobuf:"bytes,1,opt,name=organisation,proto3" json:"organisation,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"` // Роли пользователя в организации
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganisationMembership) Reset() {
+	*x = OrganisationMembership{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganisationMembership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganisationMembership) ProtoMessage() {}
+
+func (x *OrganisationMembership) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganisationMembership.ProtoReflect.Descriptor instead.
+func (*OrganisationMembership) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OrganisationMembership) GetOrganisation() *Organisation {
+	if x != nil {
+		return x.Organisation
+	}
+	return nil
+}
+
+func (x *OrganisationMembership) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *OrganisationMembership) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type OrganisationMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganisationMember) Reset() {
+	*x = OrganisationMember{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganisationMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganisationMember) ProtoMessage() {}
+
+func (x *OrganisationMember) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganisationMember.ProtoReflect.Descriptor instead.
+func (*OrganisationMember) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *OrganisationMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrganisationMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrganisationMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *OrganisationMember) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *OrganisationMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+func (x *OrganisationMember) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type OrganisationInvitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  string                 `protobuf:"bytes,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	OrgId         string                 `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`                          // Назначаются при принятии
+	InvitedBy     string                 `protobuf:"bytes,5,opt,name=invited_by,json=invitedBy,proto3" json:"invited_by,omitempty"` // Пусто, если пригласивший удален или это машинный клиент
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrganisationInvitation) Reset() {
+	*x = OrganisationInvitation{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrganisationInvitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrganisationInvitation) ProtoMessage() {}
+
+func (x *OrganisationInvitation) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrganisationInvitation.ProtoReflect.Descriptor instead.
+func (*OrganisationInvitation) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *OrganisationInvitation) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+func (x *OrganisationInvitation) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *OrganisationInvitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrganisationInvitation) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *OrganisationInvitation) GetInvitedBy() string {
+	if x != nil {
+		return x.InvitedBy
+	}
+	return ""
+}
+
+func (x *OrganisationInvitation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrganisationInvitation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreateOrganisationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	OwnerId       *string                `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"` // Не задано - владельцем становится вызывающий
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganisationRequest) Reset() {
+	*x = CreateOrganisationRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganisationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganisationRequest) ProtoMessage() {}
+
+func (x *CreateOrganisationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganisationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganisationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateOrganisationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrganisationRequest) GetOwnerId() string {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return ""
+}
+
+type ListMyOrganisationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyOrganisationsRequest) Reset() {
+	*x = ListMyOrganisationsRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyOrganisationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyOrganisationsRequest) ProtoMessage() {}
+
+func (x *ListMyOrganisationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyOrganisationsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyOrganisationsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{39}
+}
+
+type ListMyOrganisationsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Organisations []*OrganisationMembership `protobuf:"bytes,1,rep,name=organisations,proto3" json:"organisations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyOrganisationsResponse) Reset() {
+	*x = ListMyOrganisationsResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyOrganisationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyOrganisationsResponse) ProtoMessage() {}
+
+func (x *ListMyOrganisationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyOrganisationsResponse.ProtoReflect.Descriptor instead.
+func (*ListMyOrganisationsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListMyOrganisationsResponse) GetOrganisations() []*OrganisationMembership {
+	if x != nil {
+		return x.Organisations
+	}
+	return nil
+}
+
+type ListOrganisationMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganisationMembersRequest) Reset() {
+	*x = ListOrganisationMembersRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganisationMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganisationMembersRequest) ProtoMessage() {}
+
+func (x *ListOrganisationMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganisationMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganisationMembersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListOrganisationMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListOrganisationMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrganisationMember  `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganisationMembersResponse) Reset() {
+	*x = ListOrganisationMembersResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganisationMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganisationMembersResponse) ProtoMessage() {}
+
+func (x *ListOrganisationMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganisationMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganisationMembersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListOrganisationMembersResponse) GetMembers() []*OrganisationMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type InviteToOrganisationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"` // На этот адрес уходит ссылка; принять приглашение может только он
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"` // Роли из конфигурации organisations.roles или owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteToOrganisationRequest) Reset() {
+	*x = InviteToOrganisationRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteToOrganisationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteToOrganisationRequest) ProtoMessage() {}
+
+func (x *InviteToOrganisationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteToOrganisationRequest.ProtoReflect.Descriptor instead.
+func (*InviteToOrganisationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *InviteToOrganisationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *InviteToOrganisationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InviteToOrganisationRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type RevokeOrganisationInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	InvitationId  string                 `protobuf:"bytes,2,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOrganisationInvitationRequest) Reset() {
+	*x = RevokeOrganisationInvitationRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOrganisationInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOrganisationInvitationRequest) ProtoMessage() {}
+
+func (x *RevokeOrganisationInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOrganisationInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RevokeOrganisationInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RevokeOrganisationInvitationRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RevokeOrganisationInvitationRequest) GetInvitationId() string {
+	if x != nil {
+		return x.InvitationId
+	}
+	return ""
+}
+
+type RevokeOrganisationInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeOrganisationInvitationResponse) Reset() {
+	*x = RevokeOrganisationInvitationResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeOrganisationInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeOrganisationInvitationResponse) ProtoMessage() {}
+
+func (x *RevokeOrganisationInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeOrganisationInvitationResponse.ProtoReflect.Descriptor instead.
+func (*RevokeOrganisationInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{45}
+}
+
+type AcceptOrganisationInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Токен из ссылки в письме
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptOrganisationInvitationRequest) Reset() {
+	*x = AcceptOrganisationInvitationRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptOrganisationInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptOrganisationInvitationRequest) ProtoMessage() {}
+
+func (x *AcceptOrganisationInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptOrganisationInvitationRequest.ProtoReflect.Descriptor instead.
+func (*AcceptOrganisationInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AcceptOrganisationInvitationRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type UpdateOrganisationMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"` // Заменяют текущие роли участника
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrganisationMemberRequest) Reset() {
+	*x = UpdateOrganisationMemberRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrganisationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrganisationMemberRequest) ProtoMessage() {}
+
+func (x *UpdateOrganisationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrganisationMemberRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrganisationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateOrganisationMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *UpdateOrganisationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateOrganisationMemberRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type RemoveOrganisationMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Свой ID - выйти из организации
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganisationMemberRequest) Reset() {
+	*x = RemoveOrganisationMemberRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganisationMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganisationMemberRequest) ProtoMessage() {}
+
+func (x *RemoveOrganisationMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganisationMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrganisationMemberRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RemoveOrganisationMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RemoveOrganisationMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveOrganisationMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveOrganisationMemberResponse) Reset() {
+	*x = RemoveOrganisationMemberResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveOrganisationMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveOrganisationMemberResponse) ProtoMessage() {}
+
+func (x *RemoveOrganisationMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveOrganisationMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveOrganisationMemberResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{49}
+}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangePasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JwtToken      string                 `protobuf:"bytes,1,opt,name=jwt_token,json=jwtToken,proto3" json:"jwt_token,omitempty"` // Новый токен: все ранее выданные токены отозваны
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePasswordResponse) Reset() {
+	*x = ChangePasswordResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordResponse) ProtoMessage() {}
+
+func (x *ChangePasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordResponse.ProtoReflect.Descriptor instead.
+func (*ChangePasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ChangePasswordResponse) GetJwtToken() string {
+	if x != nil {
+		return x.JwtToken
+	}
+	return ""
+}
+
+type ChangeEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"` // Текущий пароль для подтверждения
+	NewEmail      string                 `protobuf:"bytes,2,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEmailRequest) Reset() {
+	*x = ChangeEmailRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEmailRequest) ProtoMessage() {}
+
+func (x *ChangeEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEmailRequest.ProtoReflect.Descriptor instead.
+func (*ChangeEmailRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ChangeEmailRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ChangeEmailRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+// Email меняется только после подтверждения по ссылке, отправленной на новый адрес
+type ChangeEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEmailResponse) Reset() {
+	*x = ChangeEmailResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEmailResponse) ProtoMessage() {}
+
+func (x *ChangeEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEmailResponse.ProtoReflect.Descriptor instead.
+func (*ChangeEmailResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{53}
+}
+
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Токен из письма на новый адрес
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ConfirmEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ConfirmEmailChangeResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	JwtToken        string                 `protobuf:"bytes,1,opt,name=jwt_token,json=jwtToken,proto3" json:"jwt_token,omitempty"`                       // Новый токен с обновленным email
+	PasswordExpired bool                   `protobuf:"varint,2,opt,name=password_expired,json=passwordExpired,proto3" json:"password_expired,omitempty"` // Срок действия пароля истек: jwt_token годится только для ChangePassword
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeResponse) Reset() {
+	*x = ConfirmEmailChangeResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ConfirmEmailChangeResponse) GetJwtToken() string {
+	if x != nil {
+		return x.JwtToken
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeResponse) GetPasswordExpired() bool {
+	if x != nil {
+		return x.PasswordExpired
+	}
+	return false
+}
+
+type UserProfile struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username       string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Roles          []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                       // active, suspended, disabled, pending_deletion
+	StatusReason   *string                `protobuf:"bytes,7,opt,name=status_reason,json=statusReason,proto3,oneof" json:"status_reason,omitempty"` // Причина блокировки
+	SuspendedUntil *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=suspended_until,json=suspendedUntil,proto3,oneof" json:"suspended_until,omitempty"`
+	LastLoginAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_login_at,json=lastLoginAt,proto3,oneof" json:"last_login_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserProfile) Reset() {
+	*x = UserProfile{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProfile) ProtoMessage() {}
+
+func (x *UserProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProfile.ProtoReflect.Descriptor instead.
+func (*UserProfile) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UserProfile) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserProfile) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserProfile) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *UserProfile) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserProfile) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UserProfile) GetStatusReason() string {
+	if x != nil && x.StatusReason != nil {
+		return *x.StatusReason
+	}
+	return ""
+}
+
+func (x *UserProfile) GetSuspendedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return nil
+}
+
+func (x *UserProfile) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+type GetMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeRequest) ProtoMessage() {}
+
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{57}
+}
+
+type GetUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserRequest) Reset() {
+	*x = GetUserRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserRequest) ProtoMessage() {}
+
+func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
+func (*GetUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state          protoimpl.MessageState  `protogen:"open.v1"`
+	EmailPrefix    *string                 `protobuf:"bytes,1,opt,name=email_prefix,json=emailPrefix,proto3,oneof" json:"email_prefix,omitempty"` // Поиск по началу email без учета регистра
+	Role           *string                 `protobuf:"bytes,2,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	Status         *string                 `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"` // active, suspended, disabled, pending_deletion
+	CreatedFrom    *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3,oneof" json:"created_from,omitempty"`
+	CreatedTo      *timestamppb.Timestamp  `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3,oneof" json:"created_to,omitempty"`
+	LastLoginFrom  *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=last_login_from,json=lastLoginFrom,proto3,oneof" json:"last_login_from,omitempty"`
+	LastLoginTo    *timestamppb.Timestamp  `protobuf:"bytes,7,opt,name=last_login_to,json=lastLoginTo,proto3,oneof" json:"last_login_to,omitempty"`
+	SortBy         ListUsersRequest_SortBy `protobuf:"varint,8,opt,name=sort_by,json=sortBy,proto3,enum=api.AuthService.ListUsersRequest_SortBy" json:"sort_by,omitempty"`
+	Descending     bool                    `protobuf:"varint,9,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize       int32                   `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // По умолчанию 50, максимум 500
+	Cursor         string                  `protobuf:"bytes,11,opt,name=cursor,proto3" json:"cursor,omitempty"`                      // next_cursor из предыдущего ответа
+	WithTotalCount bool                    `protobuf:"varint,12,opt,name=with_total_count,json=withTotalCount,proto3" json:"with_total_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListUsersRequest) GetEmailPrefix() string {
+	if x != nil && x.EmailPrefix != nil {
+		return *x.EmailPrefix
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLastLoginFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginFrom
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLastLoginTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginTo
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetSortBy() ListUsersRequest_SortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return ListUsersRequest_CREATED_AT
+}
+
+func (x *ListUsersRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetWithTotalCount() bool {
+	if x != nil {
+		return x.WithTotalCount
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserProfile         `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`  // Пустой - страниц больше нет
+	TotalCount    int64                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Заполняется при with_total_count
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserProfile {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type UpdateUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUsernameRequest) Reset() {
+	*x = UpdateUsernameRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUsernameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUsernameRequest) ProtoMessage() {}
+
+func (x *UpdateUsernameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUsernameRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUsernameRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *UpdateUsernameRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      string                 `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"` // Текущий пароль; аккаунтам без пароля нужен вход не ранее reauth_window назад
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DeleteAccountRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type DeleteAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgeAfter    *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=purge_after,json=purgeAfter,proto3" json:"purge_after,omitempty"` // Время окончательного удаления данных
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteAccountResponse) GetPurgeAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAfter
+	}
+	return nil
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{64}
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExportDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"` // Все данные пользователя в JSON
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDataResponse) Reset() {
+	*x = ExportDataResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDataResponse) ProtoMessage() {}
+
+func (x *ExportDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportDataResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ExportDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SuspendUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3,oneof" json:"until,omitempty"` // Без until блокировка бессрочная
+	Disable       bool                   `protobuf:"varint,4,opt,name=disable,proto3" json:"disable,omitempty"`  // Отключить аккаунт полностью (until игнорируется)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserRequest) ProtoMessage() {}
+
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SuspendUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *SuspendUserRequest) GetDisable() bool {
+	if x != nil {
+		return x.Disable
+	}
+	return false
+}
+
+type ReactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserRequest) Reset() {
+	*x = ReactivateUserRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserRequest) ProtoMessage() {}
+
+func (x *ReactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ReactivateUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactivateUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Data          []byte                    `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Format        ImportUsersRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=api.AuthService.ImportUsersRequest_Format" json:"format,omitempty"`
+	DryRun        bool                      `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Проверить и посчитать изменения без записи
+	Overwrite     bool                      `protobuf:"varint,4,opt,name=overwrite,proto3" json:"overwrite,omitempty"`         // Заменить пароли существующих пользователей и отозвать их токены
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ImportUsersRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetFormat() ImportUsersRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return ImportUsersRequest_CSV
+}
+
+func (x *ImportUsersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportUsersRequest) GetOverwrite() bool {
+	if x != nil {
+		return x.Overwrite
+	}
+	return false
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ImportRowError) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Created       int32                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Updated       int32                  `protobuf:"varint,4,opt,name=updated,proto3" json:"updated,omitempty"`
+	Unchanged     int32                  `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Failed        int32                  `protobuf:"varint,6,opt,name=failed,proto3" json:"failed,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,7,rep,name=errors,proto3" json:"errors,omitempty"` // Только строки с ошибками
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ImportUsersResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportUsersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetUnchanged() int32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetRegistrationChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegistrationChallengeRequest) Reset() {
+	*x = GetRegistrationChallengeRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegistrationChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegistrationChallengeRequest) ProtoMessage() {}
+
+func (x *GetRegistrationChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegistrationChallengeRequest.ProtoReflect.Descriptor instead.
+func (*GetRegistrationChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{72}
+}
+
+// Способы пройти испытание; клиент выбирает любой из предложенных
+type RegistrationChallenge struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Required        bool                   `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	CaptchaProvider string                 `protobuf:"bytes,2,opt,name=captcha_provider,json=captchaProvider,proto3" json:"captcha_provider,omitempty"` // hcaptcha или recaptcha; пусто - капча не настроена
+	CaptchaSiteKey  string                 `protobuf:"bytes,3,opt,name=captcha_site_key,json=captchaSiteKey,proto3" json:"captcha_site_key,omitempty"`  // Ключ сайта для виджета капчи
+	ProofOfWork     *ProofOfWorkChallenge  `protobuf:"bytes,4,opt,name=proof_of_work,json=proofOfWork,proto3,oneof" json:"proof_of_work,omitempty"`     // Для клиентов без виджета капчи
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RegistrationChallenge) Reset() {
+	*x = RegistrationChallenge{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationChallenge) ProtoMessage() {}
+
+func (x *RegistrationChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationChallenge.ProtoReflect.Descriptor instead.
+func (*RegistrationChallenge) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RegistrationChallenge) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *RegistrationChallenge) GetCaptchaProvider() string {
+	if x != nil {
+		return x.CaptchaProvider
+	}
+	return ""
+}
+
+func (x *RegistrationChallenge) GetCaptchaSiteKey() string {
+	if x != nil {
+		return x.CaptchaSiteKey
+	}
+	return ""
+}
+
+func (x *RegistrationChallenge) GetProofOfWork() *ProofOfWorkChallenge {
+	if x != nil {
+		return x.ProofOfWork
+	}
+	return nil
+}
+
+// Нужно найти nonce, при котором SHA-256(challenge + ":" + nonce) начинается с difficulty нулевых бит
+type ProofOfWorkChallenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     string                 `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Difficulty    uint32                 `protobuf:"varint,2,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProofOfWorkChallenge) Reset() {
+	*x = ProofOfWorkChallenge{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofOfWorkChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofOfWorkChallenge) ProtoMessage() {}
+
+func (x *ProofOfWorkChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofOfWorkChallenge.ProtoReflect.Descriptor instead.
+func (*ProofOfWorkChallenge) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ProofOfWorkChallenge) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+func (x *ProofOfWorkChallenge) GetDifficulty() uint32 {
+	if x != nil {
+		return x.Difficulty
+	}
+	return 0
+}
+
+func (x *ProofOfWorkChallenge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ChallengeSolution struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Solution:
+	//
+	//	*ChallengeSolution_CaptchaToken
+	//	*ChallengeSolution_ProofOfWork
+	Solution      isChallengeSolution_Solution `protobuf_oneof:"solution"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChallengeSolution) Reset() {
+	*x = ChallengeSolution{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengeSolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeSolution) ProtoMessage() {}
+
+func (x *ChallengeSolution) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeSolution.ProtoReflect.Descriptor instead.
+func (*ChallengeSolution) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ChallengeSolution) GetSolution() isChallengeSolution_Solution {
+	if x != nil {
+		return x.Solution
+	}
+	return nil
+}
+
+func (x *ChallengeSolution) GetCaptchaToken() string {
+	if x != nil {
+		if x, ok := x.Solution.(*ChallengeSolution_CaptchaToken); ok {
+			return x.CaptchaToken
+		}
+	}
+	return ""
+}
+
+func (x *ChallengeSolution) GetProofOfWork() *ProofOfWorkSolution {
+	if x != nil {
+		if x, ok := x.Solution.(*ChallengeSolution_ProofOfWork); ok {
+			return x.ProofOfWork
+		}
+	}
+	return nil
+}
+
+type isChallengeSolution_Solution interface {
+	isChallengeSolution_Solution()
+}
+
+type ChallengeSolution_CaptchaToken struct {
+	CaptchaToken string `protobuf:"bytes,1,opt,name=captcha_token,json=captchaToken,proto3,oneof"` // Ответ виджета капчи
+}
+
+type ChallengeSolution_ProofOfWork struct {
+	ProofOfWork *ProofOfWorkSolution `protobuf:"bytes,2,opt,name=proof_of_work,json=proofOfWork,proto3,oneof"`
+}
+
+func (*ChallengeSolution_CaptchaToken) isChallengeSolution_Solution() {}
+
+func (*ChallengeSolution_ProofOfWork) isChallengeSolution_Solution() {}
+
+type ProofOfWorkSolution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenge     string                 `protobuf:"bytes,1,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	Nonce         string                 `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProofOfWorkSolution) Reset() {
+	*x = ProofOfWorkSolution{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProofOfWorkSolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofOfWorkSolution) ProtoMessage() {}
+
+func (x *ProofOfWorkSolution) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofOfWorkSolution.ProtoReflect.Descriptor instead.
+func (*ProofOfWorkSolution) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ProofOfWorkSolution) GetChallenge() string {
+	if x != nil {
+		return x.Challenge
+	}
+	return ""
+}
+
+func (x *ProofOfWorkSolution) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type GetPasswordPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPasswordPolicyRequest) Reset() {
+	*x = GetPasswordPolicyRequest{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPasswordPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPasswordPolicyRequest) ProtoMessage() {}
+
+func (x *GetPasswordPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPasswordPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetPasswordPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{77}
+}
+
+type PasswordPolicy struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	MinLength           int32                  `protobuf:"varint,1,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`
+	MaxLength           int32                  `protobuf:"varint,2,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"` // 0 - без ограничения
+	MinUniqueChars      int32                  `protobuf:"varint,3,opt,name=min_unique_chars,json=minUniqueChars,proto3" json:"min_unique_chars,omitempty"`
+	RequireUpper        bool                   `protobuf:"varint,4,opt,name=require_upper,json=requireUpper,proto3" json:"require_upper,omitempty"`
+	RequireLower        bool                   `protobuf:"varint,5,opt,name=require_lower,json=requireLower,proto3" json:"require_lower,omitempty"`
+	RequireNumber       bool                   `protobuf:"varint,6,opt,name=require_number,json=requireNumber,proto3" json:"require_number,omitempty"`
+	RequireSpecial      bool                   `protobuf:"varint,7,opt,name=require_special,json=requireSpecial,proto3" json:"require_special,omitempty"`
+	DisallowedSequences []string               `protobuf:"bytes,8,rep,name=disallowed_sequences,json=disallowedSequences,proto3" json:"disallowed_sequences,omitempty"` // Запрещены sequence_length подряд символов любой строки, в том числе в обратном порядке
+	SequenceLength      int32                  `protobuf:"varint,9,opt,name=sequence_length,json=sequenceLength,proto3" json:"sequence_length,omitempty"`
+	HistoryDepth        int32                  `protobuf:"varint,10,opt,name=history_depth,json=historyDepth,proto3" json:"history_depth,omitempty"`      // Сколько прежних паролей нельзя использовать повторно
+	MaxAgeSeconds       int64                  `protobuf:"varint,11,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"` // Срок действия пароля; 0 - бессрочно
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PasswordPolicy) Reset() {
+	*x = PasswordPolicy{}
+	mi := &file_auth_service_auth_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PasswordPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PasswordPolicy) ProtoMessage() {}
+
+func (x *PasswordPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_service_auth_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PasswordPolicy.ProtoReflect.Descriptor instead.
+func (*PasswordPolicy) Descriptor() ([]byte, []int) {
+	return file_auth_service_auth_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *PasswordPolicy) GetMinLength() int32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetMinUniqueChars() int32 {
+	if x != nil {
+		return x.MinUniqueChars
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetRequireUpper() bool {
+	if x != nil {
+		return x.RequireUpper
+	}
+	return false
+}
+
+func (x *PasswordPolicy) GetRequireLower() bool {
+	if x != nil {
+		return x.RequireLower
+	}
+	return false
+}
+
+func (x *PasswordPolicy) GetRequireNumber() bool {
+	if x != nil {
+		return x.RequireNumber
+	}
+	return false
+}
+
+func (x *PasswordPolicy) GetRequireSpecial() bool {
+	if x != nil {
+		return x.RequireSpecial
+	}
+	return false
+}
+
+func (x *PasswordPolicy) GetDisallowedSequences() []string {
+	if x != nil {
+		return x.DisallowedSequences
+	}
+	return nil
+}
+
+func (x *PasswordPolicy) GetSequenceLength() int32 {
+	if x != nil {
+		return x.SequenceLength
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetHistoryDepth() int32 {
+	if x != nil {
+		return x.HistoryDepth
+	}
+	return 0
+}
+
+func (x *PasswordPolicy) GetMaxAgeSeconds() int64 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+var File_auth_service_auth_service_proto protoreflect.FileDescriptor
+
+const file_auth_service_auth_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1fauth-service/auth-service.proto\x12\x0fapi.AuthService\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/rpc/status.proto\"\r\n" +
+	"\vPingRequest\"\x1e\n" +
+	"\fPingResponse\x12\x0e\n" +
+	"\x02ok\x18\x01 \x01(\bR\x02ok\"\xce\x01\n" +
+	"\x0fRegisterRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12E\n" +
+	"\tchallenge\x18\x03 \x01(\v2\".api.AuthService.ChallengeSolutionH\x00R\tchallenge\x88\x01\x01\x12$\n" +
+	"\vinvite_code\x18\x04 \x01(\tH\x01R\n" +
+	"inviteCode\x88\x01\x01B\f\n" +
+	"\n" +
+	"_challengeB\x0e\n" +
+	"\f_invite_code\"h\n" +
+	"\x10RegisterResponse\x12\x1b\n" +
+	"\tjwt_token\x18\x01 \x01(\tR\bjwtToken\x12-\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.google.rpc.StatusH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\x95\x01\n" +
+	"\fLoginRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12 \n" +
+	"\tclient_id\x18\x03 \x01(\tH\x00R\bclientId\x88\x01\x01\x12\x19\n" +
+	"\x05nonce\x18\x04 \x01(\tH\x01R\x05nonce\x88\x01\x01B\f\n" +
+	"\n" +
+	"_client_idB\b\n" +
+	"\x06_nonce\"\x84\x01\n" +
+	"\rLoginResponse\x12\x1b\n" +
+	"\tjwt_token\x18\x02 \x01(\tR\bjwtToken\x12\x1e\n" +
+	"\bid_token\x18\x03 \x01(\tH\x00R\aidToken\x88\x01\x01\x12)\n" +
+	"\x10password_expired\x18\x04 \x01(\bR\x0fpasswordExpiredB\v\n" +
+	"\t_id_token\"v\n" +
+	"\x18LoginWithProviderRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
+	"\bid_token\x18\x02 \x01(\tR\aidToken\x12\x19\n" +
+	"\x05nonce\x18\x03 \x01(\tH\x00R\x05nonce\x88\x01\x01B\b\n" +
+	"\x06_nonce\"/\n" +
+	"\x17RequestMagicLinkRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1a\n" +
+	"\x18RequestMagicLinkResponse\"/\n" +
+	"\x17ConsumeMagicLinkRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\",\n" +
+	"\x10AuthorizeRequest\x12\x18\n" +
+	"\arequest\x18\x01 \x01(\tR\arequest\"6\n" +
+	"\x11AuthorizeResponse\x12!\n" +
+	"\fredirect_uri\x18\x01 \x01(\tR\vredirectUri\"l\n" +
+	"\x12VerifyTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12,\n" +
+	"\x0forganisation_id\x18\x02 \x01(\tH\x00R\x0eorganisationId\x88\x01\x01B\x12\n" +
+	"\x10_organisation_id\"\x94\x02\n" +
+	"\x13VerifyTokenResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12-\n" +
+	"\x05error\x18\x05 \x01(\v2\x12.google.rpc.StatusH\x00R\x05error\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"is_service\x18\x06 \x01(\bR\tisService\x12\x1b\n" +
+	"\ttenant_id\x18\a \x01(\tR\btenantId\x12-\n" +
+	"\x12organisation_roles\x18\b \x03(\tR\x11organisationRolesB\b\n" +
+	"\x06_error\"\\\n" +
+	"\x18IssueServiceTokenRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\"|\n" +
+	"\x19IssueServiceTokenResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x02 \x01(\tR\ttokenType\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\x03R\texpiresIn\"F\n" +
+	"\x1aCreateServiceClientRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\"_\n" +
+	"\x1bCreateServiceClientResponse\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\"9\n" +
+	"\x1aRevokeServiceClientRequest\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\"\x1d\n" +
+	"\x1bRevokeServiceClientResponse\"\xbf\x02\n" +
+	"\x06ApiKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01\x12A\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"lastUsedAt\x88\x01\x01B\r\n" +
+	"\v_expires_atB\x0f\n" +
+	"\r_last_used_at\"\x8e\x01\n" +
+	"\x13CreateApiKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12>\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"Y\n" +
+	"\x14CreateApiKeyResponse\x12)\n" +
+	"\x03key\x18\x01 \x01(\v2\x17.api.AuthService.ApiKeyR\x03key\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x14\n" +
+	"\x12ListApiKeysRequest\"B\n" +
+	"\x13ListApiKeysResponse\x12+\n" +
+	"\x04keys\x18\x01 \x03(\v2\x17.api.AuthService.ApiKeyR\x04keys\",\n" +
+	"\x13RevokeApiKeyRequest\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\tR\x05keyId\"\x16\n" +
+	"\x14RevokeApiKeyResponse\"\xa9\x02\n" +
+	"\x06Invite\x12\x1b\n" +
+	"\tinvite_id\x18\x01 \x01(\tR\binviteId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x19\n" +
+	"\bmax_uses\x18\x04 \x01(\x05R\amaxUses\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x06 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\texpiresAt\x88\x01\x01B\r\n" +
+	"\v_expires_at\"\xba\x01\n" +
+	"\x13CreateInviteRequest\x12\x19\n" +
+	"\x05email\x18\x01 \x01(\tH\x00R\x05email\x88\x01\x01\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12\x19\n" +
+	"\bmax_uses\x18\x03 \x01(\x05R\amaxUses\x12>\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x01R\texpiresAt\x88\x01\x01B\b\n" +
+	"\x06_emailB\r\n" +
+	"\v_expires_at\"[\n" +
+	"\x14CreateInviteResponse\x12/\n" +
+	"\x06invite\x18\x01 \x01(\v2\x17.api.AuthService.InviteR\x06invite\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x14\n" +
+	"\x12ListInvitesRequest\"H\n" +
+	"\x13ListInvitesResponse\x121\n" +
+	"\ainvites\x18\x01 \x03(\v2\x17.api.AuthService.InviteR\ainvites\"2\n" +
+	"\x13RevokeInviteRequest\x12\x1b\n" +
+	"\tinvite_id\x18\x01 \x01(\tR\binviteId\"\x16\n" +
+	"\x14RevokeInviteResponse\"\x93\x01\n" +
+	"\fOrganisation\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaa\x01\n" +
+	"\x16OrganisationMembership\x12A\n" +
+	"\forganisation\x18\x01 \x01(\v2\x1d.api.AuthService.OrganisationR\forganisation\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x127\n" +
+	"\tjoined_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"\xe9\x01\n" +
+	"\x12OrganisationMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x127\n" +
+	"\tjoined_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x95\x02\n" +
+	"\x16OrganisationInvitation\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\tR\finvitationId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x12\x1d\n" +
+	"\n" +
+	"invited_by\x18\x05 \x01(\tR\tinvitedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\\\n" +
+	"\x19CreateOrganisationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\bowner_id\x18\x02 \x01(\tH\x00R\aownerId\x88\x01\x01B\v\n" +
+	"\t_owner_id\"\x1c\n" +
+	"\x1aListMyOrganisationsRequest\"l\n" +
+	"\x1bListMyOrganisationsResponse\x12M\n" +
+	"\rorganisations\x18\x01 \x03(\v2'.api.AuthService.OrganisationMembershipR\rorganisations\"7\n" +
+	"\x1eListOrganisationMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"`\n" +
+	"\x1fListOrganisationMembersResponse\x12=\n" +
+	"\amembers\x18\x01 \x03(\v2#.api.AuthService.OrganisationMemberR\amembers\"`\n" +
+	"\x1bInviteToOrganisationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\"a\n" +
+	"#RevokeOrganisationInvitationRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12#\n" +
+	"\rinvitation_id\x18\x02 \x01(\tR\finvitationId\"&\n" +
+	"$RevokeOrganisationInvitationResponse\";\n" +
+	"#AcceptOrganisationInvitationRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"g\n" +
+	"\x1fUpdateOrganisationMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\"Q\n" +
+	"\x1fRemoveOrganisationMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\"\n" +
+	" RemoveOrganisationMemberResponse\"e\n" +
+	"\x15ChangePasswordRequest\x12)\n" +
+	"\x10current_password\x18\x01 \x01(\tR\x0fcurrentPassword\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"5\n" +
+	"\x16ChangePasswordResponse\x12\x1b\n" +
+	"\tjwt_token\x18\x01 \x01(\tR\bjwtToken\"M\n" +
+	"\x12ChangeEmailRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\x12\x1b\n" +
+	"\tnew_email\x18\x02 \x01(\tR\bnewEmail\"\x15\n" +
+	"\x13ChangeEmailResponse\"1\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"d\n" +
+	"\x1aConfirmEmailChangeResponse\x12\x1b\n" +
+	"\tjwt_token\x18\x01 \x01(\tR\bjwtToken\x12)\n" +
+	"\x10password_expired\x18\x02 \x01(\bR\x0fpasswordExpired\"\xb2\x03\n" +
+	"\vUserProfile\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x04 \x03(\tR\x05roles\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12(\n" +
+	"\rstatus_reason\x18\a \x01(\tH\x00R\fstatusReason\x88\x01\x01\x12H\n" +
+	"\x0fsuspended_until\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x01R\x0esuspendedUntil\x88\x01\x01\x12C\n" +
+	"\rlast_login_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x02R\vlastLoginAt\x88\x01\x01B\x10\n" +
+	"\x0e_status_reasonB\x12\n" +
+	"\x10_suspended_untilB\x10\n" +
+	"\x0e_last_login_at\"\x0e\n" +
+	"\fGetMeRequest\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xe7\x05\n" +
+	"\x10ListUsersRequest\x12&\n" +
+	"\femail_prefix\x18\x01 \x01(\tH\x00R\vemailPrefix\x88\x01\x01\x12\x17\n" +
+	"\x04role\x18\x02 \x01(\tH\x01R\x04role\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x03 \x01(\tH\x02R\x06status\x88\x01\x01\x12B\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x03R\vcreatedFrom\x88\x01\x01\x12>\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x04R\tcreatedTo\x88\x01\x01\x12G\n" +
+	"\x0flast_login_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x05R\rlastLoginFrom\x88\x01\x01\x12C\n" +
+	"\rlast_login_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x06R\vlastLoginTo\x88\x01\x01\x12A\n" +
+	"\asort_by\x18\b \x01(\x0e2(.api.AuthService.ListUsersRequest.SortByR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\t \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\v \x01(\tR\x06cursor\x12(\n" +
+	"\x10with_total_count\x18\f \x01(\bR\x0ewithTotalCount\"6\n" +
+	"\x06SortBy\x12\x0e\n" +
+	"\n" +
+	"CREATED_AT\x10\x00\x12\t\n" +
+	"\x05EMAIL\x10\x01\x12\x11\n" +
+	"\rLAST_LOGIN_AT\x10\x02B\x0f\n" +
+	"\r_email_prefixB\a\n" +
+	"\x05_roleB\t\n" +
+	"\a_statusB\x0f\n" +
+	"\r_created_fromB\r\n" +
+	"\v_created_toB\x12\n" +
+	"\x10_last_login_fromB\x10\n" +
+	"\x0e_last_login_to\"\x89\x01\n" +
+	"\x11ListUsersResponse\x122\n" +
+	"\x05users\x18\x01 \x03(\v2\x1c.api.AuthService.UserProfileR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x03R\n" +
+	"totalCount\"3\n" +
+	"\x15UpdateUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"2\n" +
+	"\x14DeleteAccountRequest\x12\x1a\n" +
+	"\bpassword\x18\x01 \x01(\tR\bpassword\"T\n" +
+	"\x15DeleteAccountResponse\x12;\n" +
+	"\vpurge_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"purgeAfter\"\x15\n" +
+	"\x13ExportMyDataRequest\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"(\n" +
+	"\x12ExportDataResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xa0\x01\n" +
+	"\x12SuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x125\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x05until\x88\x01\x01\x12\x18\n" +
+	"\adisable\x18\x04 \x01(\bR\adisableB\b\n" +
+	"\x06_until\"H\n" +
+	"\x15ReactivateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xc1\x01\n" +
+	"\x12ImportUsersRequest\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12B\n" +
+	"\x06format\x18\x02 \x01(\x0e2*.api.AuthService.ImportUsersRequest.FormatR\x06format\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\toverwrite\x18\x04 \x01(\bR\toverwrite\"\x1c\n" +
+	"\x06Format\x12\a\n" +
+	"\x03CSV\x10\x00\x12\t\n" +
+	"\x05JSONL\x10\x01\"P\n" +
+	"\x0eImportRowError\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"\xe7\x01\n" +
+	"\x13ImportUsersResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x04 \x01(\x05R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\x05 \x01(\x05R\tunchanged\x12\x16\n" +
+	"\x06failed\x18\x06 \x01(\x05R\x06failed\x127\n" +
+	"\x06errors\x18\a \x03(\v2\x1f.api.AuthService.ImportRowErrorR\x06errors\"!\n" +
+	"\x1fGetRegistrationChallengeRequest\"\xea\x01\n" +
+	"\x15RegistrationChallenge\x12\x1a\n" +
+	"\brequired\x18\x01 \x01(\bR\brequired\x12)\n" +
+	"\x10captcha_provider\x18\x02 \x01(\tR\x0fcaptchaProvider\x12(\n" +
+	"\x10captcha_site_key\x18\x03 \x01(\tR\x0ecaptchaSiteKey\x12N\n" +
+	"\rproof_of_work\x18\x04 \x01(\v2%.api.AuthService.ProofOfWorkChallengeH\x00R\vproofOfWork\x88\x01\x01B\x10\n" +
+	"\x0e_proof_of_work\"\x8f\x01\n" +
+	"\x14ProofOfWorkChallenge\x12\x1c\n" +
+	"\tchallenge\x18\x01 \x01(\tR\tchallenge\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x02 \x01(\rR\n" +
+	"difficulty\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x92\x01\n" +
+	"\x11ChallengeSolution\x12%\n" +
+	"\rcaptcha_token\x18\x01 \x01(\tH\x00R\fcaptchaToken\x12J\n" +
+	"\rproof_of_work\x18\x02 \x01(\v2$.api.AuthService.ProofOfWorkSolutionH\x00R\vproofOfWorkB\n" +
+	"\n" +
+	"\bsolution\"I\n" +
+	"\x13ProofOfWorkSolution\x12\x1c\n" +
+	"\tchallenge\x18\x01 \x01(\tR\tchallenge\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\tR\x05nonce\"\x1a\n" +
+	"\x18GetPasswordPolicyRequest\"\xbb\x03\n" +
+	"\x0ePasswordPolicy\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\x01 \x01(\x05R\tminLength\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x02 \x01(\x05R\tmaxLength\x12(\n" +
+	"\x10min_unique_chars\x18\x03 \x01(\x05R\x0eminUniqueChars\x12#\n" +
+	"\rrequire_upper\x18\x04 \x01(\bR\frequireUpper\x12#\n" +
+	"\rrequire_lower\x18\x05 \x01(\bR\frequireLower\x12%\n" +
+	"\x0erequire_number\x18\x06 \x01(\bR\rrequireNumber\x12'\n" +
+	"\x0frequire_special\x18\a \x01(\bR\x0erequireSpecial\x121\n" +
+	"\x14disallowed_sequences\x18\b \x03(\tR\x13disallowedSequences\x12'\n" +
+	"\x0fsequence_length\x18\t \x01(\x05R\x0esequenceLength\x12#\n" +
+	"\rhistory_depth\x18\n" +
+	" \x01(\x05R\fhistoryDepth\x12&\n" +
+	"\x0fmax_age_seconds\x18\v \x01(\x03R\rmaxAgeSeconds2\xa7\x1f\n" +
+	"\vAuthService\x12E\n" +
+	"\x04Ping\x12\x1c.api.AuthService.PingRequest\x1a\x1d.api.AuthService.PingResponse\"\x00\x12Q\n" +
+	"\bRegister\x12 .api.AuthService.RegisterRequest\x1a!.api.AuthService.RegisterResponse\"\x00\x12H\n" +
+	"\x05Login\x12\x1d.api.AuthService.LoginRequest\x1a\x1e.api.AuthService.LoginResponse\"\x00\x12Z\n" +
+	"\vVerifyToken\x12#.api.AuthService.VerifyTokenRequest\x1a$.api.AuthService.VerifyTokenResponse\"\x00\x12a\n" +
+	"\x11GetPasswordPolicy\x12).api.AuthService.GetPasswordPolicyRequest\x1a\x1f.api.AuthService.PasswordPolicy\"\x00\x12v\n" +
+	"\x18GetRegistrationChallenge\x120.api.AuthService.GetRegistrationChallengeRequest\x1a&.api.AuthService.RegistrationChallenge\"\x00\x12`\n" +
+	"\x11LoginWithProvider\x12).api.AuthService.LoginWithProviderRequest\x1a\x1e.api.AuthService.LoginResponse\"\x00\x12i\n" +
+	"\x10RequestMagicLink\x12(.api.AuthService.RequestMagicLinkRequest\x1a).api.AuthService.RequestMagicLinkResponse\"\x00\x12^\n" +
+	"\x10ConsumeMagicLink\x12(.api.AuthService.ConsumeMagicLinkRequest\x1a\x1e.api.AuthService.LoginResponse\"\x00\x12T\n" +
+	"\tAuthorize\x12!.api.AuthService.AuthorizeRequest\x1a\".api.AuthService.AuthorizeResponse\"\x00\x12l\n" +
+	"\x11IssueServiceToken\x12).api.AuthService.IssueServiceTokenRequest\x1a*.api.AuthService.IssueServiceTokenResponse\"\x00\x12r\n" +
+	"\x13CreateServiceClient\x12+.api.AuthService.CreateServiceClientRequest\x1a,.api.AuthService.CreateServiceClientResponse\"\x00\x12r\n" +
+	"\x13RevokeServiceClient\x12+.api.AuthService.RevokeServiceClientRequest\x1a,.api.AuthService.RevokeServiceClientResponse\"\x00\x12F\n" +
+	"\x05GetMe\x12\x1d.api.AuthService.GetMeRequest\x1a\x1c.api.AuthService.UserProfile\"\x00\x12J\n" +
+	"\aGetUser\x12\x1f.api.AuthService.GetUserRequest\x1a\x1c.api.AuthService.UserProfile\"\x00\x12T\n" +
+	"\tListUsers\x12!.api.AuthService.ListUsersRequest\x1a\".api.AuthService.ListUsersResponse\"\x00\x12X\n" +
+	"\x0eUpdateUsername\x12&.api.AuthService.UpdateUsernameRequest\x1a\x1c.api.AuthService.UserProfile\"\x00\x12R\n" +
+	"\vSuspendUser\x12#.api.AuthService.SuspendUserRequest\x1a\x1c.api.AuthService.UserProfile\"\x00\x12X\n" +
+	"\x0eReactivateUser\x12&.api.AuthService.ReactivateUserRequest\x1a\x1c.api.AuthService.UserProfile\"\x00\x12c\n" +
+	"\x0eChangePassword\x12&.api.AuthService.ChangePasswordRequest\x1a'.api.AuthService.ChangePasswordResponse\"\x00\x12Z\n" +
+	"\vChangeEmail\x12#.api.AuthService.ChangeEmailRequest\x1a$.api.AuthService.ChangeEmailResponse\"\x00\x12o\n" +
+	"\x12ConfirmEmailChange\x12*.api.AuthService.ConfirmEmailChangeRequest\x1a+.api.AuthService.ConfirmEmailChangeResponse\"\x00\x12`\n" +
+	"\rDeleteAccount\x12%.api.AuthService.DeleteAccountRequest\x1a&.api.AuthService.DeleteAccountResponse\"\x00\x12[\n" +
+	"\fExportMyData\x12$.api.AuthService.ExportMyDataRequest\x1a#.api.AuthService.ExportDataResponse\"\x00\x12_\n" +
+	"\x0eExportUserData\x12&.api.AuthService.ExportUserDataRequest\x1a#.api.AuthService.ExportDataResponse\"\x00\x12]\n" +
+	"\fCreateApiKey\x12$.api.AuthService.CreateApiKeyRequest\x1a%.api.AuthService.CreateApiKeyResponse\"\x00\x12Z\n" +
+	"\vListApiKeys\x12#.api.AuthService.ListApiKeysRequest\x1a$.api.AuthService.ListApiKeysResponse\"\x00\x12]\n" +
+	"\fRevokeApiKey\x12$.api.AuthService.RevokeApiKeyRequest\x1a%.api.AuthService.RevokeApiKeyResponse\"\x00\x12]\n" +
+	"\fCreateInvite\x12$.api.AuthService.CreateInviteRequest\x1a%.api.AuthService.CreateInviteResponse\"\x00\x12Z\n" +
+	"\vListInvites\x12#.api.AuthService.ListInvitesRequest\x1a$.api.AuthService.ListInvitesResponse\"\x00\x12]\n" +
+	"\fRevokeInvite\x12$.api.AuthService.RevokeInviteRequest\x1a%.api.AuthService.RevokeInviteResponse\"\x00\x12Z\n" +
+	"\vImportUsers\x12#.api.AuthService.ImportUsersRequest\x1a$.api.AuthService.ImportUsersResponse\"\x00\x12a\n" +
+	"\x12CreateOrganisation\x12*.api.AuthService.CreateOrganisationRequest\x1a\x1d.api.AuthService.Organisation\"\x00\x12r\n" +
+	"\x13ListMyOrganisations\x12+.api.AuthService.ListMyOrganisationsRequest\x1a,.api.AuthService.ListMyOrganisationsResponse\"\x00\x12~\n" +
+	"\x17ListOrganisationMembers\x12/.api.AuthService.ListOrganisationMembersRequest\x1a0.api.AuthService.ListOrganisationMembersResponse\"\x00\x12o\n" +
+	"\x14InviteToOrganisation\x12,.api.AuthService.InviteToOrganisationRequest\x1a'.api.AuthService.OrganisationInvitation\"\x00\x12\x8d\x01\n" +
+	"\x1cRevokeOrganisationInvitation\x124.api.AuthService.RevokeOrganisationInvitationRequest\x1a5.api.AuthService.RevokeOrganisationInvitationResponse\"\x00\x12\x7f\n" +
+	"\x1cAcceptOrganisationInvitation\x124.api.AuthService.AcceptOrganisationInvitationRequest\x1a'.api.AuthService.OrganisationMembership\"\x00\x12s\n" +
+	"\x18UpdateOrganisationMember\x120.api.AuthService.UpdateOrganisationMemberRequest\x1a#.api.AuthService.OrganisationMember\"\x00\x12\x81\x01\n" +
+	"\x18RemoveOrganisationMember\x120.api.AuthService.RemoveOrganisationMemberRequest\x1a1.api.AuthService.RemoveOrganisationMemberResponse\"\x00B?Z=github.com/mussyaroslav/auth-service/generate/api.authserviceb\x06proto3"
+
+var (
+	file_auth_service_auth_service_proto_rawDescOnce sync.Once
+	file_auth_service_auth_service_proto_rawDescData []byte
+)
+
+func file_auth_service_auth_service_proto_rawDescGZIP() []byte {
+	file_auth_service_auth_service_proto_rawDescOnce.Do(func() {
+		file_auth_service_auth_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_service_auth_service_proto_rawDesc), len(file_auth_service_auth_service_proto_rawDesc)))
+	})
+	return file_auth_service_auth_service_proto_rawDescData
+}
+
+var file_auth_service_auth_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_auth_service_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 79)
+var file_auth_service_auth_service_proto_goTypes = []any{
+	(ListUsersRequest_SortBy)(0),                 // 0: api.AuthService.ListUsersRequest.SortBy
+	(ImportUsersRequest_Format)(0),               // 1: api.AuthService.ImportUsersRequest.Format
+	(*PingRequest)(nil),                          // 2: api.AuthService.PingRequest
+	(*PingResponse)(nil),                         // 3: api.AuthService.PingResponse
+	(*RegisterRequest)(nil),                      // 4: api.AuthService.RegisterRequest
+	(*RegisterResponse)(nil),                     // 5: api.AuthService.RegisterResponse
+	(*LoginRequest)(nil),                         // 6: api.AuthService.LoginRequest
+	(*LoginResponse)(nil),                        // 7: api.AuthService.LoginResponse
+	(*LoginWithProviderRequest)(nil),             // 8: api.AuthService.LoginWithProviderRequest
+	(*RequestMagicLinkRequest)(nil),              // 9: api.AuthService.RequestMagicLinkRequest
+	(*RequestMagicLinkResponse)(nil),             // 10: api.AuthService.RequestMagicLinkResponse
+	(*ConsumeMagicLinkRequest)(nil),              // 11: api.AuthService.ConsumeMagicLinkRequest
+	(*AuthorizeRequest)(nil),                     // 12: api.AuthService.AuthorizeRequest
+	(*AuthorizeResponse)(nil),                    // 13: api.AuthService.AuthorizeResponse
+	(*VerifyTokenRequest)(nil),                   // 14: api.AuthService.VerifyTokenRequest
+	(*VerifyTokenResponse)(nil),                  // 15: api.AuthService.VerifyTokenResponse
+	(*IssueServiceTokenRequest)(nil),             // 16: api.AuthService.IssueServiceTokenRequest
+	(*IssueServiceTokenResponse)(nil),            // 17: api.AuthService.IssueServiceTokenResponse
+	(*CreateServiceClientRequest)(nil),           // 18: api.AuthService.CreateServiceClientRequest
+	(*CreateServiceClientResponse)(nil),          // 19: api.AuthService.CreateServiceClientResponse
+	(*RevokeServiceClientRequest)(nil),           // 20: api.AuthService.RevokeServiceClientRequest
+	(*RevokeServiceClientResponse)(nil),          // 21: api.AuthService.RevokeServiceClientResponse
+	(*ApiKey)(nil),                               // 22: api.AuthService.ApiKey
+	(*CreateApiKeyRequest)(nil),                  // 23: api.AuthService.CreateApiKeyRequest
+	(*CreateApiKeyResponse)(nil),                 // 24: api.AuthService.CreateApiKeyResponse
+	(*ListApiKeysRequest)(nil),                   // 25: api.AuthService.ListApiKeysRequest
+	(*ListApiKeysResponse)(nil),                  // 26: api.AuthService.ListApiKeysResponse
+	(*RevokeApiKeyRequest)(nil),                  // 27: api.AuthService.RevokeApiKeyRequest
+	(*RevokeApiKeyResponse)(nil),                 // 28: api.AuthService.RevokeApiKeyResponse
+	(*Invite)(nil),                               // 29: api.AuthService.Invite
+	(*CreateInviteRequest)(nil),                  // 30: api.AuthService.CreateInviteRequest
+	(*CreateInviteResponse)(nil),                 // 31: api.AuthService.CreateInviteResponse
+	(*ListInvitesRequest)(nil),                   // 32: api.AuthService.ListInvitesRequest
+	(*ListInvitesResponse)(nil),                  // 33: api.AuthService.ListInvitesResponse
+	(*RevokeInviteRequest)(nil),                  // 34: api.AuthService.RevokeInviteRequest
+	(*RevokeInviteResponse)(nil),                 // 35: api.AuthService.RevokeInviteResponse
+	(*Organisation)(nil),                         // 36: api.AuthService.Organisation
+	(*OrganisationMembership)(nil),               // 37: api.AuthService.OrganisationMembership
+	(*OrganisationMember)(nil),                   // 38: api.AuthService.OrganisationMember
+	(*OrganisationInvitation)(nil),               // 39: api.AuthService.OrganisationInvitation
+	(*CreateOrganisationRequest)(nil),            // 40: api.AuthService.CreateOrganisationRequest
+	(*ListMyOrganisationsRequest)(nil),           // 41: api.AuthService.ListMyOrganisationsRequest
+	(*ListMyOrganisationsResponse)(nil),          // 42: api.AuthService.ListMyOrganisationsResponse
+	(*ListOrganisationMembersRequest)(nil),       // 43: api.AuthService.ListOrganisationMembersRequest
+	(*ListOrganisationMembersResponse)(nil),      // 44: api.AuthService.ListOrganisationMembersResponse
+	(*InviteToOrganisationRequest)(nil),          // 45: api.AuthService.InviteToOrganisationRequest
+	(*RevokeOrganisationInvitationRequest)(nil),  // 46: api.AuthService.RevokeOrganisationInvitationRequest
+	(*RevokeOrganisationInvitationResponse)(nil), // 47: api.AuthService.RevokeOrganisationInvitationResponse
+	(*AcceptOrganisationInvitationRequest)(nil),  // 48: api.AuthService.AcceptOrganisationInvitationRequest
+	(*UpdateOrganisationMemberRequest)(nil),      // 49: api.AuthService.UpdateOrganisationMemberRequest
+	(*RemoveOrganisationMemberRequest)(nil),      // 50: api.AuthService.RemoveOrganisationMemberRequest
+	(*RemoveOrganisationMemberResponse)(nil),     // 51: api.AuthService.RemoveOrganisationMemberResponse
+	(*ChangePasswordRequest)(nil),                // 52: api.AuthService.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),               // 53: api.AuthService.ChangePasswordResponse
+	(*ChangeEmailRequest)(nil),                   // 54: api.AuthService.ChangeEmailRequest
+	(*ChangeEmailResponse)(nil),                  // 55: api.AuthService.ChangeEmailResponse
+	(*ConfirmEmailChangeRequest)(nil),            // 56: api.AuthService.ConfirmEmailChangeRequest
+	(*ConfirmEmailChangeResponse)(nil),           // 57: api.AuthService.ConfirmEmailChangeResponse
+	(*UserProfile)(nil),                          // 58: api.AuthService.UserProfile
+	(*GetMeRequest)(nil),                         // 59: api.AuthService.GetMeRequest
+	(*GetUserRequest)(nil),                       // 60: api.AuthService.GetUserRequest
+	(*ListUsersRequest)(nil),                     // 61: api.AuthService.ListUsersRequest
+	(*ListUsersResponse)(nil),                    // 62: api.AuthService.ListUsersResponse
+	(*UpdateUsernameRequest)(nil),                // 63: api.AuthService.UpdateUsernameRequest
+	(*DeleteAccountRequest)(nil),                 // 64: api.AuthService.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),                // 65: api.AuthService.DeleteAccountResponse
+	(*ExportMyDataRequest)(nil),                  // 66: api.AuthService.ExportMyDataRequest
+	(*ExportUserDataRequest)(nil),                // 67: api.AuthService.ExportUserDataRequest
+	(*ExportDataResponse)(nil),                   // 68: api.AuthService.ExportDataResponse
+	(*SuspendUserRequest)(nil),                   // 69: api.AuthService.SuspendUserRequest
+	(*ReactivateUserRequest)(nil),                // 70: api.AuthService.ReactivateUserRequest
+	(*ImportUsersRequest)(nil),                   // 71: api.AuthService.ImportUsersRequest
+	(*ImportRowError)(nil),                       // 72: api.AuthService.ImportRowError
+	(*ImportUsersResponse)(nil),                  // 73: api.AuthService.ImportUsersResponse
+	(*GetRegistrationChallengeRequest)(nil),      // 74: api.AuthService.GetRegistrationChallengeRequest
+	(*RegistrationChallenge)(nil),                // 75: api.AuthService.RegistrationChallenge
+	(*ProofOfWorkChallenge)(nil),                 // 76: api.AuthService.ProofOfWorkChallenge
+	(*ChallengeSolution)(nil),                    // 77: api.AuthService.ChallengeSolution
+	(*ProofOfWorkSolution)(nil),                  // 78: api.AuthService.ProofOfWorkSolution
+	(*GetPasswordPolicyRequest)(nil),             // 79: api.AuthService.GetPasswordPolicyRequest
+	(*PasswordPolicy)(nil),                       // 80: api.AuthService.PasswordPolicy
+	(*status.Status)(nil),                        // 81: google.rpc.Status
+	(*timestamppb.Timestamp)(nil),                // 82: google.protobuf.Timestamp
+}
+var file_auth_service_auth_service_proto_depIdxs = []int32{
+	77, // 0: api.AuthService.RegisterRequest.challenge:type_name -> api.AuthService.ChallengeSolution
+	81, // 1: api.AuthService.RegisterResponse.error:type_name -> google.rpc.Status
+	81, // 2: api.AuthService.VerifyTokenResponse.error:type_name -> google.rpc.Status
+	82, // 3: api.AuthService.ApiKey.created_at:type_name -> google.protobuf.Timestamp
+	82, // 4: api.AuthService.ApiKey.expires_at:type_name -> google.protobuf.Timestamp
+	82, // 5: api.AuthService.ApiKey.last_used_at:type_name -> google.protobuf.Timestamp
+	82, // 6: api.AuthService.CreateApiKeyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	22, // 7: api.AuthService.CreateApiKeyResponse.key:type_name -> api.AuthService.ApiKey
+	22, // 8: api.AuthService.ListApiKeysResponse.keys:type_name -> api.AuthService.ApiKey
+	82, // 9: api.AuthService.Invite.created_at:type_name -> google.protobuf.Timestamp
+	82, // 10: api.AuthService.Invite.expires_at:type_name -> google.protobuf.Timestamp
+	82, // 11: api.AuthService.CreateInviteRequest.expires_at:type_name -> google.protobuf.Timestamp
+	29, // 12: api.AuthService.CreateInviteResponse.invite:type_name -> api.AuthService.Invite
+	29, // 13: api.AuthService.ListInvitesResponse.invites:type_name -> api.AuthService.Invite
+	82, // 14: api.AuthService.Organisation.created_at:type_name -> google.protobuf.Timestamp
+	36, // 15: api.AuthService.OrganisationMembership.organisation:type_name -> api.AuthService.Organisation
+	82, // 16: api.AuthService.OrganisationMembership.joined_at:type_name -> google.protobuf.Timestamp
+	82, // 17: api.AuthService.OrganisationMember.joined_at:type_name -> google.protobuf.Timestamp
+	82, // 18: api.AuthService.OrganisationMember.updated_at:type_name -> google.protobuf.Timestamp
+	82, // 19: api.AuthService.OrganisationInvitation.created_at:type_name -> google.protobuf.Timestamp
+	82, // 20: api.AuthService.OrganisationInvitation.expires_at:type_name -> google.protobuf.Timestamp
+	37, // 21: api.AuthService.ListMyOrganisationsResponse.organisations:type_name -> api.AuthService.OrganisationMembership
+	38, // 22: api.AuthService.ListOrganisationMembersResponse.members:type_name -> api.AuthService.OrganisationMember
+	82, // 23: api.AuthService.UserProfile.created_at:type_name -> google.protobuf.Timestamp
+	82, // 24: api.AuthService.UserProfile.suspended_until:type_name -> google.protobuf.Timestamp
+	82, // 25: api.AuthService.UserProfile.last_login_at:type_name -> google.protobuf.Timestamp
+	82, // 26: api.AuthService.ListUsersRequest.created_from:type_name -> google.protobuf.Timestamp
+	82, // 27: api.AuthService.ListUsersRequest.created_to:type_name -> google.protobuf.Timestamp
+	82, // 28: api.AuthService.ListUsersRequest.last_login_from:type_name -> google.protobuf.Timestamp
+	82, // 29: api.AuthService.ListUsersRequest.last_login_to:type_name -> google.protobuf.Timestamp
+	0,  // 30: api.AuthService.ListUsersRequest.sort_by:type_name -> api.AuthService.ListUsersRequest.SortBy
+	58, // 31: api.AuthService.ListUsersResponse.users:type_name -> api.AuthService.UserProfile
+	82, // 32: api.AuthService.DeleteAccountResponse.purge_after:type_name -> google.protobuf.Timestamp
+	82, // 33: api.AuthService.SuspendUserRequest.until:type_name -> google.protobuf.Timestamp
+	1,  // 34: api.AuthService.ImportUsersRequest.format:type_name -> api.AuthService.ImportUsersRequest.Format
+	72, // 35: api.AuthService.ImportUsersResponse.errors:type_name -> api.AuthService.ImportRowError
+	76, // 36: api.AuthService.RegistrationChallenge.proof_of_work:type_name -> api.AuthService.ProofOfWorkChallenge
+	82, // 37: api.AuthService.ProofOfWorkChallenge.expires_at:type_name -> google.protobuf.Timestamp
+	78, // 38: api.AuthService.ChallengeSolution.proof_of_work:type_name -> api.AuthService.ProofOfWorkSolution
+	2,  // 39: api.AuthService.AuthService.Ping:input_type -> api.AuthService.PingRequest
+	4,  // 40: api.AuthService.AuthService.Register:input_type -> api.AuthService.RegisterRequest
+	6,  // 41: api.AuthService.AuthService.Login:input_type -> api.AuthService.LoginRequest
+	14, // 42: api.AuthService.AuthService.VerifyToken:input_type -> api.AuthService.VerifyTokenRequest
+	79, // 43: api.AuthService.AuthService.GetPasswordPolicy:input_type -> api.AuthService.GetPasswordPolicyRequest
+	74, // 44: api.AuthService.AuthService.GetRegistrationChallenge:input_type -> api.AuthService.GetRegistrationChallengeRequest
+	8,  // 45: api.AuthService.AuthService.LoginWithProvider:input_type -> api.AuthService.LoginWithProviderRequest
+	9,  // 46: api.AuthService.AuthService.RequestMagicLink:input_type -> api.AuthService.RequestMagicLinkRequest
+	11, // 47: api.AuthService.AuthService.ConsumeMagicLink:input_type -> api.AuthService.ConsumeMagicLinkRequest
+	12, // 48: api.AuthService.AuthService.Authorize:input_type -> api.AuthService.AuthorizeRequest
+	16, // 49: api.AuthService.AuthService.IssueServiceToken:input_type -> api.AuthService.IssueServiceTokenRequest
+	18, // 50: api.AuthService.AuthService.CreateServiceClient:input_type -> api.AuthService.CreateServiceClientRequest
+	20, // 51: api.AuthService.AuthService.RevokeServiceClient:input_type -> api.AuthService.RevokeServiceClientRequest
+	59, // 52: api.AuthService.AuthService.GetMe:input_type -> api.AuthService.GetMeRequest
+	60, // 53: api.AuthService.AuthService.GetUser:input_type -> api.AuthService.GetUserRequest
+	61, // 54: api.AuthService.AuthService.ListUsers:input_type -> api.AuthService.ListUsersRequest
+	63, // 55: api.AuthService.AuthService.UpdateUsername:input_type -> api.AuthService.UpdateUsernameRequest
+	69, // 56: api.AuthService.AuthService.SuspendUser:input_type -> api.AuthService.SuspendUserRequest
+	70, // 57: api.AuthService.AuthService.ReactivateUser:input_type -> api.AuthService.ReactivateUserRequest
+	52, // 58: api.AuthService.AuthService.ChangePassword:input_type -> api.AuthService.ChangePasswordRequest
+	54, // 59: api.AuthService.AuthService.ChangeEmail:input_type -> api.AuthService.ChangeEmailRequest
+	56, // 60: api.AuthService.AuthService.ConfirmEmailChange:input_type -> api.AuthService.ConfirmEmailChangeRequest
+	64, // 61: api.AuthService.AuthService.DeleteAccount:input_type -> api.AuthService.DeleteAccountRequest
+	66, // 62: api.AuthService.AuthService.ExportMyData:input_type -> api.AuthService.ExportMyDataRequest
+	67, // 63: api.AuthService.AuthService.ExportUserData:input_type -> api.AuthService.ExportUserDataRequest
+	23, // 64: api.AuthService.AuthService.CreateApiKey:input_type -> api.AuthService.CreateApiKeyRequest
+	25, // 65: api.AuthService.AuthService.ListApiKeys:input_type -> api.AuthService.ListApiKeysRequest
+	27, // 66: api.AuthService.AuthService.RevokeApiKey:input_type -> api.AuthService.RevokeApiKeyRequest
+	30, // 67: api.AuthService.AuthService.CreateInvite:input_type -> api.AuthService.CreateInviteRequest
+	32, // 68: api.AuthService.AuthService.ListInvites:input_type -> api.AuthService.ListInvitesRequest
+	34, // 69: api.AuthService.AuthService.RevokeInvite:input_type -> api.AuthService.RevokeInviteRequest
+	71, // 70: api.AuthService.AuthService.ImportUsers:input_type -> api.AuthService.ImportUsersRequest
+	40, // 71: api.AuthService.AuthService.CreateOrganisation:input_type -> api.AuthService.CreateOrganisationRequest
+	41, // 72: api.AuthService.AuthService.ListMyOrganisations:input_type -> api.AuthService.ListMyOrganisationsRequest
+	43, // 73: api.AuthService.AuthService.ListOrganisationMembers:input_type -> api.AuthService.ListOrganisationMembersRequest
+	45, // 74: api.AuthService.AuthService.InviteToOrganisation:input_type -> api.AuthService.InviteToOrganisationRequest
+	46, // 75: api.AuthService.AuthService.RevokeOrganisationInvitation:input_type -> api.AuthService.RevokeOrganisationInvitationRequest
+	48, // 76: api.AuthService.AuthService.AcceptOrganisationInvitation:input_type -> api.AuthService.AcceptOrganisationInvitationRequest
+	49, // 77: api.AuthService.AuthService.UpdateOrganisationMember:input_type -> api.AuthService.UpdateOrganisationMemberRequest
+	50, // 78: api.AuthService.AuthService.RemoveOrganisationMember:input_type -> api.AuthService.RemoveOrganisationMemberRequest
+	3,  // 79: api.AuthService.AuthService.Ping:output_type -> api.AuthService.PingResponse
+	5,  // 80: api.AuthService.AuthService.Register:output_type -> api.AuthService.RegisterResponse
+	7,  // 81: api.AuthService.AuthService.Login:output_type -> api.AuthService.LoginResponse
+	15, // 82: api.AuthService.AuthService.VerifyToken:output_type -> api.AuthService.VerifyTokenResponse
+	80, // 83: api.AuthService.AuthService.GetPasswordPolicy:output_type -> api.AuthService.PasswordPolicy
+	75, // 84: api.AuthService.AuthService.GetRegistrationChallenge:output_type -> api.AuthService.RegistrationChallenge
+	7,  // 85: api.AuthService.AuthService.LoginWithProvider:output_type -> api.AuthService.LoginResponse
+	10, // 86: api.AuthService.AuthService.RequestMagicLink:output_type -> api.AuthService.RequestMagicLinkResponse
+	7,  // 87: api.AuthService.AuthService.ConsumeMagicLink:output_type -> api.AuthService.LoginResponse
+	13, // 88: api.AuthService.AuthService.Authorize:output_type -> api.AuthService.AuthorizeResponse
+	17, // 89: api.AuthService.AuthService.IssueServiceToken:output_type -> api.AuthService.IssueServiceTokenResponse
+	19, // 90: api.AuthService.AuthService.CreateServiceClient:output_type -> api.AuthService.CreateServiceClientResponse
+	21, // 91: api.AuthService.AuthService.RevokeServiceClient:output_type -> api.AuthService.RevokeServiceClientResponse
+	58, // 92: api.AuthService.AuthService.GetMe:output_type -> api.AuthService.UserProfile
+	58, // 93: api.AuthService.AuthService.GetUser:output_type -> api.AuthService.UserProfile
+	62, // 94: api.AuthService.AuthService.ListUsers:output_type -> api.AuthService.ListUsersResponse
+	58, // 95: api.AuthService.AuthService.UpdateUsername:output_type -> api.AuthService.UserProfile
+	58, // 96: api.AuthService.AuthService.SuspendUser:output_type -> api.AuthService.UserProfile
+	58, // 97: api.AuthService.AuthService.ReactivateUser:output_type -> api.AuthService.UserProfile
+	53, // 98: api.AuthService.AuthService.ChangePassword:output_type -> api.AuthService.ChangePasswordResponse
+	55, // 99: api.AuthService.AuthService.ChangeEmail:output_type -> api.AuthService.ChangeEmailResponse
+	57, // 100: api.AuthService.AuthService.ConfirmEmailChange:output_type -> api.AuthService.ConfirmEmailChangeResponse
+	65, // 101: api.AuthService.AuthService.DeleteAccount:output_type -> api.AuthService.DeleteAccountResponse
+	68, // 102: api.AuthService.AuthService.ExportMyData:output_type -> api.AuthService.ExportDataResponse
+	68, // 103: api.AuthService.AuthService.ExportUserData:output_type -> api.AuthService.ExportDataResponse
+	24, // 104: api.AuthService.AuthService.CreateApiKey:output_type -> api.AuthService.CreateApiKeyResponse
+	26, // 105: api.AuthService.AuthService.ListApiKeys:output_type -> api.AuthService.ListApiKeysResponse
+	28, // 106: api.AuthService.AuthService.RevokeApiKey:output_type -> api.AuthService.RevokeApiKeyResponse
+	31, // 107: api.AuthService.AuthService.CreateInvite:output_type -> api.AuthService.CreateInviteResponse
+	33, // 108: api.AuthService.AuthService.ListInvites:output_type -> api.AuthService.ListInvitesResponse
+	35, // 109: api.AuthService.AuthService.RevokeInvite:output_type -> api.AuthService.RevokeInviteResponse
+	73, // 110: api.AuthService.AuthService.ImportUsers:output_type -> api.AuthService.ImportUsersResponse
+	36, // 111: api.AuthService.AuthService.CreateOrganisation:output_type -> api.AuthService.Organisation
+	42, // 112: api.AuthService.AuthService.ListMyOrganisations:output_type -> api.AuthService.ListMyOrganisationsResponse
+	44, // 113: api.AuthService.AuthService.ListOrganisationMembers:output_type -> api.AuthService.ListOrganisationMembersResponse
+	39, // 114: api.AuthService.AuthService.InviteToOrganisation:output_type -> api.AuthService.OrganisationInvitation
+	47, // 115: api.AuthService.AuthService.RevokeOrganisationInvitation:output_type -> api.AuthService.RevokeOrganisationInvitationResponse
+	37, // 116: api.AuthService.AuthService.AcceptOrganisationInvitation:output_type -> api.AuthService.OrganisationMembership
+	38, // 117: api.AuthService.AuthService.UpdateOrganisationMember:output_type -> api.AuthService.OrganisationMember
+	51, // 118: api.AuthService.AuthService.RemoveOrganisationMember:output_type -> api.AuthService.RemoveOrganisationMemberResponse
+	79, // [79:119] is the sub-list for method output_type
+	39, // [39:79] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
+}
+
+func init() { file_auth_service_auth_service_proto_init() }
+func file_auth_service_auth_service_proto_init() {
+	if File_auth_service_auth_service_proto != nil {
+		return
+	}
+	file_auth_service_auth_service_proto_msgTypes[2].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[12].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[20].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[21].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[27].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[28].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[38].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[56].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[59].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[67].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[73].OneofWrappers = []any{}
+	file_auth_service_auth_service_proto_msgTypes[75].OneofWrappers = []any{
+		(*ChallengeSolution_CaptchaToken)(nil),
+		(*ChallengeSolution_ProofOfWork)(nil),
 	}
-	file_auth_service_auth_service_proto_msgTypes[3].OneofWrappers = []any{}
-	file_auth_service_auth_service_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_service_auth_service_proto_rawDesc), len(file_auth_service_auth_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   79,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_auth_service_auth_service_proto_goTypes,
 		DependencyIndexes: file_auth_service_auth_service_proto_depIdxs,
+		EnumInfos:         file_auth_service_auth_service_proto_enumTypes,
 		MessageInfos:      file_auth_service_auth_service_proto_msgTypes,
 	}.Build()
 	File_auth_service_auth_service_proto = out.File
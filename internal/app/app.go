@@ -3,37 +3,60 @@ package app
 import (
 	"auth-service/config"
 	grpcapp "auth-service/internal/app/grpc"
+	httpapp "auth-service/internal/app/http"
 	"auth-service/internal/services/auth"
+	"auth-service/internal/services/challenge"
 	"auth-service/internal/services/validator"
+	"auth-service/pkg/logger"
 	"log/slog"
+	"os"
 )
 
 type App struct {
 	log        *slog.Logger
 	GRPCServer *grpcapp.App
+	HTTPServer *httpapp.App
 	AuthApp    *auth.Service
+	Validator  *validator.Validator
+	Challenge  *challenge.Service
 }
 
 func New(log *slog.Logger, cfg *config.Config) *App {
 	authApp := auth.New(log, cfg)
-	validatorApp := validator.New()
-	grpcApp := grpcapp.New(log, cfg.GRPC.Port, authApp, validatorApp)
+	validatorApp := validator.New(log, cfg)
+
+	challengeApp, err := challenge.New(log, cfg.RegistrationChallenge, cfg.Cert.Jwt, nil)
+	if err != nil {
+		log.Warn("Failed to configure registration challenge. Check config.yaml!", logger.Err(err))
+		os.Exit(2)
+	}
+
+	grpcApp := grpcapp.New(log, cfg.GRPC.Port, authApp, validatorApp, challengeApp)
+	httpApp := httpapp.New(log, cfg.HTTP.Port, cfg.HTTP.Timeout, cfg.HTTP.Metrics, authApp)
 
 	return &App{
 		log:        log,
 		GRPCServer: grpcApp,
+		HTTPServer: httpApp,
 		AuthApp:    authApp,
+		Validator:  validatorApp,
+		Challenge:  challengeApp,
 	}
 }
 
 func (a *App) MustRun() {
+	a.AuthApp.Start()
 	a.GRPCServer.MustRun()
+	a.HTTPServer.MustRun()
 
 	a.log.Info("Application is running")
 }
 
 func (a *App) Stop() {
+	a.HTTPServer.Stop()
 	a.GRPCServer.Stop()
+	_ = a.Validator.Close()
+	a.Challenge.Close()
 	a.AuthApp.Close()
 	a.log.Info("Application is stopped")
 }
@@ -2,6 +2,7 @@ package grpcapp
 
 import (
 	"auth-service/internal/services/auth"
+	"auth-service/internal/services/challenge"
 	AuthServices "auth-service/internal/services/grpc-server/auth-service"
 	"auth-service/internal/services/validator"
 	"fmt"
@@ -18,9 +19,9 @@ type App struct {
 }
 
 // New creates new gRPC server application
-func New(log *slog.Logger, port int, authApp *auth.Service, validator *validator.Validator) *App {
-	gRPCServer := grpc.NewServer()
-	AuthServices.Register(gRPCServer, log, authApp, validator)
+func New(log *slog.Logger, port int, authApp *auth.Service, validator *validator.Validator, challenge *challenge.Service) *App {
+	gRPCServer := grpc.NewServer(grpc.ChainUnaryInterceptor(errorInterceptor(log), tenantInterceptor(authApp)))
+	AuthServices.Register(gRPCServer, log, authApp, validator, challenge)
 
 	return &App{
 		log:        log,
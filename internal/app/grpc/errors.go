@@ -0,0 +1,28 @@
+package grpcapp
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/pkg/logger"
+	"context"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// errorInterceptor единственное место, где ошибки сервиса превращаются в gRPC статусы.
+// Внутренние ошибки пишутся в лог целиком, а клиент получает только код и ErrorInfo
+func errorInterceptor(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		st := errs.Status(err)
+		if st.Code() == codes.Internal {
+			log.Error("внутренняя ошибка", slog.String("method", info.FullMethod), logger.Err(err))
+		}
+		return nil, st.Err()
+	}
+}
@@ -0,0 +1,34 @@
+package grpcapp
+
+import (
+	"auth-service/internal/services/auth"
+	"auth-service/internal/tenant"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tenantInterceptor определяет арендатора вызова по metadata x-tenant (ID или slug),
+// а без нее - по claim tid из токена в authorization. Все обработчики получают
+// контекст, ограниченный этим арендатором
+func tenantInterceptor(authApp *auth.Service) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var idOrSlug, bearer string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(tenant.MetadataKey); len(values) > 0 {
+				idOrSlug = strings.TrimSpace(values[0])
+			}
+			if values := md.Get("authorization"); len(values) > 0 {
+				bearer, _ = strings.CutPrefix(values[0], "Bearer ")
+			}
+		}
+
+		ctx, err := authApp.TenantContext(ctx, idOrSlug, bearer)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,86 @@
+package httpapp
+
+import (
+	"auth-service/internal/services/auth"
+	OIDCServices "auth-service/internal/services/http-server/oidc"
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type App struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	port       int
+}
+
+// New creates new HTTP server application
+func New(log *slog.Logger, port int, timeout time.Duration, metrics bool, authApp *auth.Service) *App {
+	mux := http.NewServeMux()
+	OIDCServices.Register(mux, log, authApp)
+
+	// expvar: метрики пула хеширования паролей и рантайма
+	if metrics {
+		mux.Handle("GET /debug/vars", expvar.Handler())
+	}
+
+	return &App{
+		log: log,
+		httpServer: &http.Server{
+			Handler:      mux,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
+		},
+		port: port,
+	}
+}
+
+// MustRun runs HTTP server and panics if any error occurs
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+// Run runs HTTP server
+func (a *App) Run() error {
+	const op = "httpapp.Run"
+	log := a.log.With(
+		slog.String("op", op),
+		slog.Int("port", a.port),
+	)
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", a.port))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	go func() {
+		log.Info("HTTP server start..", slog.String("addr", l.Addr().String()))
+		if err := a.httpServer.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(fmt.Sprintf("%s: %v", op, err))
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops HTTP server
+func (a *App) Stop() {
+	const op = "httpapp.Stop"
+	log := a.log.With(
+		slog.String("op", op),
+	)
+	log.Info("graceful stopping HTTP server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		log.Error(fmt.Sprintf("%s: %v", op, err))
+	}
+}
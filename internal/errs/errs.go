@@ -0,0 +1,132 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Kind категория доменной ошибки, по ней выбирается gRPC код ответа
+type Kind uint8
+
+const (
+	KindInternal Kind = iota
+	KindInvalidArgument
+	KindNotFound
+	KindAlreadyExists
+	KindUnauthenticated
+	KindPermissionDenied
+	KindFailedPrecondition
+	KindResourceExhausted
+	KindUnavailable
+	KindCanceled
+	KindDeadlineExceeded
+)
+
+// Причины для ошибок без собственной доменной причины
+const (
+	ReasonInternal         = "INTERNAL"
+	ReasonCanceled         = "CANCELED"
+	ReasonDeadlineExceeded = "DEADLINE_EXCEEDED"
+)
+
+// Error доменная ошибка. Клиенту уходят только Kind, Reason, Message, метаданные и детали;
+// причина (cause) попадает лишь в логи через Error()
+type Error struct {
+	kind     Kind
+	reason   string
+	message  string
+	metadata map[string]string
+	details  []protoadapt.MessageV1
+	cause    error
+}
+
+// New создает ошибку. reason - машиночитаемый код в UPPER_SNAKE_CASE для ErrorInfo,
+// message - текст, который можно показать клиенту
+func New(kind Kind, reason, message string) *Error {
+	return &Error{kind: kind, reason: reason, message: message}
+}
+
+// Internal оборачивает внутреннюю ошибку. Клиент получит только код Internal,
+// message и текст err пишутся в лог
+func Internal(message string, err error) *Error {
+	return &Error{kind: KindInternal, reason: ReasonInternal, message: message, cause: err}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.message + ": " + e.cause.Error()
+	}
+	return e.message
+}
+
+func (e *Error) Unwrap() error { return e.cause }
+
+// Is сравнивает ошибки по причине, поэтому errors.Is работает и для копий
+// из With, WithDetails и Wrap
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.kind == e.kind && t.reason == e.reason
+}
+
+func (e *Error) Kind() Kind                      { return e.kind }
+func (e *Error) Reason() string                  { return e.reason }
+func (e *Error) Message() string                 { return e.message }
+func (e *Error) Metadata() map[string]string     { return e.metadata }
+func (e *Error) Details() []protoadapt.MessageV1 { return e.details }
+
+// With возвращает копию ошибки с дополнительным полем метаданных ErrorInfo
+func (e *Error) With(key, value string) *Error {
+	c := e.clone()
+	if c.metadata == nil {
+		c.metadata = make(map[string]string, 1)
+	}
+	c.metadata[key] = value
+	return c
+}
+
+// WithDetails возвращает копию ошибки с дополнительными деталями статуса (BadRequest, LocalizedMessage)
+func (e *Error) WithDetails(details ...protoadapt.MessageV1) *Error {
+	c := e.clone()
+	c.details = append(c.details, details...)
+	return c
+}
+
+// WithMessage возвращает копию ошибки с другим текстом для клиента
+func (e *Error) WithMessage(message string) *Error {
+	c := e.clone()
+	c.message = message
+	return c
+}
+
+// Wrap возвращает копию ошибки с внутренней причиной для логов
+func (e *Error) Wrap(err error) *Error {
+	c := e.clone()
+	c.cause = err
+	return c
+}
+
+func (e *Error) clone() *Error {
+	c := *e
+	c.metadata = maps.Clone(e.metadata)
+	c.details = slices.Clone(e.details)
+	return &c
+}
+
+// KindOf возвращает категорию ошибки. Отмена и истечение контекста распознаются
+// и внутри внутренних ошибок, все прочие ошибки считаются внутренними
+func KindOf(err error) Kind {
+	var e *Error
+	switch {
+	case errors.As(err, &e) && e.kind != KindInternal:
+		return e.kind
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindDeadlineExceeded
+	}
+	return KindInternal
+}
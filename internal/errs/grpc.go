@@ -0,0 +1,92 @@
+package errs
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain домен ErrorInfo для всех ошибок сервиса
+const Domain = "auth-service"
+
+// internalMessage единственный текст, который клиент видит при внутренней ошибке
+const internalMessage = "internal error"
+
+var kindCodes = map[Kind]codes.Code{
+	KindInternal:           codes.Internal,
+	KindInvalidArgument:    codes.InvalidArgument,
+	KindNotFound:           codes.NotFound,
+	KindAlreadyExists:      codes.AlreadyExists,
+	KindUnauthenticated:    codes.Unauthenticated,
+	KindPermissionDenied:   codes.PermissionDenied,
+	KindFailedPrecondition: codes.FailedPrecondition,
+	KindResourceExhausted:  codes.ResourceExhausted,
+	KindUnavailable:        codes.Unavailable,
+	KindCanceled:           codes.Canceled,
+	KindDeadlineExceeded:   codes.DeadlineExceeded,
+}
+
+// Code возвращает gRPC код для категории ошибки
+func (k Kind) Code() codes.Code {
+	if code, ok := kindCodes[k]; ok {
+		return code
+	}
+	return codes.Internal
+}
+
+// Status переводит ошибку в gRPC статус для ответа клиенту. Каждый статус содержит ErrorInfo
+// с причиной; у внутренних ошибок текст заменяется на общий, чтобы не раскрывать детали
+func Status(err error) *status.Status {
+	var e *Error
+	switch kind := KindOf(err); {
+	case kind == KindCanceled:
+		return build(codes.Canceled, "request canceled", ReasonCanceled, nil, nil)
+	case kind == KindDeadlineExceeded:
+		return build(codes.DeadlineExceeded, "deadline exceeded", ReasonDeadlineExceeded, nil, nil)
+	case kind != KindInternal && errors.As(err, &e):
+		return build(kind.Code(), e.message, e.reason, e.metadata, e.details)
+	}
+
+	// Статусы, созданные вне доменного пакета (gRPC, сторонние библиотеки), пропускаем,
+	// дополнив ErrorInfo, если его нет
+	if st, ok := status.FromError(err); ok && err != nil && st.Code() != codes.Unknown && st.Code() != codes.Internal {
+		for _, d := range st.Details() {
+			if _, ok := d.(*errdetails.ErrorInfo); ok {
+				return st
+			}
+		}
+		return build(st.Code(), st.Message(), codeReason(st.Code()), nil, nil)
+	}
+
+	return build(codes.Internal, internalMessage, ReasonInternal, nil, nil)
+}
+
+func build(code codes.Code, message, reason string, metadata map[string]string, details []protoadapt.MessageV1) *status.Status {
+	st := status.New(code, message)
+	all := append([]protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   Domain,
+		Metadata: metadata,
+	}}, details...)
+	if withDetails, err := st.WithDetails(all...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// codeReason строит причину из gRPC кода: InvalidArgument -> INVALID_ARGUMENT
+func codeReason(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// UserExport все данные сервиса авторизации о пользователе (GDPR)
+type UserExport struct {
+	User                    *User                           `json:"user"`
+	ExternalIdentities      []*ExternalIdentity             `json:"external_identities"`
+	ApiKeys                 []*ApiKey                       `json:"api_keys"`
+	EmailChangeRequests     []*EmailChangeRequest           `json:"email_change_requests"`
+	MagicLinks              []*MagicLinkExport              `json:"magic_links"`
+	PasswordChanges         []*PasswordChangeExport         `json:"password_changes"`
+	Organisations           []*OrganisationMembership       `json:"organisations"`
+	OrganisationInvitations []*OrganisationInvitationExport `json:"organisation_invitations"`
+	CreatedInvites          []*Invite                       `json:"created_invites"`
+	ExportedAt              time.Time                       `json:"exported_at"`
+}
+
+// MagicLinkExport ссылка входа без хеша токена
+type MagicLinkExport struct {
+	ExpiresAt  time.Time    `db:"expires_at" json:"expires_at"`
+	ConsumedAt sql.NullTime `db:"consumed_at" json:"consumed_at"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+}
+
+// PasswordChangeExport смена пароля из истории; прежние хеши не выгружаются
+type PasswordChangeExport struct {
+	ChangedAt time.Time `db:"created_at" json:"changed_at"`
+}
+
+// OrganisationInvitationExport приглашение в организацию, полученное пользователем или отправленное им
+type OrganisationInvitationExport struct {
+	OrganisationInvitation
+	AcceptedAt sql.NullTime `db:"accepted_at" json:"accepted_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at" json:"revoked_at"`
+}
+
+// EmailChangeRequest запрос на смену email без секретного токена
+type EmailChangeRequest struct {
+	NewEmail   string       `db:"new_email" json:"new_email"`
+	ExpiresAt  time.Time    `db:"expires_at" json:"expires_at"`
+	ConsumedAt sql.NullTime `db:"consumed_at" json:"consumed_at"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+}
+
+// RequestAccountDeletion помечает аккаунт к удалению и отзывает все токены пользователя
+func RequestAccountDeletion(ctx context.Context, userID uuid.UUID, purgeAfter time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE auth.users
+		SET status = 'pending_deletion',
+		    deletion_requested_at = CURRENT_TIMESTAMP,
+		    purge_after = $2,
+		    token_version = token_version + 1,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND tenant_id = $3 AND deletion_requested_at IS NULL
+	`, userID, purgeAfter, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка при запросе удаления аккаунта", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers окончательно удаляет пользователей с истекшим льготным периодом всех арендаторов:
+// это фоновая задача, а не запрос арендатора. Связанные строки удаляются каскадно (ON DELETE CASCADE)
+func PurgeDeletedUsers(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		DELETE FROM auth.users
+		WHERE purge_after IS NOT NULL AND purge_after <= CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return 0, errs.Internal("Ошибка удаления пользователей", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// ExportUserData собирает все данные пользователя из схемы auth
+func ExportUserData(ctx context.Context, userID uuid.UUID) (*UserExport, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	export := &UserExport{User: new(User), ExportedAt: time.Now().UTC()}
+
+	err := db.GetContext(ctx, export.User, `
+		SELECT `+userColumns+` FROM auth.users WHERE user_id = $1 AND tenant_id = $2
+	`, userID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	// Пользователь уже найден в пределах арендатора, остальные данные выбираются по его ID
+	queries := []struct {
+		dest  any
+		query string
+	}{
+		{&export.User.Roles, `
+			SELECT r.role_name
+			FROM auth.user_roles ur
+			JOIN auth.roles r ON ur.role_id = r.role_id
+			WHERE ur.user_id = $1`},
+		{&export.ExternalIdentities, `
+			SELECT provider, subject, user_id, COALESCE(email, '') AS email
+			FROM auth.external_identities
+			WHERE user_id = $1`},
+		{&export.ApiKeys, `
+			SELECT key_id, user_id, name, prefix, secret_hash, roles, expires_at, last_used_at, created_at, revoked_at
+			FROM auth.api_keys
+			WHERE user_id = $1`},
+		{&export.EmailChangeRequests, `
+			SELECT new_email, expires_at, consumed_at, created_at
+			FROM auth.email_change_requests
+			WHERE user_id = $1`},
+		{&export.MagicLinks, `
+			SELECT expires_at, consumed_at, created_at
+			FROM auth.magic_links
+			WHERE user_id = $1`},
+		{&export.PasswordChanges, `
+			SELECT created_at
+			FROM auth.password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC`},
+		{&export.Organisations, `
+			SELECT ` + organisationColumns + `, m.roles, m.created_at AS joined_at
+			FROM auth.organisation_members m
+			JOIN auth.organisations o ON o.org_id = m.org_id
+			WHERE m.user_id = $1`},
+		{&export.OrganisationInvitations, `
+			SELECT i.invitation_id, i.org_id, i.email, i.roles, i.token_hash, i.invited_by,
+			       i.expires_at, i.created_at, i.accepted_at, i.revoked_at
+			FROM auth.organisation_invitations i
+			JOIN auth.organisations o ON o.org_id = i.org_id
+			JOIN auth.users u ON u.user_id = $1 AND u.tenant_id = o.tenant_id
+			WHERE lower(i.email) = lower(u.email) OR i.invited_by = $1`},
+		{&export.CreatedInvites, `
+			SELECT ` + inviteColumns + `
+			FROM auth.invites
+			WHERE created_by = $1`},
+	}
+	for _, q := range queries {
+		if err = db.SelectContext(ctx, q.dest, q.query, userID); err != nil {
+			return nil, errs.Internal("ошибка при выгрузке данных пользователя", err)
+		}
+	}
+
+	return export, nil
+}
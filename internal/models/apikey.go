@@ -0,0 +1,138 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// ApiKey персональный API ключ пользователя
+type ApiKey struct {
+	KeyId      uuid.UUID      `db:"key_id" json:"key_id"`
+	UserId     uuid.UUID      `db:"user_id" json:"user_id"`
+	Name       string         `db:"name" json:"name"`
+	Prefix     string         `db:"prefix" json:"prefix"`
+	SecretHash string         `db:"secret_hash" json:"-"`
+	Roles      pq.StringArray `db:"roles" json:"roles"` // nil - все роли владельца
+	ExpiresAt  sql.NullTime   `db:"expires_at" json:"expires_at"`
+	LastUsedAt sql.NullTime   `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+	RevokedAt  sql.NullTime   `db:"revoked_at" json:"revoked_at"`
+	TenantId   uuid.UUID      `db:"tenant_id" json:"-"` // Арендатор владельца, заполняется GetApiKeyByPrefix
+}
+
+// CreateApiKey сохраняет новый API ключ
+func CreateApiKey(ctx context.Context, key *ApiKey) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	// Ключ создается, только если владелец принадлежит арендатору запроса
+	err := db.QueryRowxContext(ctx, `
+		INSERT INTO auth.api_keys (key_id, user_id, name, prefix, secret_hash, roles, expires_at)
+		SELECT $1, user_id, $3, $4, $5, $6, $7 FROM auth.users WHERE user_id = $2 AND tenant_id = $8
+		RETURNING created_at
+	`, key.KeyId, key.UserId, key.Name, key.Prefix, key.SecretHash, key.Roles, key.ExpiresAt, tenant.ID(ctx)).
+		Scan(&key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return errs.Internal("Ошибка создания API ключа", err)
+	}
+
+	return nil
+}
+
+// ListApiKeys возвращает неотозванные API ключи пользователя
+func ListApiKeys(ctx context.Context, userID uuid.UUID) ([]*ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var keys []*ApiKey
+	err := db.SelectContext(ctx, &keys, `
+		SELECT k.key_id, k.user_id, k.name, k.prefix, k.secret_hash, k.roles, k.expires_at, k.last_used_at, k.created_at, k.revoked_at
+		FROM auth.api_keys k
+		JOIN auth.users u ON u.user_id = k.user_id
+		WHERE k.user_id = $1 AND u.tenant_id = $2 AND k.revoked_at IS NULL
+		ORDER BY k.created_at DESC
+	`, userID, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении API ключей", err)
+	}
+
+	return keys, nil
+}
+
+// GetApiKeyByPrefix получает активный (неотозванный и неистекший) API ключ по публичной части.
+// Ключ не содержит арендатора, поэтому поиск идет по всем арендаторам, а арендатор берется у владельца
+func GetApiKeyByPrefix(ctx context.Context, prefix string) (*ApiKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	key := new(ApiKey)
+	err := db.GetContext(ctx, key, `
+		SELECT k.key_id, k.user_id, k.name, k.prefix, k.secret_hash, k.roles, k.expires_at, k.last_used_at, k.created_at, k.revoked_at,
+		       u.tenant_id
+		FROM auth.api_keys k
+		JOIN auth.users u ON u.user_id = k.user_id
+		WHERE k.prefix = $1
+		  AND k.revoked_at IS NULL
+		  AND (k.expires_at IS NULL OR k.expires_at > CURRENT_TIMESTAMP)
+	`, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrApiKeyNotFound
+		}
+		return nil, errs.Internal("ошибка при получении API ключа", err)
+	}
+
+	return key, nil
+}
+
+// TouchApiKey обновляет время последнего использования не чаще раза в минуту,
+// чтобы частые проверки ключа не превращались в поток UPDATE
+func TouchApiKey(ctx context.Context, keyID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	_, err := db.ExecContext(ctx, `
+		UPDATE auth.api_keys k
+		SET last_used_at = CURRENT_TIMESTAMP
+		FROM auth.users u
+		WHERE k.key_id = $1
+		  AND u.user_id = k.user_id AND u.tenant_id = $2
+		  AND (k.last_used_at IS NULL OR k.last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')
+	`, keyID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка обновления API ключа", err)
+	}
+
+	return nil
+}
+
+// RevokeApiKey отзывает API ключ пользователя
+func RevokeApiKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE auth.api_keys k
+		SET revoked_at = CURRENT_TIMESTAMP
+		FROM auth.users u
+		WHERE k.key_id = $1 AND k.user_id = $2 AND k.revoked_at IS NULL
+		  AND u.user_id = k.user_id AND u.tenant_id = $3
+	`, keyID, userID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка отзыва API ключа", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrApiKeyNotFound
+	}
+
+	return nil
+}
@@ -1,99 +1,194 @@
 package models
 
 import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
 	"context"
 	"database/sql"
 	"errors"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"time"
 )
 
 const (
 	dbTimeOut = 10 * time.Second
 
-	roleReader = 1
+	// defaultRole роль, которую получает каждый новый пользователь; есть у каждого арендатора
+	defaultRole = "reader"
+
+	// usernameAttempts сколько раз генерировать username при коллизии
+	usernameAttempts = 5
+
+	// userColumns столбцы auth.users, читаемые в структуру User
+	userColumns = `user_id, tenant_id, username, email, password_hash, token_version, created_at, deletion_requested_at,
+		status, status_reason, suspended_until, last_login_at, password_changed_at`
+)
+
+// UnusablePasswordHash хеш аккаунтов без пароля: не совпадает ни с одним паролем,
+// пока пользователь не задаст свой
+const UnusablePasswordHash = "!"
+
+// Статусы аккаунта (auth.users.status)
+const (
+	UserStatusActive          = "active"
+	UserStatusSuspended       = "suspended"
+	UserStatusDisabled        = "disabled"
+	UserStatusPendingDeletion = "pending_deletion"
 )
 
 // AuthRequest предназначена для объединения данных, получаемых во время регистрации
 type AuthRequest struct {
 	Email    string `db:"email" json:"email"`
 	Password string `db:"password" json:"password"`
+	ClientID string `json:"client_id,omitempty"` // OIDC клиент, для которого выпускается ID токен
+	Nonce    string `json:"nonce,omitempty"`     // OIDC nonce, переносится в ID токен без изменений
+
+	InviteCode string `json:"-"` // Код приглашения; обязателен в режиме invite_only
 }
 
 type AuthResponse struct {
 	JWTToken string `json:"jwt_token"`
+	IDToken  string `json:"id_token,omitempty"`
+
+	// Срок действия пароля истек: JWTToken годится только для ChangePassword
+	PasswordExpired bool `json:"password_expired,omitempty"`
+}
+
+// UserInfo содержит стандартные OIDC claims для эндпоинта /userinfo
+type UserInfo struct {
+	Sub               string   `json:"sub"`
+	Email             string   `json:"email"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Roles             []string `json:"roles,omitempty"`
 }
 
 // TokenInfo содержит информацию, извлеченную из JWT токена
 type TokenInfo struct {
-	UserID  string   // ID пользователя (из поля sub)
-	Email   string   // Email пользователя
-	Roles   []string // Роли пользователя
-	IsValid bool     // Флаг валидности токена
+	UserID    string   // ID пользователя (из поля sub)
+	Email     string   // Email пользователя
+	Roles     []string // Роли пользователя
+	IsValid   bool     // Флаг валидности токена
+	IsService bool     // Токен выдан машинному клиенту, а не пользователю
+	IsApiKey  bool     // Вместо JWT предъявлен персональный API ключ
+	TenantID  string   // Арендатор, которому выдан токен (claim tid)
+
+	ApiKeyScoped bool      // API ключ ограничен набором ролей; роли владельца в организациях ему не передаются
+	AuthTime     time.Time // Время входа пользователя (claim auth_time), у API ключей не заполняется
 }
 
 type User struct {
 	UserId       uuid.UUID `db:"user_id" json:"user_id"`
+	TenantId     uuid.UUID `db:"tenant_id" json:"tenant_id"`
 	Username     string    `db:"username" json:"username"`
 	Email        string    `db:"email" json:"email"`
 	Roles        []string  `db:"roles" json:"roles"`
 	PasswordHash string    `db:"password_hash" json:"-"` // Не включаем в JSON
+	TokenVersion int       `db:"token_version" json:"-"` // Версия токенов; увеличение отзывает все выданные JWT
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+
+	DeletionRequestedAt sql.NullTime `db:"deletion_requested_at" json:"deletion_requested_at"` // Запрошено удаление аккаунта
+
+	Status         string         `db:"status" json:"status"`
+	StatusReason   sql.NullString `db:"status_reason" json:"status_reason"`
+	SuspendedUntil sql.NullTime   `db:"suspended_until" json:"suspended_until"`
+	LastLoginAt    sql.NullTime   `db:"last_login_at" json:"last_login_at"`
+
+	PasswordChangedAt time.Time `db:"password_changed_at" json:"password_changed_at"`
 }
 
-// ---------------------------------------------------------------------------------------------------------------------
+// IsActive может ли пользователь входить и пользоваться токенами.
+// Истекшая временная блокировка снимается без отдельного обновления в БД
+func (u *User) IsActive() bool {
+	switch u.Status {
+	case UserStatusActive:
+		return true
+	case UserStatusSuspended:
+		return u.SuspendedUntil.Valid && u.SuspendedUntil.Time.Before(time.Now())
+	default:
+		return false
+	}
+}
 
-// CreateUser создает нового пользователя в базе данных с использованием sqlx
-func CreateUser(ctx context.Context, userID uuid.UUID, email, passwordHash string) (*User, error) {
-	// Текущее время для полей created_at и updated_at
-	now := time.Now()
+// UserTokenState данные пользователя, проверяемые при каждой проверке токена
+type UserTokenState struct {
+	TokenVersion   int          `db:"token_version"`
+	Status         string       `db:"status"`
+	SuspendedUntil sql.NullTime `db:"suspended_until"`
+}
 
+// ---------------------------------------------------------------------------------------------------------------------
+
+// CreateUser создает нового пользователя в базе данных с использованием sqlx.
+// emailCanonical - канонический вид email, по нему проверяется уникальность
+func CreateUser(ctx context.Context, userID uuid.UUID, email, emailCanonical, passwordHash string) (*User, error) {
 	// Начинаем транзакцию
 	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при начале транзакции: %v", err)
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
 	}
 	defer tx.Rollback() // Откат в случае ошибки
 
-	// SQL запрос для вставки нового пользователя
+	user, err := createUser(ctx, tx, userID, email, emailCanonical, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Фиксируем транзакцию
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return user, nil
+}
+
+// createUser вставляет пользователя с ролью по умолчанию в рамках переданной транзакции
+func createUser(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, email, emailCanonical, passwordHash string) (*User, error) {
+	// Текущее время для полей created_at и updated_at
+	now := time.Now()
+
+	// SQL запрос для вставки нового пользователя. Конфликт по username не прерывает
+	// транзакцию, а возвращает пустой результат - тогда генерируем другое имя
 	query := `
-		INSERT INTO auth.users (user_id, email, password_hash, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING user_id, username, email
+		INSERT INTO auth.users (user_id, username, email, email_canonical, password_hash, created_at, updated_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (tenant_id, username) DO NOTHING
+		RETURNING ` + userColumns + `
 	`
 
 	// Создаем объект пользователя для возврата
 	user := new(User)
+	tid := tenant.ID(ctx)
 
-	// Выполняем запрос с использованием sqlx
-	err = tx.QueryRowxContext(ctx, query, userID, email, passwordHash, now, now).
-		StructScan(user)
+	var err error
+	for attempt := 0; attempt < usernameAttempts; attempt++ {
+		// Выполняем запрос с использованием sqlx
+		err = tx.QueryRowxContext(ctx, query, userID, GenerateUsername(), email, emailCanonical, passwordHash, now, now, tid).
+			StructScan(user)
+		if !errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+	}
 
 	if err != nil {
 		// Проверяем, является ли ошибка нарушением уникальности (дублирование email)
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
-			return nil, status.Error(codes.AlreadyExists, "Пользователь с таким email уже существует")
+			return nil, ErrEmailTaken
 		}
 
 		// Другие ошибки базы данных
-		return nil, status.Errorf(codes.Internal, "Ошибка создания пользователя: %v", err)
+		return nil, errs.Internal("Ошибка создания пользователя", err)
 	}
 
-	// Назначаем роль "reader" напрямую
+	// Назначаем роль по умолчанию из набора ролей арендатора
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO auth.user_roles (user_id, role_id)
-		VALUES ($1, $2)
-	`, userID, roleReader)
+		SELECT $1, role_id FROM auth.roles WHERE tenant_id = $2 AND role_name = $3
+	`, userID, tid, defaultRole)
 
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при назначении роли по умолчанию: %v", err)
-	}
-
-	// Фиксируем транзакцию
-	if err = tx.Commit(); err != nil {
-		return nil, status.Errorf(codes.Internal, "Ошибка при фиксации транзакции: %v", err)
+		return nil, errs.Internal("Ошибка при назначении роли по умолчанию", err)
 	}
 
 	return user, nil
@@ -108,41 +203,301 @@ func GetUserRoles(ctx context.Context, userID uuid.UUID) ([]string, error) {
 		SELECT r.role_name
 		FROM auth.user_roles ur
 		JOIN auth.roles r ON ur.role_id = r.role_id
-		WHERE ur.user_id = $1
+		WHERE ur.user_id = $1 AND r.tenant_id = $2
 	`
 
 	var roles []string
-	err := db.SelectContext(ctx, &roles, query, userID)
+	err := db.SelectContext(ctx, &roles, query, userID, tenant.ID(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return []string{}, nil
 		}
-		return nil, status.Errorf(codes.Internal, "Ошибка при получении ролей пользователя: %v", err)
+		return nil, errs.Internal("Ошибка при получении ролей пользователя", err)
 	}
 
 	return roles, nil
 }
 
-// GetUserByEmail получает пользователя из базы данных по email
-func GetUserByEmail(ctx context.Context, email string) (*User, error) {
+// GetUsersRoles возвращает роли нескольких пользователей одним запросом
+func GetUsersRoles(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	var rows []struct {
+		UserID   uuid.UUID `db:"user_id"`
+		RoleName string    `db:"role_name"`
+	}
+	err := db.SelectContext(ctx, &rows, `
+		SELECT ur.user_id, r.role_name
+		FROM auth.user_roles ur
+		JOIN auth.roles r ON ur.role_id = r.role_id
+		WHERE ur.user_id = ANY($1::uuid[]) AND r.tenant_id = $2
+	`, pq.Array(ids), tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении ролей пользователей", err)
+	}
+
+	roles := make(map[uuid.UUID][]string, len(userIDs))
+	for _, row := range rows {
+		roles[row.UserID] = append(roles[row.UserID], row.RoleName)
+	}
+
+	return roles, nil
+}
+
+// GetUserByEmail получает пользователя из базы данных по каноническому виду email
+// (по уникальному индексу uq_users_email_canonical)
+func GetUserByEmail(ctx context.Context, emailCanonical string) (*User, error) {
 	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
 	defer cancel()
 
 	query := `
-		SELECT user_id, username, email, password_hash
+		SELECT ` + userColumns + `
 		FROM auth.users
-		WHERE email = $1
+		WHERE tenant_id = $2 AND email_canonical = $1
 	`
 
 	user := new(User)
 
-	err := db.GetContext(ctx, user, query, email)
+	err := db.GetContext(ctx, user, query, emailCanonical, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	return user, nil
+}
+
+// GetUserByID получает пользователя из базы данных по ID
+func GetUserByID(ctx context.Context, userID uuid.UUID) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM auth.users
+		WHERE user_id = $1 AND tenant_id = $2
+	`
+
+	user := new(User)
+
+	err := db.GetContext(ctx, user, query, userID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	return user, nil
+}
+
+// GetUserTokenState возвращает версию токенов и статус пользователя
+func GetUserTokenState(ctx context.Context, userID uuid.UUID) (*UserTokenState, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	state := new(UserTokenState)
+	err := db.GetContext(ctx, state, `
+		SELECT token_version, status, suspended_until
+		FROM auth.users
+		WHERE user_id = $1 AND tenant_id = $2
+	`, userID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	return state, nil
+}
+
+// SetUserStatus меняет статус пользователя. Для active причина и срок блокировки сбрасываются,
+// а запрошенное удаление отменяется
+func SetUserStatus(
+	ctx context.Context,
+	userID uuid.UUID,
+	newStatus string,
+	reason string,
+	until *time.Time,
+	changedBy uuid.UUID,
+) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var reasonValue sql.NullString
+	if reason != "" {
+		reasonValue = sql.NullString{String: reason, Valid: true}
+	}
+	var untilValue sql.NullTime
+	if until != nil {
+		untilValue = sql.NullTime{Time: *until, Valid: true}
+	}
+
+	user := new(User)
+	err := db.GetContext(ctx, user, `
+		UPDATE auth.users
+		SET status = $2,
+		    status_reason = $3,
+		    suspended_until = $4,
+		    status_changed_by = $5,
+		    deletion_requested_at = CASE WHEN $2 = 'active' THEN NULL ELSE deletion_requested_at END,
+		    purge_after = CASE WHEN $2 = 'active' THEN NULL ELSE purge_after END,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND tenant_id = $6
+		RETURNING `+userColumns+`
+	`, userID, newStatus, reasonValue, untilValue, changedBy, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("Ошибка изменения статуса пользователя", err)
+	}
+
+	return user, nil
+}
+
+// UpdatePassword меняет хеш пароля и увеличивает версию токенов, отзывая все выданные JWT.
+// Прежний хеш сохраняется в истории, в которой остается не больше historySize записей
+func UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string, historySize int) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Непригодный хеш аккаунтов без пароля (вход через соцсети) в историю не попадает
+	if historySize > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO auth.password_history (user_id, password_hash)
+			SELECT user_id, password_hash FROM auth.users
+			WHERE user_id = $1 AND tenant_id = $3 AND password_hash <> $2
+		`, userID, UnusablePasswordHash, tenant.ID(ctx))
+		if err != nil {
+			return nil, errs.Internal("Ошибка сохранения истории паролей", err)
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM auth.password_history
+		WHERE user_id = $1 AND history_id NOT IN (
+			SELECT history_id FROM auth.password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, history_id DESC
+			LIMIT $2
+		)
+	`, userID, historySize)
+	if err != nil {
+		return nil, errs.Internal("Ошибка очистки истории паролей", err)
+	}
+
+	user := new(User)
+	err = tx.GetContext(ctx, user, `
+		UPDATE auth.users
+		SET password_hash = $2, token_version = token_version + 1,
+			password_changed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND tenant_id = $3
+		RETURNING `+userColumns+`
+	`, userID, passwordHash, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, errs.Internal("Ошибка обновления пароля", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return user, nil
+}
+
+// GetPasswordHistory возвращает хеши прежних паролей пользователя, начиная с последнего
+func GetPasswordHistory(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var hashes []string
+	err := db.SelectContext(ctx, &hashes, `
+		SELECT h.password_hash FROM auth.password_history h
+		JOIN auth.users u ON u.user_id = h.user_id
+		WHERE h.user_id = $1 AND u.tenant_id = $3
+		ORDER BY h.created_at DESC, h.history_id DESC
+		LIMIT $2
+	`, userID, limit, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка получения истории паролей", err)
+	}
+
+	return hashes, nil
+}
+
+// RehashPassword заменяет хеш того же пароля, не отзывая токены. Запись выполняется, только если
+// хеш не изменился с момента проверки, чтобы не затереть пароль, смененный параллельно
+func RehashPassword(ctx context.Context, userID uuid.UUID, oldHash, newHash string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	_, err := db.ExecContext(ctx, `
+		UPDATE auth.users
+		SET password_hash = $3
+		WHERE user_id = $1 AND tenant_id = $4 AND password_hash = $2
+	`, userID, oldHash, newHash, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка обновления хеша пароля", err)
+	}
+
+	return nil
+}
+
+// UpdateUsername меняет username пользователя
+func UpdateUsername(ctx context.Context, userID uuid.UUID, username string) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	user := new(User)
+	err := db.GetContext(ctx, user, `
+		UPDATE auth.users
+		SET username = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1 AND tenant_id = $3
+		RETURNING `+userColumns+`
+	`, userID, username, tenant.ID(ctx))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "пользователь не найден")
+			return nil, ErrUserNotFound
 		}
-		return nil, status.Errorf(codes.Internal, "ошибка при получении пользователя: %v", err)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return nil, ErrUsernameTaken
+		}
+		return nil, errs.Internal("Ошибка обновления имени пользователя", err)
 	}
 
 	return user, nil
 }
+
+// TouchLastLogin запоминает время успешного входа пользователя
+func TouchLastLogin(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	_, err := db.ExecContext(ctx, `
+		UPDATE auth.users SET last_login_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND tenant_id = $2
+	`, userID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка обновления времени входа", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AuthorizationCode код авторизации OIDC, выданный пользователю для клиента
+type AuthorizationCode struct {
+	TenantId      uuid.UUID `db:"tenant_id"`
+	UserId        uuid.UUID `db:"user_id"`
+	ClientId      string    `db:"client_id"`
+	RedirectURI   string    `db:"redirect_uri"`
+	CodeChallenge string    `db:"code_challenge"`
+	Nonce         string    `db:"nonce"`
+	AuthTime      time.Time `db:"auth_time"`
+}
+
+// CreateAuthorizationCode сохраняет хеш кода авторизации пользователя арендатора из контекста
+func CreateAuthorizationCode(ctx context.Context, codeHash string, code *AuthorizationCode, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO auth.authorization_codes
+			(code_hash, tenant_id, user_id, client_id, redirect_uri, code_challenge, nonce, auth_time, expires_at)
+		SELECT $1, tenant_id, user_id, $3, $4, $5, $6, $7, $8 FROM auth.users WHERE user_id = $2 AND tenant_id = $9
+	`, codeHash, code.UserId, code.ClientId, code.RedirectURI, code.CodeChallenge, code.Nonce, code.AuthTime, expiresAt,
+		tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка создания кода авторизации", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConsumeAuthorizationCode атомарно помечает код использованным и возвращает его. Клиент OIDC
+// не знает арендатора, поэтому код ищется по хешу, а арендатор берется из записи.
+// Повторное использование, истекший или неизвестный код дают NotFound
+func ConsumeAuthorizationCode(ctx context.Context, codeHash string) (*AuthorizationCode, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	code := new(AuthorizationCode)
+	err := db.GetContext(ctx, code, `
+		UPDATE auth.authorization_codes
+		SET consumed_at = CURRENT_TIMESTAMP
+		WHERE code_hash = $1 AND consumed_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING tenant_id, user_id, client_id, redirect_uri, code_challenge, nonce, auth_time
+	`, codeHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAuthorizationCodeNotFound
+		}
+		return nil, errs.Internal("ошибка при использовании кода авторизации", err)
+	}
+
+	return code, nil
+}
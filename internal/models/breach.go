@@ -0,0 +1,37 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"context"
+)
+
+// GetBreachedHashRange возвращает суффиксы утекших SHA-1 хешей с заданным префиксом и число утечек
+func GetBreachedHashRange(ctx context.Context, prefix string) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	rows, err := db.QueryxContext(ctx, `
+		SELECT suffix, count
+		FROM auth.breached_password_hashes
+		WHERE prefix = $1
+	`, prefix)
+	if err != nil {
+		return nil, errs.Internal("ошибка при проверке базы утечек", err)
+	}
+	defer rows.Close()
+
+	hashes := make(map[string]int)
+	for rows.Next() {
+		var suffix string
+		var count int
+		if err = rows.Scan(&suffix, &count); err != nil {
+			return nil, errs.Internal("ошибка при проверке базы утечек", err)
+		}
+		hashes[suffix] = count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errs.Internal("ошибка при проверке базы утечек", err)
+	}
+
+	return hashes, nil
+}
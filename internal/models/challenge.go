@@ -0,0 +1,39 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"context"
+	"time"
+)
+
+// SpendChallenge отмечает задачу proof-of-work использованной. false - задача уже использована
+func SpendChallenge(ctx context.Context, challengeHash string, expiresAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO auth.spent_challenges (challenge_hash, expires_at)
+		VALUES ($1, $2)
+		ON CONFLICT (challenge_hash) DO NOTHING
+	`, challengeHash, expiresAt)
+	if err != nil {
+		return false, errs.Internal("ошибка сохранения использованной задачи", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return n == 1, nil
+}
+
+// DeleteExpiredChallenges удаляет использованные задачи с истекшим сроком и возвращает их число
+func DeleteExpiredChallenges(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `DELETE FROM auth.spent_challenges WHERE expires_at < CURRENT_TIMESTAMP`)
+	if err != nil {
+		return 0, errs.Internal("ошибка очистки использованных задач", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// ServiceClient машинный клиент, получающий токены по client credentials
+type ServiceClient struct {
+	ClientID   string       `db:"client_id" json:"client_id"`
+	TenantId   uuid.UUID    `db:"tenant_id" json:"tenant_id"`
+	Name       string       `db:"name" json:"name"`
+	SecretHash string       `db:"secret_hash" json:"-"`
+	Roles      []string     `db:"-" json:"roles"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+	RevokedAt  sql.NullTime `db:"revoked_at" json:"-"`
+}
+
+// CreateServiceClient создает машинного клиента и назначает ему роли
+func CreateServiceClient(ctx context.Context, clientID, name, secretHash string, roles []string) (*ServiceClient, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	client := new(ServiceClient)
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO auth.service_clients (client_id, name, secret_hash, tenant_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING client_id, tenant_id, name, secret_hash, created_at, revoked_at
+	`, clientID, name, secretHash, tenant.ID(ctx)).StructScan(client)
+	if err != nil {
+		return nil, errs.Internal("Ошибка создания клиента", err)
+	}
+
+	// Назначаем роли по имени; неизвестные роли считаются ошибкой запроса
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO auth.service_client_roles (client_id, role_id)
+		SELECT $1, role_id FROM auth.roles WHERE tenant_id = $3 AND role_name = ANY($2)
+	`, clientID, pq.Array(roles), client.TenantId)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при назначении ролей клиенту", err)
+	}
+	if n, _ := res.RowsAffected(); int(n) != len(roles) {
+		return nil, ErrUnknownRole
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	client.Roles = roles
+	return client, nil
+}
+
+// GetServiceClient получает активного машинного клиента вместе с его ролями
+func GetServiceClient(ctx context.Context, clientID string) (*ServiceClient, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	client := new(ServiceClient)
+	err := db.GetContext(ctx, client, `
+		SELECT client_id, tenant_id, name, secret_hash, created_at, revoked_at
+		FROM auth.service_clients
+		WHERE client_id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+	`, clientID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrClientNotFound
+		}
+		return nil, errs.Internal("ошибка при получении клиента", err)
+	}
+
+	err = db.SelectContext(ctx, &client.Roles, `
+		SELECT r.role_name
+		FROM auth.service_client_roles cr
+		JOIN auth.roles r ON cr.role_id = r.role_id
+		WHERE cr.client_id = $1 AND r.tenant_id = $2
+	`, clientID, client.TenantId)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении ролей клиента", err)
+	}
+
+	return client, nil
+}
+
+// ServiceClientActive существует ли у арендатора неотозванный клиент clientID
+func ServiceClientActive(ctx context.Context, clientID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var active bool
+	err := db.GetContext(ctx, &active, `
+		SELECT EXISTS (
+			SELECT 1 FROM auth.service_clients
+			WHERE client_id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+		)
+	`, clientID, tenant.ID(ctx))
+	if err != nil {
+		return false, errs.Internal("ошибка при проверке клиента", err)
+	}
+
+	return active, nil
+}
+
+// RevokeServiceClient отзывает машинного клиента: новые токены ему не выдаются
+func RevokeServiceClient(ctx context.Context, clientID string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE auth.service_clients
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE client_id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+	`, clientID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("ошибка отзыва клиента", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrClientNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"context"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// emailScanTimeOut выборка и перезапись email всех пользователей
+const emailScanTimeOut = 5 * time.Minute
+
+// UserEmail адрес пользователя и его канонический вид в том виде, в котором они хранятся
+type UserEmail struct {
+	UserId         uuid.UUID `db:"user_id" json:"user_id"`
+	TenantId       uuid.UUID `db:"tenant_id" json:"tenant_id"`
+	Email          string    `db:"email" json:"email"`
+	EmailCanonical string    `db:"email_canonical" json:"email_canonical"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+}
+
+// EmailUpdate новые адрес и канонический вид email пользователя
+type EmailUpdate struct {
+	Email     string
+	Canonical string
+}
+
+// ListUserEmails возвращает email пользователей всех арендаторов по возрастанию даты регистрации.
+// Используется командой обслуживания, а не запросами арендаторов
+func ListUserEmails(ctx context.Context) ([]UserEmail, error) {
+	ctx, cancel := context.WithTimeout(ctx, emailScanTimeOut)
+	defer cancel()
+
+	var emails []UserEmail
+	err := db.SelectContext(ctx, &emails, `
+		SELECT user_id, tenant_id, email, email_canonical, created_at
+		FROM auth.users
+		ORDER BY created_at, user_id
+	`)
+	if err != nil {
+		return nil, errs.Internal("ошибка при получении email пользователей", err)
+	}
+
+	return emails, nil
+}
+
+// UpdateUserEmails записывает нормализованные email и их канонический вид одной транзакцией.
+// Версия токенов не меняется: адрес остается тем же ящиком
+func UpdateUserEmails(ctx context.Context, emails map[uuid.UUID]EmailUpdate) error {
+	ctx, cancel := context.WithTimeout(ctx, emailScanTimeOut)
+	defer cancel()
+
+	ids := make([]uuid.UUID, 0, len(emails))
+	addresses := make([]string, 0, len(emails))
+	canonicals := make([]string, 0, len(emails))
+	for id, email := range emails {
+		ids = append(ids, id)
+		addresses = append(addresses, email.Email)
+		canonicals = append(canonicals, email.Canonical)
+	}
+
+	_, err := db.ExecContext(ctx, `
+		UPDATE auth.users u
+		SET email = v.email, email_canonical = v.email_canonical, updated_at = CURRENT_TIMESTAMP
+		FROM unnest($1::UUID[], $2::TEXT[], $3::TEXT[]) AS v(user_id, email, email_canonical)
+		WHERE u.user_id = v.user_id
+	`, pq.Array(ids), pq.Array(addresses), pq.Array(canonicals))
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrEmailTaken.Wrap(err)
+		}
+		return errs.Internal("ошибка при обновлении email пользователей", err)
+	}
+
+	return nil
+}
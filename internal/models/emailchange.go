@@ -0,0 +1,91 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// CreateEmailChangeRequest сохраняет запрос на смену email до подтверждения нового адреса
+// вместе с его каноническим видом
+func CreateEmailChangeRequest(
+	ctx context.Context, tokenHash string, userID uuid.UUID, newEmail, newEmailCanonical string, expiresAt time.Time,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO auth.email_change_requests (token_hash, user_id, new_email, new_email_canonical, expires_at)
+		SELECT $1, user_id, $3, $4, $5 FROM auth.users WHERE user_id = $2 AND tenant_id = $6
+	`, tokenHash, userID, newEmail, newEmailCanonical, expiresAt, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка создания запроса смены email", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange подтверждает запрос и меняет email пользователя.
+// Возвращает прежний email для уведомления и обновленного пользователя
+func ConfirmEmailChange(ctx context.Context, tokenHash string) (string, *User, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	var req struct {
+		UserID            uuid.UUID `db:"user_id"`
+		NewEmail          string    `db:"new_email"`
+		NewEmailCanonical string    `db:"new_email_canonical"`
+	}
+	err = tx.GetContext(ctx, &req, `
+		UPDATE auth.email_change_requests r
+		SET consumed_at = CURRENT_TIMESTAMP
+		FROM auth.users u
+		WHERE r.token_hash = $1 AND r.consumed_at IS NULL AND r.expires_at > CURRENT_TIMESTAMP
+		  AND u.user_id = r.user_id AND u.tenant_id = $2
+		RETURNING r.user_id, r.new_email, r.new_email_canonical
+	`, tokenHash, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, ErrEmailChangeNotFound
+		}
+		return "", nil, errs.Internal("ошибка при подтверждении смены email", err)
+	}
+
+	var oldEmail string
+	err = tx.GetContext(ctx, &oldEmail, `SELECT email FROM auth.users WHERE user_id = $1 FOR UPDATE`, req.UserID)
+	if err != nil {
+		return "", nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	user := new(User)
+	err = tx.GetContext(ctx, user, `
+		UPDATE auth.users
+		SET email = $2, email_canonical = $3, token_version = token_version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE user_id = $1
+		RETURNING `+userColumns+`
+	`, req.UserID, req.NewEmail, req.NewEmailCanonical)
+	if err != nil {
+		// Адрес мог быть занят, пока запрос ждал подтверждения
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return "", nil, ErrEmailTaken
+		}
+		return "", nil, errs.Internal("Ошибка смены email", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return "", nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return oldEmail, user, nil
+}
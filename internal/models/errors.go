@@ -0,0 +1,26 @@
+package models
+
+import "auth-service/internal/errs"
+
+// Ошибки хранилища, на которые сервисы реагируют отдельно от внутренних
+var (
+	ErrUserNotFound                   = errs.New(errs.KindNotFound, "USER_NOT_FOUND", "пользователь не найден")
+	ErrEmailTaken                     = errs.New(errs.KindAlreadyExists, "EMAIL_TAKEN", "Пользователь с таким email уже существует")
+	ErrUsernameTaken                  = errs.New(errs.KindAlreadyExists, "USERNAME_TAKEN", "Имя пользователя уже занято")
+	ErrApiKeyNotFound                 = errs.New(errs.KindNotFound, "API_KEY_NOT_FOUND", "API ключ не найден")
+	ErrClientNotFound                 = errs.New(errs.KindNotFound, "CLIENT_NOT_FOUND", "клиент не найден")
+	ErrUnknownRole                    = errs.New(errs.KindInvalidArgument, "UNKNOWN_ROLE", "Указана несуществующая роль")
+	ErrEmailChangeNotFound            = errs.New(errs.KindNotFound, "EMAIL_CHANGE_NOT_FOUND", "запрос смены email недействителен")
+	ErrIdentityNotFound               = errs.New(errs.KindNotFound, "IDENTITY_NOT_FOUND", "привязка не найдена")
+	ErrMagicLinkNotFound              = errs.New(errs.KindNotFound, "MAGIC_LINK_NOT_FOUND", "ссылка недействительна")
+	ErrInvalidCursor                  = errs.New(errs.KindInvalidArgument, "INVALID_CURSOR", "недействительный курсор")
+	ErrInviteNotFound                 = errs.New(errs.KindNotFound, "INVITE_NOT_FOUND", "приглашение не найдено")
+	ErrTenantNotFound                 = errs.New(errs.KindNotFound, "TENANT_NOT_FOUND", "арендатор не найден")
+	ErrTenantExists                   = errs.New(errs.KindAlreadyExists, "TENANT_EXISTS", "арендатор с таким slug уже существует")
+	ErrOrganisationNotFound           = errs.New(errs.KindNotFound, "ORGANISATION_NOT_FOUND", "организация не найдена")
+	ErrOrganisationMemberNotFound     = errs.New(errs.KindNotFound, "ORGANISATION_MEMBER_NOT_FOUND", "пользователь не состоит в организации")
+	ErrOrganisationInvitationNotFound = errs.New(errs.KindNotFound, "ORGANISATION_INVITATION_NOT_FOUND", "приглашение в организацию не найдено")
+	ErrLastOrganisationOwner          = errs.New(errs.KindFailedPrecondition, "ORGANISATION_LAST_OWNER", "у организации должен остаться хотя бы один владелец")
+	ErrUnknownSortField               = errs.New(errs.KindInvalidArgument, "UNKNOWN_SORT_FIELD", "неизвестное поле сортировки")
+	ErrAuthorizationCodeNotFound      = errs.New(errs.KindNotFound, "AUTHORIZATION_CODE_NOT_FOUND", "код авторизации недействителен")
+)
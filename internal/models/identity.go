@@ -0,0 +1,95 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+)
+
+// ExternalIdentity привязка аккаунта внешнего провайдера к пользователю
+type ExternalIdentity struct {
+	Provider string    `db:"provider" json:"provider"`
+	Subject  string    `db:"subject" json:"subject"`
+	UserId   uuid.UUID `db:"user_id" json:"user_id"`
+	Email    string    `db:"email" json:"email"`
+}
+
+// GetUserByExternalIdentity получает пользователя по провайдеру и его sub, обновляя время входа
+func GetUserByExternalIdentity(ctx context.Context, provider, subject string) (*User, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	query := `
+		WITH identity AS (
+			UPDATE auth.external_identities
+			SET last_login_at = CURRENT_TIMESTAMP
+			WHERE tenant_id = $3 AND provider = $1 AND subject = $2
+			RETURNING user_id
+		)
+		SELECT ` + userColumns + `
+		FROM auth.users
+		WHERE user_id = (SELECT user_id FROM identity) AND tenant_id = $3
+	`
+
+	user := new(User)
+
+	err := db.GetContext(ctx, user, query, provider, subject, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrIdentityNotFound
+		}
+		return nil, errs.Internal("ошибка при получении пользователя", err)
+	}
+
+	return user, nil
+}
+
+// LinkExternalIdentity привязывает аккаунт провайдера к существующему пользователю
+func LinkExternalIdentity(ctx context.Context, identity *ExternalIdentity) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO auth.external_identities (provider, subject, user_id, email, last_login_at, tenant_id)
+		SELECT $1, $2, user_id, $4, CURRENT_TIMESTAMP, tenant_id FROM auth.users WHERE user_id = $3 AND tenant_id = $5
+	`, identity.Provider, identity.Subject, identity.UserId, identity.Email, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка привязки внешнего аккаунта", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CreateUserWithIdentity создает пользователя без пароля и привязку к провайдеру в одной транзакции
+func CreateUserWithIdentity(ctx context.Context, identity *ExternalIdentity, emailCanonical, passwordHash string) (*User, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	user, err := createUser(ctx, tx, identity.UserId, identity.Email, emailCanonical, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth.external_identities (provider, subject, user_id, email, last_login_at, tenant_id)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP, $5)
+	`, identity.Provider, identity.Subject, identity.UserId, identity.Email, user.TenantId)
+	if err != nil {
+		return nil, errs.Internal("Ошибка привязки внешнего аккаунта", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return user, nil
+}
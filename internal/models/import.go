@@ -0,0 +1,172 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// importTimeOut время на один пакет импорта: COPY и upsert нескольких тысяч строк
+const importTimeOut = 2 * time.Minute
+
+// Результат импорта строки
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportUser подготовленная к записи строка импорта: email проверен, пароль уже захеширован
+type ImportUser struct {
+	Email          string
+	EmailCanonical string // По нему ищется существующий пользователь
+	PasswordHash   string
+	Roles          []string
+}
+
+// ImportUsers записывает пакет пользователей одной транзакцией. Строки копируются через COPY
+// во временную таблицу, затем переносятся в auth.users. Существующие пользователи (по email)
+// пропускаются: их пароль мог быть изменен после миграции, поэтому повторный импорт того же
+// файла ничего не меняет. С overwrite у существующих пользователей заменяется хеш пароля,
+// а выданные им токены отзываются; роли в обоих случаях назначаются только записанным строкам
+// и только добавляются. При dryRun транзакция откатывается, но результат по строкам
+// вычисляется так же, как при записи. Возвращает результат по каноническому виду email каждой строки
+func ImportUsers(ctx context.Context, users []ImportUser, dryRun, overwrite bool) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, importTimeOut)
+	defer cancel()
+
+	tid := tenant.ID(ctx)
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		CREATE TEMP TABLE import_users
+		(
+			user_id         UUID,
+			username        VARCHAR(50),
+			email           VARCHAR(255),
+			email_canonical VARCHAR(255),
+			password_hash   VARCHAR(255),
+			roles           TEXT[]
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return nil, errs.Internal("ошибка при создании временной таблицы", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("import_users", "user_id", "username", "email", "email_canonical", "password_hash", "roles"))
+	if err != nil {
+		return nil, errs.Internal("ошибка при подготовке COPY", err)
+	}
+	for _, u := range users {
+		_, err = stmt.ExecContext(ctx, uuid.New(), GenerateUsername(), u.Email, u.EmailCanonical, u.PasswordHash, pq.Array(u.Roles))
+		if err != nil {
+			stmt.Close()
+			return nil, errs.Internal("ошибка при копировании строк", err)
+		}
+	}
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return nil, errs.Internal("ошибка при завершении COPY", err)
+	}
+	if err = stmt.Close(); err != nil {
+		return nil, errs.Internal("ошибка при завершении COPY", err)
+	}
+
+	// Пропущенные и неизмененные строки не попадают в RETURNING;
+	// xmax = 0 отличает вставленную строку от обновленной
+	query := `
+		INSERT INTO auth.users (user_id, username, email, email_canonical, password_hash, created_at, updated_at, tenant_id)
+		SELECT user_id, username, email, email_canonical, password_hash, now(), now(), $1
+		FROM import_users
+		ON CONFLICT (tenant_id, email_canonical) DO NOTHING
+		RETURNING email_canonical, (xmax = 0) AS inserted
+	`
+	if overwrite {
+		query = `
+			INSERT INTO auth.users (user_id, username, email, email_canonical, password_hash, created_at, updated_at, tenant_id)
+			SELECT user_id, username, email, email_canonical, password_hash, now(), now(), $1
+			FROM import_users
+			ON CONFLICT (tenant_id, email_canonical) DO UPDATE
+				SET password_hash       = EXCLUDED.password_hash,
+					password_changed_at = now(),
+					token_version       = users.token_version + 1,
+					updated_at          = now()
+				WHERE users.password_hash IS DISTINCT FROM EXCLUDED.password_hash
+			RETURNING email_canonical, (xmax = 0) AS inserted
+		`
+	}
+	rows, err := tx.QueryxContext(ctx, query, tid)
+	if err != nil {
+		return nil, errs.Internal("ошибка при записи пользователей", err)
+	}
+
+	result := make(map[string]string, len(users))
+	for _, u := range users {
+		result[u.EmailCanonical] = ImportUnchanged
+	}
+	var written []string
+	for rows.Next() {
+		var canonical string
+		var inserted bool
+		if err = rows.Scan(&canonical, &inserted); err != nil {
+			rows.Close()
+			return nil, errs.Internal("ошибка при чтении результата импорта", err)
+		}
+		written = append(written, canonical)
+		if inserted {
+			result[canonical] = ImportCreated
+		} else {
+			result[canonical] = ImportUpdated
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return nil, errs.Internal("ошибка при записи пользователей", err)
+	}
+
+	// Роль по умолчанию и роли из файла. Неизвестные роли отсеяны до вызова
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth.user_roles (user_id, role_id)
+		SELECT u.user_id, r.role_id
+		FROM import_users i
+		JOIN auth.users u ON u.tenant_id = $1 AND u.email_canonical = i.email_canonical
+		JOIN auth.roles r ON r.tenant_id = $1 AND (r.role_name = $2 OR r.role_name = ANY (i.roles))
+		WHERE i.email_canonical = ANY ($3)
+		ON CONFLICT (user_id, role_id) DO NOTHING
+	`, tid, defaultRole, pq.Array(written))
+	if err != nil {
+		return nil, errs.Internal("ошибка при назначении ролей", err)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return result, nil
+}
+
+// ListRoleNames возвращает названия всех ролей арендатора
+func ListRoleNames(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var roles []string
+	err := db.SelectContext(ctx, &roles, `
+		SELECT role_name FROM auth.roles WHERE tenant_id = $1 ORDER BY role_name
+	`, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("ошибка при получении ролей", err)
+	}
+	return roles, nil
+}
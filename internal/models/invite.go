@@ -0,0 +1,155 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// Invite приглашение для регистрации. Код хранится только в виде хеша
+type Invite struct {
+	InviteId  uuid.UUID      `db:"invite_id" json:"invite_id"`
+	CodeHash  string         `db:"code_hash" json:"-"`
+	Email     sql.NullString `db:"email" json:"email"` // NULL - для любого email
+	Roles     pq.StringArray `db:"roles" json:"roles"` // Роли сверх роли по умолчанию
+	MaxUses   int            `db:"max_uses" json:"max_uses"`
+	Uses      int            `db:"uses" json:"uses"`
+	CreatedBy uuid.NullUUID  `db:"created_by" json:"created_by"`
+	ExpiresAt sql.NullTime   `db:"expires_at" json:"expires_at"`
+	CreatedAt time.Time      `db:"created_at" json:"created_at"`
+	RevokedAt sql.NullTime   `db:"revoked_at" json:"revoked_at"`
+}
+
+// inviteColumns столбцы auth.invites, читаемые в структуру Invite
+const inviteColumns = `invite_id, code_hash, email, roles, max_uses, uses, created_by, expires_at, created_at, revoked_at`
+
+// CreateInvite сохраняет новое приглашение. Неизвестные роли считаются ошибкой запроса
+func CreateInvite(ctx context.Context, invite *Invite) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	if len(invite.Roles) > 0 {
+		var known int
+		err := db.GetContext(ctx, &known, `
+			SELECT count(*) FROM auth.roles WHERE tenant_id = $2 AND role_name = ANY($1)
+		`, invite.Roles, tenant.ID(ctx))
+		if err != nil {
+			return errs.Internal("Ошибка при проверке ролей", err)
+		}
+		if known != len(invite.Roles) {
+			return ErrUnknownRole
+		}
+	}
+	if invite.Roles == nil {
+		invite.Roles = pq.StringArray{}
+	}
+
+	err := db.QueryRowxContext(ctx, `
+		INSERT INTO auth.invites (invite_id, code_hash, email, roles, max_uses, created_by, expires_at, tenant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`, invite.InviteId, invite.CodeHash, invite.Email, invite.Roles, invite.MaxUses, invite.CreatedBy, invite.ExpiresAt,
+		tenant.ID(ctx)).
+		Scan(&invite.CreatedAt)
+	if err != nil {
+		return errs.Internal("Ошибка создания приглашения", err)
+	}
+
+	return nil
+}
+
+// ListInvites возвращает неотозванные приглашения, в том числе истекшие и исчерпанные
+func ListInvites(ctx context.Context) ([]*Invite, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var invites []*Invite
+	err := db.SelectContext(ctx, &invites, `
+		SELECT `+inviteColumns+`
+		FROM auth.invites
+		WHERE tenant_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении приглашений", err)
+	}
+
+	return invites, nil
+}
+
+// RevokeInvite отзывает приглашение
+func RevokeInvite(ctx context.Context, inviteID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE auth.invites
+		SET revoked_at = CURRENT_TIMESTAMP
+		WHERE invite_id = $1 AND tenant_id = $2 AND revoked_at IS NULL
+	`, inviteID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка отзыва приглашения", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrInviteNotFound
+	}
+
+	return nil
+}
+
+// CreateUserWithInvite создает пользователя по приглашению в одной транзакции: использование
+// кода засчитывается, только если пользователь создан, а роли из приглашения назначаются сразу
+func CreateUserWithInvite(ctx context.Context, userID uuid.UUID, email, emailCanonical, passwordHash, codeHash string) (*User, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка строки UPDATE не дает двум регистрациям превысить max_uses
+	var roles pq.StringArray
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE auth.invites
+		SET uses = uses + 1
+		WHERE code_hash = $1
+		  AND tenant_id = $3
+		  AND revoked_at IS NULL
+		  AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		  AND uses < max_uses
+		  AND (email IS NULL OR lower(email) = lower($2))
+		RETURNING roles
+	`, codeHash, email, tenant.ID(ctx)).Scan(&roles)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrInviteNotFound
+		}
+		return nil, errs.Internal("Ошибка при использовании приглашения", err)
+	}
+
+	user, err := createUser(ctx, tx, userID, email, emailCanonical, passwordHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(roles) > 0 {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO auth.user_roles (user_id, role_id)
+			SELECT $1, role_id FROM auth.roles WHERE tenant_id = $3 AND role_name = ANY($2)
+			ON CONFLICT (user_id, role_id) DO NOTHING
+		`, userID, roles, tenant.ID(ctx))
+		if err != nil {
+			return nil, errs.Internal("Ошибка при назначении ролей из приглашения", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return user, nil
+}
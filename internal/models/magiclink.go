@@ -0,0 +1,55 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreateMagicLink сохраняет хеш одноразового токена входа
+func CreateMagicLink(ctx context.Context, tokenHash string, userID uuid.UUID, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO auth.magic_links (token_hash, user_id, expires_at)
+		SELECT $1, user_id, $3 FROM auth.users WHERE user_id = $2 AND tenant_id = $4
+	`, tokenHash, userID, expiresAt, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка создания ссылки входа", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// ConsumeMagicLink атомарно помечает ссылку использованной и возвращает ID пользователя.
+// Повторное использование, истекшая или неизвестная ссылка дают NotFound
+func ConsumeMagicLink(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var userID uuid.UUID
+	err := db.GetContext(ctx, &userID, `
+		UPDATE auth.magic_links l
+		SET consumed_at = CURRENT_TIMESTAMP
+		FROM auth.users u
+		WHERE l.token_hash = $1 AND l.consumed_at IS NULL AND l.expires_at > CURRENT_TIMESTAMP
+		  AND u.user_id = l.user_id AND u.tenant_id = $2
+		RETURNING l.user_id
+	`, tokenHash, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrMagicLinkNotFound
+		}
+		return uuid.Nil, errs.Internal("ошибка при использовании ссылки входа", err)
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,422 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"slices"
+	"time"
+)
+
+// OrgRoleOwner роль владельца организации. У организации всегда остается хотя бы один владелец
+const OrgRoleOwner = "owner"
+
+// Organisation организация внутри арендатора, например ресторан сети
+type Organisation struct {
+	OrgId     uuid.UUID     `db:"org_id" json:"org_id"`
+	TenantId  uuid.UUID     `db:"tenant_id" json:"tenant_id"`
+	Name      string        `db:"name" json:"name"`
+	CreatedBy uuid.NullUUID `db:"created_by" json:"created_by"`
+	CreatedAt time.Time     `db:"created_at" json:"created_at"`
+}
+
+// OrganisationMembership организация и роли пользователя в ней
+type OrganisationMembership struct {
+	Organisation
+	Roles    pq.StringArray `db:"roles" json:"roles"`
+	JoinedAt time.Time      `db:"joined_at" json:"joined_at"`
+}
+
+// OrganisationMember участник организации
+type OrganisationMember struct {
+	UserId    uuid.UUID      `db:"user_id" json:"user_id"`
+	Email     string         `db:"email" json:"email"`
+	Username  string         `db:"username" json:"username"`
+	Roles     pq.StringArray `db:"roles" json:"roles"`
+	JoinedAt  time.Time      `db:"joined_at" json:"joined_at"`
+	UpdatedAt time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// OrganisationInvitation приглашение в организацию. Токен из письма хранится только в виде хеша
+type OrganisationInvitation struct {
+	InvitationId uuid.UUID      `db:"invitation_id" json:"invitation_id"`
+	OrgId        uuid.UUID      `db:"org_id" json:"org_id"`
+	Email        string         `db:"email" json:"email"`
+	Roles        pq.StringArray `db:"roles" json:"roles"`
+	TokenHash    string         `db:"token_hash" json:"-"`
+	InvitedBy    uuid.NullUUID  `db:"invited_by" json:"invited_by"`
+	ExpiresAt    time.Time      `db:"expires_at" json:"expires_at"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+}
+
+// organisationColumns столбцы auth.organisations с псевдонимом o
+const organisationColumns = `o.org_id, o.tenant_id, o.name, o.created_by, o.created_at`
+
+// CreateOrganisation создает организацию и делает ownerID ее владельцем
+func CreateOrganisation(ctx context.Context, org *Organisation, ownerID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	org.TenantId = tenant.ID(ctx)
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO auth.organisations (org_id, tenant_id, name, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`, org.OrgId, org.TenantId, org.Name, org.CreatedBy).Scan(&org.CreatedAt)
+	if err != nil {
+		return errs.Internal("Ошибка создания организации", err)
+	}
+
+	// Владелец должен быть пользователем того же арендатора
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO auth.organisation_members (org_id, user_id, roles)
+		SELECT $1, user_id, $3
+		FROM auth.users
+		WHERE user_id = $2 AND tenant_id = $4
+	`, org.OrgId, ownerID, pq.Array([]string{OrgRoleOwner}), org.TenantId)
+	if err != nil {
+		return errs.Internal("Ошибка добавления владельца организации", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrUserNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return nil
+}
+
+// GetOrganisation получает организацию арендатора по ID
+func GetOrganisation(ctx context.Context, orgID uuid.UUID) (*Organisation, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	org := new(Organisation)
+	err := db.GetContext(ctx, org, `
+		SELECT `+organisationColumns+`
+		FROM auth.organisations o
+		WHERE o.org_id = $1 AND o.tenant_id = $2
+	`, orgID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganisationNotFound
+		}
+		return nil, errs.Internal("Ошибка при получении организации", err)
+	}
+
+	return org, nil
+}
+
+// ListUserOrganisations возвращает организации, в которых состоит пользователь, с его ролями
+func ListUserOrganisations(ctx context.Context, userID uuid.UUID) ([]*OrganisationMembership, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var memberships []*OrganisationMembership
+	err := db.SelectContext(ctx, &memberships, `
+		SELECT `+organisationColumns+`, m.roles, m.created_at AS joined_at
+		FROM auth.organisation_members m
+		JOIN auth.organisations o ON o.org_id = m.org_id
+		WHERE m.user_id = $1 AND o.tenant_id = $2
+		ORDER BY o.name, o.org_id
+	`, userID, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении организаций пользователя", err)
+	}
+
+	return memberships, nil
+}
+
+// GetOrganisationRoles возвращает роли пользователя в организации арендатора
+func GetOrganisationRoles(ctx context.Context, orgID, userID uuid.UUID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var roles pq.StringArray
+	err := db.GetContext(ctx, &roles, `
+		SELECT m.roles
+		FROM auth.organisation_members m
+		JOIN auth.organisations o ON o.org_id = m.org_id
+		WHERE m.org_id = $1 AND m.user_id = $2 AND o.tenant_id = $3
+	`, orgID, userID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganisationMemberNotFound
+		}
+		return nil, errs.Internal("Ошибка при получении ролей в организации", err)
+	}
+
+	return roles, nil
+}
+
+// ListOrganisationMembers возвращает участников организации
+func ListOrganisationMembers(ctx context.Context, orgID uuid.UUID) ([]*OrganisationMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	var members []*OrganisationMember
+	err := db.SelectContext(ctx, &members, `
+		SELECT u.user_id, u.email, u.username, m.roles, m.created_at AS joined_at, m.updated_at
+		FROM auth.organisation_members m
+		JOIN auth.organisations o ON o.org_id = m.org_id
+		JOIN auth.users u ON u.user_id = m.user_id
+		WHERE m.org_id = $1 AND o.tenant_id = $2
+		ORDER BY m.created_at, u.user_id
+	`, orgID, tenant.ID(ctx))
+	if err != nil {
+		return nil, errs.Internal("Ошибка при получении участников организации", err)
+	}
+
+	return members, nil
+}
+
+// SetOrganisationMemberRoles заменяет роли участника. Последний владелец не может потерять роль owner
+func SetOrganisationMemberRoles(ctx context.Context, orgID, userID uuid.UUID, roles []string) (*OrganisationMember, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockOrganisation(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(roles, OrgRoleOwner) {
+		if err = checkOtherOwner(ctx, tx, orgID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	member := new(OrganisationMember)
+	err = tx.GetContext(ctx, member, `
+		WITH updated AS (
+			UPDATE auth.organisation_members
+			SET roles = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE org_id = $1 AND user_id = $2
+			RETURNING user_id, roles, created_at, updated_at
+		)
+		SELECT u.user_id, u.email, u.username, upd.roles, upd.created_at AS joined_at, upd.updated_at
+		FROM updated upd
+		JOIN auth.users u ON u.user_id = upd.user_id
+	`, orgID, userID, pq.Array(roles))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganisationMemberNotFound
+		}
+		return nil, errs.Internal("Ошибка при изменении ролей участника", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return member, nil
+}
+
+// RemoveOrganisationMember исключает пользователя из организации. Последнего владельца исключить нельзя
+func RemoveOrganisationMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockOrganisation(ctx, tx, orgID); err != nil {
+		return err
+	}
+	if err = checkOtherOwner(ctx, tx, orgID, userID); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM auth.organisation_members WHERE org_id = $1 AND user_id = $2
+	`, orgID, userID)
+	if err != nil {
+		return errs.Internal("Ошибка при исключении участника", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrOrganisationMemberNotFound
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return nil
+}
+
+// lockOrganisation проверяет, что организация принадлежит арендатору, и блокирует ее строку:
+// так два владельца не могут одновременно снять с себя роль owner
+func lockOrganisation(ctx context.Context, tx *sqlx.Tx, orgID uuid.UUID) error {
+	var locked uuid.UUID
+	err := tx.GetContext(ctx, &locked, `
+		SELECT org_id FROM auth.organisations WHERE org_id = $1 AND tenant_id = $2 FOR UPDATE
+	`, orgID, tenant.ID(ctx))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrganisationNotFound
+		}
+		return errs.Internal("Ошибка при блокировке организации", err)
+	}
+
+	return nil
+}
+
+// checkOtherOwner проверяет, что без userID у организации останется владелец
+func checkOtherOwner(ctx context.Context, tx *sqlx.Tx, orgID, userID uuid.UUID) error {
+	var isOwner, otherOwners bool
+	err := tx.QueryRowxContext(ctx, `
+		SELECT
+			COALESCE(bool_or(user_id = $2), false),
+			COALESCE(bool_or(user_id <> $2), false)
+		FROM auth.organisation_members
+		WHERE org_id = $1 AND $3 = ANY(roles)
+	`, orgID, userID, OrgRoleOwner).Scan(&isOwner, &otherOwners)
+	if err != nil {
+		return errs.Internal("Ошибка при проверке владельцев организации", err)
+	}
+	if isOwner && !otherOwners {
+		return ErrLastOrganisationOwner
+	}
+
+	return nil
+}
+
+// CreateOrganisationInvitation сохраняет приглашение в организацию арендатора
+func CreateOrganisationInvitation(ctx context.Context, inv *OrganisationInvitation) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	if inv.Roles == nil {
+		inv.Roles = pq.StringArray{}
+	}
+
+	err := db.QueryRowxContext(ctx, `
+		INSERT INTO auth.organisation_invitations (invitation_id, org_id, email, roles, token_hash, invited_by, expires_at)
+		SELECT $1, org_id, $3, $4, $5, $6, $7
+		FROM auth.organisations
+		WHERE org_id = $2 AND tenant_id = $8
+		RETURNING created_at
+	`, inv.InvitationId, inv.OrgId, inv.Email, inv.Roles, inv.TokenHash, inv.InvitedBy, inv.ExpiresAt, tenant.ID(ctx)).
+		Scan(&inv.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrOrganisationNotFound
+		}
+		return errs.Internal("Ошибка создания приглашения в организацию", err)
+	}
+
+	return nil
+}
+
+// RevokeOrganisationInvitation отзывает непринятое приглашение в организацию
+func RevokeOrganisationInvitation(ctx context.Context, orgID, invitationID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	res, err := db.ExecContext(ctx, `
+		UPDATE auth.organisation_invitations i
+		SET revoked_at = CURRENT_TIMESTAMP
+		FROM auth.organisations o
+		WHERE i.invitation_id = $1
+		  AND i.org_id = $2
+		  AND o.org_id = i.org_id
+		  AND o.tenant_id = $3
+		  AND i.accepted_at IS NULL
+		  AND i.revoked_at IS NULL
+	`, invitationID, orgID, tenant.ID(ctx))
+	if err != nil {
+		return errs.Internal("Ошибка отзыва приглашения в организацию", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrOrganisationInvitationNotFound
+	}
+
+	return nil
+}
+
+// AcceptOrganisationInvitation принимает приглашение в одной транзакции: приглашение помечается
+// принятым, только если пользователь добавлен. Приглашение действует только для своего email;
+// если пользователь уже участник, роли из приглашения добавляются к имеющимся
+func AcceptOrganisationInvitation(
+	ctx context.Context,
+	tokenHash string,
+	userID uuid.UUID,
+	email string,
+) (*OrganisationMembership, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		orgID uuid.UUID
+		roles pq.StringArray
+	)
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE auth.organisation_invitations i
+		SET accepted_at = CURRENT_TIMESTAMP
+		FROM auth.organisations o
+		WHERE i.token_hash = $1
+		  AND o.org_id = i.org_id
+		  AND o.tenant_id = $3
+		  AND i.accepted_at IS NULL
+		  AND i.revoked_at IS NULL
+		  AND i.expires_at > CURRENT_TIMESTAMP
+		  AND lower(i.email) = lower($2)
+		RETURNING i.org_id, i.roles
+	`, tokenHash, email, tenant.ID(ctx)).Scan(&orgID, &roles)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrganisationInvitationNotFound
+		}
+		return nil, errs.Internal("Ошибка при принятии приглашения в организацию", err)
+	}
+
+	membership := new(OrganisationMembership)
+	err = tx.GetContext(ctx, membership, `
+		WITH member AS (
+			INSERT INTO auth.organisation_members AS m (org_id, user_id, roles)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (org_id, user_id) DO UPDATE
+			SET roles = ARRAY(SELECT DISTINCT unnest(m.roles || EXCLUDED.roles) ORDER BY 1),
+			    updated_at = CURRENT_TIMESTAMP
+			RETURNING org_id, roles, created_at
+		)
+		SELECT `+organisationColumns+`, member.roles, member.created_at AS joined_at
+		FROM member
+		JOIN auth.organisations o ON o.org_id = member.org_id
+	`, orgID, userID, roles)
+	if err != nil {
+		return nil, errs.Internal("Ошибка добавления участника организации", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return membership, nil
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"time"
+)
+
+// Tenant арендатор: сеть ресторанов со своими пользователями и ролями
+type Tenant struct {
+	TenantId   uuid.UUID    `db:"tenant_id" json:"tenant_id"`
+	Slug       string       `db:"slug" json:"slug"`
+	Name       string       `db:"name" json:"name"`
+	CreatedAt  time.Time    `db:"created_at" json:"created_at"`
+	DisabledAt sql.NullTime `db:"disabled_at" json:"disabled_at"`
+}
+
+// GetTenant получает активного арендатора по ID или slug
+func GetTenant(ctx context.Context, idOrSlug string) (*Tenant, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	query := `
+		SELECT tenant_id, slug, name, created_at, disabled_at
+		FROM auth.tenants
+		WHERE slug = $1 AND disabled_at IS NULL
+	`
+	args := []any{idOrSlug}
+	if id, err := uuid.Parse(idOrSlug); err == nil {
+		query = `
+			SELECT tenant_id, slug, name, created_at, disabled_at
+			FROM auth.tenants
+			WHERE tenant_id = $1 AND disabled_at IS NULL
+		`
+		args = []any{id}
+	}
+
+	t := new(Tenant)
+	if err := db.GetContext(ctx, t, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, errs.Internal("ошибка при получении арендатора", err)
+	}
+
+	return t, nil
+}
+
+// CreateTenant создает арендатора с собственным набором ролей. Роль по умолчанию
+// создается всегда, остальные - из roles
+func CreateTenant(ctx context.Context, t *Tenant, roles []string) error {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs.Internal("Ошибка при начале транзакции", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowxContext(ctx, `
+		INSERT INTO auth.tenants (tenant_id, slug, name)
+		VALUES ($1, $2, $3)
+		RETURNING created_at
+	`, t.TenantId, t.Slug, t.Name).Scan(&t.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return ErrTenantExists
+		}
+		return errs.Internal("Ошибка создания арендатора", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO auth.roles (tenant_id, role_name)
+		SELECT $1, unnest($2::TEXT[])
+		ON CONFLICT (tenant_id, role_name) DO NOTHING
+	`, t.TenantId, pq.Array(append([]string{defaultRole}, roles...)))
+	if err != nil {
+		return errs.Internal("Ошибка создания ролей арендатора", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errs.Internal("Ошибка при фиксации транзакции", err)
+	}
+
+	return nil
+}
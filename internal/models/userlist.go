@@ -0,0 +1,219 @@
+package models
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/tenant"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поля сортировки списка пользователей
+const (
+	UserSortCreatedAt   = "created_at"
+	UserSortEmail       = "email"
+	UserSortLastLoginAt = "last_login_at"
+)
+
+// userSortExpressions выражения сортировки, совпадающие с индексами из migrations/016_tenants.sql
+var userSortExpressions = map[string]string{
+	UserSortCreatedAt:   `created_at`,
+	UserSortEmail:       `lower(email) COLLATE "C"`,
+	UserSortLastLoginAt: `COALESCE(last_login_at, 'epoch'::TIMESTAMP)`,
+}
+
+// ListUsersFilter параметры поиска пользователей администратором
+type ListUsersFilter struct {
+	EmailPrefix    string
+	Role           string
+	Status         string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	LastLoginFrom  *time.Time
+	LastLoginTo    *time.Time
+	SortBy         string // UserSortCreatedAt по умолчанию
+	Descending     bool
+	PageSize       int
+	Cursor         string // Непрозрачный курсор из предыдущей страницы
+	WithTotalCount bool
+}
+
+// ListUsersPage страница списка пользователей
+type ListUsersPage struct {
+	Users      []*User
+	NextCursor string // Пустой - страниц больше нет
+	TotalCount int64  // Заполняется, если запрошен WithTotalCount
+}
+
+// userCursor позиция keyset-пагинации: значение поля сортировки и user_id последней строки
+type userCursor struct {
+	SortBy string    `json:"s"`
+	Value  string    `json:"v"`
+	UserID uuid.UUID `json:"id"`
+}
+
+// ListUsers ищет пользователей с keyset-пагинацией. Каждое сочетание сортировки
+// использует свой индекс (значение, user_id), поэтому глубина страницы не влияет на скорость
+func ListUsers(ctx context.Context, filter ListUsersFilter) (*ListUsersPage, error) {
+	ctx, cancel := context.WithTimeout(ctx, dbTimeOut)
+	defer cancel()
+
+	if filter.SortBy == "" {
+		filter.SortBy = UserSortCreatedAt
+	}
+	sortExpr, ok := userSortExpressions[filter.SortBy]
+	if !ok {
+		return nil, ErrUnknownSortField.With("sort_by", filter.SortBy)
+	}
+
+	var q queryBuilder
+	q.add("tenant_id = " + q.arg(tenant.ID(ctx)))
+	q.filters(filter)
+	countWhere, countArgs := q.where(), append([]any(nil), q.args...)
+
+	if filter.Cursor != "" {
+		cursor, err := decodeUserCursor(filter.Cursor)
+		if err != nil || cursor.SortBy != filter.SortBy {
+			return nil, ErrInvalidCursor
+		}
+		value, err := cursorValue(filter.SortBy, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+
+		op := ">"
+		if filter.Descending {
+			op = "<"
+		}
+		q.add(fmt.Sprintf("(%s, user_id) %s (%s, %s)", sortExpr, op, q.arg(value), q.arg(cursor.UserID)))
+	}
+
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	// Берем на одну строку больше, чтобы понять, есть ли следующая страница
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM auth.users
+		%s
+		ORDER BY %s %s, user_id %s
+		LIMIT %d
+	`, userColumns, q.where(), sortExpr, direction, direction, filter.PageSize+1)
+
+	page := new(ListUsersPage)
+	if err := db.SelectContext(ctx, &page.Users, query, q.args...); err != nil {
+		return nil, errs.Internal("ошибка при получении списка пользователей", err)
+	}
+
+	if len(page.Users) > filter.PageSize {
+		page.Users = page.Users[:filter.PageSize]
+		page.NextCursor = encodeUserCursor(filter.SortBy, page.Users[len(page.Users)-1])
+	}
+
+	if filter.WithTotalCount {
+		err := db.GetContext(ctx, &page.TotalCount, `SELECT COUNT(*) FROM auth.users `+countWhere, countArgs...)
+		if err != nil {
+			return nil, errs.Internal("ошибка при подсчете пользователей", err)
+		}
+	}
+
+	return page, nil
+}
+
+// queryBuilder собирает WHERE с позиционными параметрами
+type queryBuilder struct {
+	conds []string
+	args  []any
+}
+
+func (q *queryBuilder) arg(v any) string {
+	q.args = append(q.args, v)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+func (q *queryBuilder) add(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+func (q *queryBuilder) where() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(q.conds, " AND ")
+}
+
+func (q *queryBuilder) filters(f ListUsersFilter) {
+	if f.EmailPrefix != "" {
+		// Диапазон [prefix, prefix+U+10FFFF) в сортировке "C" - это range scan по индексу
+		prefix := strings.ToLower(f.EmailPrefix)
+		q.add(fmt.Sprintf(`lower(email) COLLATE "C" >= %s AND lower(email) COLLATE "C" < %s`,
+			q.arg(prefix), q.arg(prefix+"\U0010FFFF")))
+	}
+	if f.Role != "" {
+		q.add(fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM auth.user_roles ur JOIN auth.roles r ON ur.role_id = r.role_id
+			WHERE ur.user_id = users.user_id AND r.tenant_id = users.tenant_id AND r.role_name = %s)`, q.arg(f.Role)))
+	}
+	if f.Status != "" {
+		q.add("status = " + q.arg(f.Status))
+	}
+	if f.CreatedFrom != nil {
+		q.add("created_at >= " + q.arg(*f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		q.add("created_at < " + q.arg(*f.CreatedTo))
+	}
+	if f.LastLoginFrom != nil {
+		q.add("last_login_at >= " + q.arg(*f.LastLoginFrom))
+	}
+	if f.LastLoginTo != nil {
+		q.add("last_login_at < " + q.arg(*f.LastLoginTo))
+	}
+}
+
+func encodeUserCursor(sortBy string, last *User) string {
+	c := userCursor{SortBy: sortBy, UserID: last.UserId}
+	switch sortBy {
+	case UserSortEmail:
+		c.Value = strings.ToLower(last.Email)
+	case UserSortLastLoginAt:
+		if last.LastLoginAt.Valid {
+			c.Value = last.LastLoginAt.Time.Format(time.RFC3339Nano)
+		} else {
+			c.Value = time.Unix(0, 0).UTC().Format(time.RFC3339Nano)
+		}
+	default:
+		c.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(raw string) (*userCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(userCursor)
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// cursorValue приводит значение курсора к типу столбца сортировки
+func cursorValue(sortBy, value string) (any, error) {
+	if sortBy == UserSortEmail {
+		return value, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
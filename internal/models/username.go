@@ -0,0 +1,18 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// GenerateUsername генерирует username по умолчанию в формате "user_XXXXXXXXXXXX".
+// Раньше имя генерировал триггер generate_username из первых символов UUID;
+// 48 бит случайного суффикса делают коллизии редкими даже при массовом импорте,
+// а createUser повторяет попытку при конфликте
+func GenerateUsername() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand не возвращает ошибок на поддерживаемых платформах
+	}
+	return "user_" + hex.EncodeToString(b)
+}
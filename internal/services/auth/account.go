@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/services/mailer"
+	"auth-service/pkg/logger"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// DeleteAccount помечает аккаунт к удалению после подтверждения паролем, а для аккаунтов без
+// пароля - недавним входом: authTime - время входа из токена, с которым пришел запрос.
+// Вход сразу блокируется, данные удаляются фоновой очисткой по истечении льготного периода
+func (s *Service) DeleteAccount(ctx context.Context, userID uuid.UUID, password string, authTime time.Time) (time.Time, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "delete_account"))
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return time.Time{}, err
+	}
+
+	if err = s.confirmAccountOwner(ctx, l, user, password, authTime); err != nil {
+		return time.Time{}, err
+	}
+
+	purgeAfter := time.Now().Add(s.cfg.AccountDeletion.GracePeriod)
+	if err = models.RequestAccountDeletion(ctx, userID, purgeAfter); err != nil {
+		l.Error("ошибка при запросе удаления аккаунта", logger.Err(err))
+		return time.Time{}, err
+	}
+
+	s.sendMail(l, mailer.Message{
+		To:      user.Email,
+		Subject: "Аккаунт будет удален",
+		Body: fmt.Sprintf("Ваш аккаунт будет окончательно удален %s.\n"+
+			"До этого момента удаление можно отменить через поддержку.\n",
+			purgeAfter.Format("02.01.2006")),
+	})
+
+	l.Info("запрошено удаление аккаунта", slog.Time("purge_after", purgeAfter))
+	return purgeAfter, nil
+}
+
+// confirmAccountOwner подтверждает, что действие выполняет владелец аккаунта. Аккаунты,
+// созданные через провайдера или magic link, не имеют пароля: для них вход должен быть
+// не раньше reauth_window назад, иначе клиент должен заново войти через провайдера или ссылку
+func (s *Service) confirmAccountOwner(
+	ctx context.Context,
+	l *slog.Logger,
+	user *models.User,
+	password string,
+	authTime time.Time,
+) error {
+	if user.PasswordHash == models.UnusablePasswordHash {
+		if authTime.IsZero() || time.Since(authTime) > s.cfg.AccountDeletion.ReauthWindow {
+			l.Debug("вход без пароля слишком давно", slog.Time("auth_time", authTime))
+			return ErrReauthRequired
+		}
+		return nil
+	}
+
+	if password == "" {
+		return ErrWrongPassword
+	}
+	ok, err := s.CheckPasswordHash(ctx, password, user.PasswordHash)
+	if err != nil {
+		l.Warn("ошибка проверки пароля", logger.Err(err))
+		return hashingError(err)
+	}
+	if !ok {
+		l.Debug("неверный пароль")
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// ExportUserData возвращает все данные пользователя в JSON
+func (s *Service) ExportUserData(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "export_user_data"))
+
+	export, err := models.ExportUserData(ctx, userID)
+	if err != nil {
+		l.Debug("ошибка выгрузки данных пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		l.Error("ошибка сериализации данных пользователя", logger.Err(err))
+		return nil, errs.Internal("failed to export user data", err)
+	}
+
+	l.Info("данные пользователя выгружены")
+	return data, nil
+}
+
+// purgeLoop периодически удаляет аккаунты с истекшим льготным периодом
+func (s *Service) purgeLoop() {
+	defer s.wg.Done()
+
+	l := s.log.With(slog.String("op", "purge_deleted_users"))
+	ticker := time.NewTicker(s.cfg.AccountDeletion.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			n, err := models.PurgeDeletedUsers(context.Background())
+			if err != nil {
+				l.Error("ошибка очистки удаленных аккаунтов", logger.Err(err))
+				continue
+			}
+			if n > 0 {
+				l.Info("удаленные аккаунты очищены", slog.Int64("count", n))
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"auth-service/config"
+	"auth-service/internal/models"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestConfirmPasswordlessAccountOwner(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := &Service{
+		log: log,
+		cfg: &config.Config{AccountDeletion: config.AccountDeletion{ReauthWindow: 5 * time.Minute}},
+	}
+	user := &models.User{PasswordHash: models.UnusablePasswordHash}
+
+	tests := []struct {
+		name     string
+		password string
+		authTime time.Time
+		wantErr  error
+	}{
+		{name: "недавний вход", authTime: time.Now().Add(-time.Minute)},
+		{name: "давний вход", authTime: time.Now().Add(-time.Hour), wantErr: ErrReauthRequired},
+		{name: "время входа неизвестно", wantErr: ErrReauthRequired},
+		{name: "пароль не заменяет вход", password: "!", authTime: time.Now().Add(-time.Hour), wantErr: ErrReauthRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.confirmAccountOwner(context.Background(), log, user, tt.password, tt.authTime)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("confirmAccountOwner = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("confirmAccountOwner = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/internal/tenant"
+	"auth-service/pkg/logger"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// apiKeyPrefix отличает API ключи от JWT в VerifyToken и помогает сканерам секретов
+	apiKeyPrefix = "cak_"
+	// apiKeyIDLen длина публичной части ключа (hex), по ней ключ ищется в БД
+	apiKeyIDLen = 12
+)
+
+// CreateApiKey создает API ключ пользователя и возвращает его секрет (единственный раз).
+// roles должны быть подмножеством текущих ролей пользователя; пустой список - все роли
+func (s *Service) CreateApiKey(
+	ctx context.Context,
+	userID uuid.UUID,
+	name string,
+	roles []string,
+	expiresAt *time.Time,
+) (*models.ApiKey, string, error) {
+	l := s.log.With(slog.String("op", "create_api_key"), slog.String("user_id", userID.String()))
+
+	if len(roles) > 0 {
+		userRoles, err := models.GetUserRoles(ctx, userID)
+		if err != nil {
+			l.Error("ошибка при получении ролей пользователя", logger.Err(err))
+			return nil, "", err
+		}
+		for _, role := range roles {
+			if !slices.Contains(userRoles, role) {
+				l.Debug("запрошена роль, которой нет у пользователя", slog.String("role", role))
+				return nil, "", ErrRoleNotAssigned.With("role", role)
+			}
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrExpiryInPast.WithMessage("срок действия ключа уже истек")
+	}
+
+	prefix := make([]byte, apiKeyIDLen/2)
+	if _, err := rand.Read(prefix); err != nil {
+		panic(err) // crypto/rand не возвращает ошибок на поддерживаемых платформах
+	}
+	secret := randomToken(32)
+
+	key := &models.ApiKey{
+		KeyId:      uuid.New(),
+		UserId:     userID,
+		Name:       name,
+		Prefix:     hex.EncodeToString(prefix),
+		SecretHash: hashSecret(secret),
+	}
+	if len(roles) > 0 {
+		key.Roles = pq.StringArray(roles)
+	}
+	if expiresAt != nil {
+		key.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	if err := models.CreateApiKey(ctx, key); err != nil {
+		l.Error("ошибка создания API ключа", logger.Err(err))
+		return nil, "", err
+	}
+
+	l.Info("API ключ создан", slog.String("key_id", key.KeyId.String()))
+	return key, apiKeyPrefix + key.Prefix + "_" + secret, nil
+}
+
+// ListApiKeys возвращает активные API ключи пользователя
+func (s *Service) ListApiKeys(ctx context.Context, userID uuid.UUID) ([]*models.ApiKey, error) {
+	keys, err := models.ListApiKeys(ctx, userID)
+	if err != nil {
+		s.log.Error("ошибка при получении API ключей", logger.Err(err))
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeApiKey отзывает API ключ пользователя
+func (s *Service) RevokeApiKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	l := s.log.With(slog.String("op", "revoke_api_key"), slog.String("key_id", keyID.String()))
+
+	if err := models.RevokeApiKey(ctx, userID, keyID); err != nil {
+		l.Debug("ошибка отзыва API ключа", logger.Err(err))
+		return err
+	}
+
+	l.Info("API ключ отозван")
+	return nil
+}
+
+// verifyApiKey проверяет API ключ и возвращает данные владельца в том же виде, что и для JWT
+func (s *Service) verifyApiKey(ctx context.Context, rawKey string) (*models.TokenInfo, error) {
+	rest := strings.TrimPrefix(rawKey, apiKeyPrefix)
+	if len(rest) <= apiKeyIDLen+1 || rest[apiKeyIDLen] != '_' {
+		s.log.Warn("Неверный формат API ключа")
+		return nil, ErrInvalidApiKey
+	}
+	prefix, secret := rest[:apiKeyIDLen], rest[apiKeyIDLen+1:]
+
+	key, err := models.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, models.ErrApiKeyNotFound) {
+			s.log.Warn("API ключ не найден, отозван или истек", slog.String("prefix", prefix))
+			return nil, ErrInvalidApiKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.SecretHash), []byte(hashSecret(secret))) != 1 {
+		s.log.Warn("Неверный секрет API ключа", slog.String("prefix", prefix))
+		return nil, ErrInvalidApiKey
+	}
+
+	// Арендатор ключа - арендатор владельца; явно указанный в запросе арендатор должен с ним совпасть
+	if requested, explicit := tenant.FromContext(ctx); explicit && requested != key.TenantId {
+		s.log.Warn("API ключ другого арендатора", slog.String("prefix", prefix))
+		return nil, ErrTokenTenantMismatch
+	}
+	if err = s.checkTenantActive(ctx, key.TenantId); err != nil {
+		s.log.Warn("API ключ отключенного арендатора", slog.String("prefix", prefix))
+		return nil, err
+	}
+	ctx = tenant.With(ctx, key.TenantId)
+
+	user, err := models.GetUserByID(ctx, key.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkUserActive(user); err != nil {
+		s.log.Warn("API ключ неактивного аккаунта", slog.String("prefix", prefix))
+		return nil, ErrInvalidApiKey
+	}
+
+	// Роли берем актуальные: роль, снятая с владельца, перестает действовать и для ключа
+	userRoles, err := models.GetUserRoles(ctx, key.UserId)
+	if err != nil {
+		return nil, err
+	}
+	roles := userRoles
+	if key.Roles != nil {
+		roles = slices.DeleteFunc(slices.Clone(userRoles), func(role string) bool {
+			return !slices.Contains(key.Roles, role)
+		})
+	}
+
+	if err = models.TouchApiKey(ctx, key.KeyId); err != nil {
+		// Неудачное обновление last_used_at не должно ломать аутентификацию
+		s.log.Warn("Не удалось обновить время использования API ключа", logger.Err(err))
+	}
+
+	s.log.Info("API ключ успешно проверен",
+		slog.String("email", s.HashEmail(user.Email)),
+		slog.String("key_id", key.KeyId.String()),
+	)
+
+	return &models.TokenInfo{
+		UserID:   user.UserId.String(),
+		Email:    user.Email,
+		Roles:    roles,
+		IsValid:  true,
+		IsApiKey: true,
+		TenantID: user.TenantId.String(),
+
+		ApiKeyScoped: key.Roles != nil,
+	}, nil
+}
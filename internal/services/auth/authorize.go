@@ -0,0 +1,260 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/tenant"
+	"auth-service/pkg/logger"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// authorizeAudience аудитория подписанного запроса авторизации, который authorization endpoint
+	// передает странице входа. VerifyToken принимает только аудиторию токенов доступа
+	authorizeAudience = "auth-service/authorize"
+
+	// pkceMethodS256 единственный поддерживаемый метод PKCE: plain не защищает от перехвата кода
+	pkceMethodS256 = "S256"
+
+	// pkceChallengeLength длина code_challenge S256: SHA-256 в base64url без выравнивания
+	pkceChallengeLength = 43
+)
+
+// AuthorizationRequest параметры запроса к authorization endpoint (RFC 6749 §4.1.1, RFC 7636 §4.3)
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// AuthorizationError ошибка запроса авторизации, о которой клиент узнает через redirect_uri
+// (RFC 6749 §4.1.2.1). Code - код ошибки OAuth2
+type AuthorizationError struct {
+	Code        string
+	Description string
+}
+
+func (e *AuthorizationError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// CodeTokens результат обмена кода авторизации
+type CodeTokens struct {
+	AccessToken string
+	IDToken     string
+	ExpiresIn   time.Duration
+}
+
+// StartAuthorization проверяет запрос авторизации и возвращает адрес страницы входа фронтенда
+// с подписанным запросом. Неизвестный клиент или незарегистрированный redirect_uri дают
+// ErrUnknownClient и ErrInvalidRedirectURI: на такой адрес перенаправлять нельзя. Остальные
+// ошибки - *AuthorizationError для передачи клиенту через redirect_uri
+func (s *Service) StartAuthorization(req *AuthorizationRequest) (string, error) {
+	if s.idKey == nil {
+		return "", ErrOIDCDisabled
+	}
+	if err := s.checkRedirectURI(req.ClientID, req.RedirectURI); err != nil {
+		return "", err
+	}
+
+	switch {
+	case req.ResponseType != "code":
+		return "", &AuthorizationError{Code: "unsupported_response_type", Description: "поддерживается только response_type=code"}
+	case !slices.Contains(strings.Fields(req.Scope), "openid"):
+		return "", &AuthorizationError{Code: "invalid_scope", Description: "scope должен содержать openid"}
+	case req.CodeChallengeMethod != pkceMethodS256:
+		return "", &AuthorizationError{Code: "invalid_request", Description: "требуется PKCE с code_challenge_method=S256"}
+	case len(req.CodeChallenge) != pkceChallengeLength:
+		return "", &AuthorizationError{Code: "invalid_request", Description: "недействительный code_challenge"}
+	}
+
+	now := time.Now()
+	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss":            "auth-service",
+		"aud":            authorizeAudience,
+		"client_id":      req.ClientID,
+		"redirect_uri":   req.RedirectURI,
+		"state":          req.State,
+		"nonce":          req.Nonce,
+		"code_challenge": req.CodeChallenge,
+		"exp":            now.Add(s.cfg.OIDC.RequestTTL).Unix(),
+		"iat":            now.Unix(),
+	})
+	request, err := claims.SignedString([]byte(s.cfg.Cert.Jwt))
+	if err != nil {
+		return "", errs.Internal("failed to sign authorization request", err)
+	}
+
+	return withQuery(s.cfg.OIDC.LoginURL, url.Values{"request": {request}})
+}
+
+// Authorize выдает код авторизации пользователю, вошедшему на странице входа, по запросу из
+// StartAuthorization и возвращает адрес возврата клиента с кодом и state. authTime - время входа
+func (s *Service) Authorize(ctx context.Context, userID uuid.UUID, authTime time.Time, request string) (string, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "authorize"))
+
+	if s.idKey == nil {
+		return "", ErrOIDCDisabled
+	}
+
+	claims, err := s.parseJWT(request)
+	if err != nil {
+		l.Debug("недействительный запрос авторизации", logger.Err(err))
+		return "", ErrInvalidAuthorizationRequest.Wrap(err)
+	}
+	if aud, _ := claims["aud"].(string); aud != authorizeAudience {
+		l.Debug("подписанный токен не является запросом авторизации")
+		return "", ErrInvalidAuthorizationRequest
+	}
+	clientID, _ := claims["client_id"].(string)
+	redirectURI, _ := claims["redirect_uri"].(string)
+	codeChallenge, _ := claims["code_challenge"].(string)
+	nonce, _ := claims["nonce"].(string)
+	state, _ := claims["state"].(string)
+	l = l.With(slog.String("client_id", clientID))
+
+	// Настройки клиента могли измениться, пока пользователь входил
+	if err = s.checkRedirectURI(clientID, redirectURI); err != nil {
+		l.Debug("клиент или redirect_uri больше не разрешены", logger.Err(err))
+		return "", err
+	}
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return "", err
+	}
+	if err = s.checkUserActive(user); err != nil {
+		l.Debug("авторизация неактивного аккаунта", logger.Err(err))
+		return "", err
+	}
+
+	code := randomToken(32)
+	err = models.CreateAuthorizationCode(ctx, hashSecret(code), &models.AuthorizationCode{
+		UserId:        userID,
+		ClientId:      clientID,
+		RedirectURI:   redirectURI,
+		CodeChallenge: codeChallenge,
+		Nonce:         nonce,
+		AuthTime:      authTime,
+	}, time.Now().Add(s.cfg.OIDC.CodeTTL))
+	if err != nil {
+		l.Error("ошибка сохранения кода авторизации", logger.Err(err))
+		return "", err
+	}
+
+	params := url.Values{"code": {code}}
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	l.Info("выдан код авторизации")
+	return withQuery(redirectURI, params)
+}
+
+// ExchangeAuthorizationCode обменивает код авторизации на access и ID токены (RFC 6749 §4.1.3),
+// проверяя code_verifier (RFC 7636 §4.6). Любое несовпадение дает ErrInvalidGrant
+func (s *Service) ExchangeAuthorizationCode(
+	ctx context.Context,
+	code, clientID, redirectURI, codeVerifier string,
+) (*CodeTokens, error) {
+	l := s.log.With(slog.String("client_id", clientID), slog.String("op", "exchange_authorization_code"))
+
+	if s.idKey == nil {
+		return nil, ErrOIDCDisabled
+	}
+
+	stored, err := models.ConsumeAuthorizationCode(ctx, hashSecret(code))
+	if err != nil {
+		if errors.Is(err, models.ErrAuthorizationCodeNotFound) {
+			l.Debug("код авторизации не найден, истек или уже использован")
+			return nil, ErrInvalidGrant
+		}
+		l.Error("ошибка при использовании кода авторизации", logger.Err(err))
+		return nil, err
+	}
+
+	// Код уже израсходован: перехвативший его не сможет перебирать code_verifier
+	if stored.ClientId != clientID || stored.RedirectURI != redirectURI ||
+		!verifyCodeChallenge(codeVerifier, stored.CodeChallenge) {
+		l.Warn("код авторизации предъявлен с другими параметрами")
+		return nil, ErrInvalidGrant
+	}
+
+	ctx = tenant.With(ctx, stored.TenantId)
+	user, err := models.GetUserByID(ctx, stored.UserId)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return nil, err
+	}
+	if err = s.checkUserActive(user); err != nil {
+		l.Debug("обмен кода неактивного аккаунта", logger.Err(err))
+		return nil, ErrInvalidGrant.Wrap(err)
+	}
+
+	accessToken, err := s.createToken(ctx, user, stored.AuthTime)
+	if err != nil {
+		l.Error("ошибка при создании токена", logger.Err(err))
+		return nil, errs.Internal("failed to create token", err)
+	}
+	idToken, err := s.CreateIDToken(user, clientID, stored.Nonce, accessToken, stored.AuthTime)
+	if err != nil {
+		l.Error("ошибка при создании ID токена", logger.Err(err))
+		return nil, errs.Internal("failed to create id token", err)
+	}
+
+	l.Info("код авторизации обменян на токены", slog.String("user_id", user.UserId.String()))
+	return &CodeTokens{AccessToken: accessToken, IDToken: idToken, ExpiresIn: userTokenTTL}, nil
+}
+
+// checkRedirectURI проверяет клиента и точное совпадение redirect_uri с зарегистрированным
+func (s *Service) checkRedirectURI(clientID, redirectURI string) error {
+	if !slices.Contains(s.cfg.OIDC.ClientIDs, clientID) {
+		return ErrUnknownClient
+	}
+	if !slices.Contains(s.cfg.OIDC.RedirectURIs[clientID], redirectURI) {
+		return ErrInvalidRedirectURI
+	}
+	return nil
+}
+
+// verifyCodeChallenge проверяет code_verifier по code_challenge метода S256
+func verifyCodeChallenge(verifier, challenge string) bool {
+	// RFC 7636 §4.1: verifier от 43 до 128 символов
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// withQuery добавляет параметры к адресу, сохраняя его собственные
+func withQuery(rawURL string, params url.Values) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errs.Internal("invalid redirect url", err)
+	}
+	q := u.Query()
+	for key, values := range params {
+		q[key] = values
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
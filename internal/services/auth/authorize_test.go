@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"auth-service/config"
+	"auth-service/internal/models"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testVerifier  = "dBjftJeZ4CVP-mJ92K9qpUWmRvnc3nTNE3HdpAnIIIM"
+	testChallenge = "sI3edURBSRSFwzO0A7es0mW00b1wv69QKNJLdxqrN58" // base64url(SHA-256(testVerifier))
+)
+
+func newOIDCService(t *testing.T) *Service {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Service{
+		log:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		idKey: key,
+		cfg: &config.Config{
+			Cert: config.Cert{Jwt: "secret"},
+			OIDC: config.OIDC{
+				Issuer:       "https://auth.example.com",
+				ClientIDs:    []string{"partner"},
+				LoginURL:     "https://app.example.com/login?lang=ru",
+				RedirectURIs: map[string][]string{"partner": {"https://partner.example.com/callback"}},
+				RequestTTL:   time.Minute,
+			},
+		},
+	}
+}
+
+func validAuthorizationRequest() *AuthorizationRequest {
+	return &AuthorizationRequest{
+		ResponseType:        "code",
+		ClientID:            "partner",
+		RedirectURI:         "https://partner.example.com/callback",
+		Scope:               "openid email",
+		State:               "s-1",
+		Nonce:               "n-1",
+		CodeChallenge:       testChallenge,
+		CodeChallengeMethod: pkceMethodS256,
+	}
+}
+
+func TestStartAuthorization(t *testing.T) {
+	s := newOIDCService(t)
+
+	loginURL, err := s.StartAuthorization(validAuthorizationRequest())
+	if err != nil {
+		t.Fatalf("StartAuthorization: %v", err)
+	}
+
+	u, err := url.Parse(loginURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Host != "app.example.com" || u.Query().Get("lang") != "ru" {
+		t.Fatalf("адрес страницы входа %s", loginURL)
+	}
+
+	claims, err := s.parseJWT(u.Query().Get("request"))
+	if err != nil {
+		t.Fatalf("запрос авторизации не разбирается: %v", err)
+	}
+	if claims["aud"] != authorizeAudience || claims["code_challenge"] != testChallenge || claims["state"] != "s-1" {
+		t.Fatalf("claims запроса авторизации %v", claims)
+	}
+}
+
+func TestStartAuthorizationRejects(t *testing.T) {
+	s := newOIDCService(t)
+
+	tests := []struct {
+		name     string
+		modify   func(req *AuthorizationRequest)
+		wantErr  error  // ошибка без перенаправления
+		wantCode string // ошибка через redirect_uri
+	}{
+		{name: "неизвестный клиент", modify: func(r *AuthorizationRequest) { r.ClientID = "other" }, wantErr: ErrUnknownClient},
+		{name: "чужой redirect_uri", modify: func(r *AuthorizationRequest) { r.RedirectURI = "https://evil.example.com/cb" }, wantErr: ErrInvalidRedirectURI},
+		{name: "implicit flow", modify: func(r *AuthorizationRequest) { r.ResponseType = "token" }, wantCode: "unsupported_response_type"},
+		{name: "без openid", modify: func(r *AuthorizationRequest) { r.Scope = "email" }, wantCode: "invalid_scope"},
+		{name: "без PKCE", modify: func(r *AuthorizationRequest) { r.CodeChallenge, r.CodeChallengeMethod = "", "" }, wantCode: "invalid_request"},
+		{name: "PKCE plain", modify: func(r *AuthorizationRequest) { r.CodeChallengeMethod = "plain" }, wantCode: "invalid_request"},
+		{name: "короткий code_challenge", modify: func(r *AuthorizationRequest) { r.CodeChallenge = "abc" }, wantCode: "invalid_request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validAuthorizationRequest()
+			tt.modify(req)
+
+			_, err := s.StartAuthorization(req)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("StartAuthorization = %v, ожидалась %v", err, tt.wantErr)
+				}
+				return
+			}
+			var authErr *AuthorizationError
+			if !errors.As(err, &authErr) || authErr.Code != tt.wantCode {
+				t.Fatalf("StartAuthorization = %v, ожидалась ошибка %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuthorizeRejectsForeignToken(t *testing.T) {
+	s := newOIDCService(t)
+
+	// Токен смены пароля подписан тем же ключом, но не является запросом авторизации
+	user := &models.User{UserId: uuid.New(), Email: "user@example.com"}
+	token, err := s.CreatePasswordChangeToken(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.Authorize(context.Background(), user.UserId, time.Now(), token); !errors.Is(err, ErrInvalidAuthorizationRequest) {
+		t.Fatalf("Authorize = %v, ожидалась ErrInvalidAuthorizationRequest", err)
+	}
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		want     bool
+	}{
+		{name: "верный verifier", verifier: testVerifier, want: true},
+		{name: "чужой verifier", verifier: strings.Repeat("a", 43)},
+		{name: "короткий verifier", verifier: testVerifier[:42]},
+		{name: "пустой verifier"},
+	}
+	for _, tt := range tests {
+		if got := verifyCodeChallenge(tt.verifier, testChallenge); got != tt.want {
+			t.Errorf("%s: verifyCodeChallenge = %v, ожидалось %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/tenant"
+	"auth-service/pkg/logger"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// subTypeUser и subTypeService различают человека и машинного клиента в claim sub_type
+	subTypeUser    = "user"
+	subTypeService = "service"
+
+	serviceClientPrefix = "svc_"
+
+	// serviceClientCacheTTL сколько помнить, что клиент не отозван; токены отозванного
+	// клиента перестают приниматься не позже чем через это время
+	serviceClientCacheTTL = time.Minute
+)
+
+// serviceClientCache кеш активных машинных клиентов, чтобы не ходить в БД при каждой проверке токена.
+// Отозванные клиенты не кешируются
+type serviceClientCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time // арендатор и client_id - когда перепроверить
+}
+
+// ServiceToken результат client credentials grant
+type ServiceToken struct {
+	AccessToken string
+	ExpiresIn   time.Duration
+}
+
+// CreateServiceClient регистрирует машинного клиента и возвращает его секрет (единственный раз)
+func (s *Service) CreateServiceClient(ctx context.Context, name string, roles []string) (*models.ServiceClient, string, error) {
+	l := s.log.With(slog.String("op", "create_service_client"), slog.String("name", name))
+
+	clientID := serviceClientPrefix + randomToken(12)
+	secret := randomToken(32)
+
+	slices.Sort(roles)
+	client, err := models.CreateServiceClient(ctx, clientID, name, hashSecret(secret), slices.Compact(roles))
+	if err != nil {
+		l.Error("ошибка создания клиента", logger.Err(err))
+		return nil, "", err
+	}
+
+	l.Info("машинный клиент создан", slog.String("client_id", clientID))
+	return client, secret, nil
+}
+
+// IssueServiceToken выполняет client credentials grant и выпускает токен сервиса
+func (s *Service) IssueServiceToken(ctx context.Context, clientID, secret string) (*ServiceToken, error) {
+	l := s.log.With(slog.String("op", "issue_service_token"), slog.String("client_id", clientID))
+
+	client, err := models.GetServiceClient(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, models.ErrClientNotFound) {
+			l.Debug("клиент не найден")
+			return nil, ErrInvalidClientCredentials
+		}
+		l.Error("ошибка при поиске клиента", logger.Err(err))
+		return nil, errs.Internal("failed to get client", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(hashSecret(secret))) != 1 {
+		l.Debug("неверный секрет клиента")
+		return nil, ErrInvalidClientCredentials
+	}
+
+	ttl := s.cfg.ServiceClients.TokenTTL
+	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":      client.ClientID,
+		"sub_type": subTypeService,
+		"name":     client.Name,
+		"iss":      "auth-service",
+		"aud":      "chef-app-services",
+		"roles":    client.Roles,
+		"tid":      client.TenantId.String(),
+		"exp":      time.Now().Add(ttl).Unix(),
+		"iat":      time.Now().Unix(),
+	})
+
+	tokenString, err := claims.SignedString([]byte(s.cfg.Cert.Jwt))
+	if err != nil {
+		l.Error("ошибка подписи токена", logger.Err(err))
+		return nil, errs.Internal("failed to create token", err)
+	}
+
+	l.Info("выпущен токен сервиса")
+	return &ServiceToken{AccessToken: tokenString, ExpiresIn: ttl}, nil
+}
+
+// RevokeServiceClient отзывает машинного клиента. Новые токены ему не выдаются,
+// а выданные перестают приниматься не позже чем через serviceClientCacheTTL
+func (s *Service) RevokeServiceClient(ctx context.Context, clientID string) error {
+	l := s.log.With(slog.String("op", "revoke_service_client"), slog.String("client_id", clientID))
+
+	if err := models.RevokeServiceClient(ctx, clientID); err != nil {
+		l.Debug("ошибка отзыва клиента", logger.Err(err))
+		return err
+	}
+
+	s.clients.mu.Lock()
+	delete(s.clients.entries, serviceClientKey(ctx, clientID))
+	s.clients.mu.Unlock()
+
+	l.Info("машинный клиент отозван")
+	return nil
+}
+
+// checkServiceClient отклоняет токен отозванного машинного клиента
+func (s *Service) checkServiceClient(ctx context.Context, clientID string) error {
+	key := serviceClientKey(ctx, clientID)
+
+	s.clients.mu.Lock()
+	recheck, ok := s.clients.entries[key]
+	s.clients.mu.Unlock()
+	if ok && time.Now().Before(recheck) {
+		return nil
+	}
+
+	active, err := models.ServiceClientActive(ctx, clientID)
+	if err != nil {
+		return err
+	}
+	if !active {
+		return ErrTokenRevoked
+	}
+
+	s.clients.mu.Lock()
+	s.clients.entries[key] = time.Now().Add(serviceClientCacheTTL)
+	s.clients.mu.Unlock()
+
+	return nil
+}
+
+func serviceClientKey(ctx context.Context, clientID string) string {
+	return tenant.ID(ctx).String() + "/" + clientID
+}
+
+// hashSecret хеширует секрет клиента. Секрет генерируется случайно с высокой энтропией,
+// поэтому медленный KDF не нужен
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken возвращает криптостойкую случайную строку из n байт в base64url
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand не возвращает ошибок на поддерживаемых платформах
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
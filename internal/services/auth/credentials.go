@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/services/mailer"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// ChangePassword меняет пароль после проверки текущего. Все ранее выданные токены
+// отзываются, вызывающему возвращается новый токен
+func (s *Service) ChangePassword(ctx context.Context, userID uuid.UUID, current, next string) (*models.AuthResponse, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "change_password"))
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	ok, err := s.CheckPasswordHash(ctx, current, user.PasswordHash)
+	if err != nil {
+		l.Warn("ошибка проверки пароля", logger.Err(err))
+		return nil, hashingError(err)
+	}
+	if !ok {
+		l.Debug("неверный текущий пароль")
+		return nil, ErrWrongPassword
+	}
+
+	if err = s.checkPasswordReuse(ctx, user, next); err != nil {
+		l.Debug("повторное использование пароля", logger.Err(err))
+		return nil, err
+	}
+
+	hashedPwd, err := s.HashPassword(ctx, next)
+	if err != nil {
+		l.Error("ошибка хеширования пароля", logger.Err(err))
+		return nil, hashingError(err)
+	}
+
+	// Текущий пароль уходит в историю; вместе с ним проверяется HistoryDepth паролей
+	user, err = models.UpdatePassword(ctx, userID, hashedPwd, max(s.cfg.PasswordPolicy.HistoryDepth-1, 0))
+	if err != nil {
+		l.Error("ошибка обновления пароля", logger.Err(err))
+		return nil, err
+	}
+
+	token, err := s.CreateToken(ctx, user)
+	if err != nil {
+		l.Error("ошибка создания токена", logger.Err(err))
+		return nil, errs.Internal("failed to create token", err)
+	}
+
+	s.sendMail(l, mailer.Message{
+		To:      user.Email,
+		Subject: "Пароль изменен",
+		Body: "Пароль от вашего аккаунта был изменен, все остальные сеансы завершены.\n" +
+			"Если это были не вы, немедленно восстановите доступ к аккаунту.\n",
+	})
+
+	l.Info("пароль изменен")
+	return &models.AuthResponse{
+		JWTToken: token,
+	}, nil
+}
+
+// ChangeEmail запрашивает смену email: на новый адрес уходит ссылка подтверждения,
+// на прежний - уведомление. Email в auth.users меняется только в ConfirmEmailChange
+func (s *Service) ChangeEmail(ctx context.Context, userID uuid.UUID, password, newEmail string) error {
+	l := s.log.With(
+		slog.String("user_id", userID.String()),
+		slog.String("new_email_hash", s.HashEmail(newEmail)),
+		slog.String("op", "change_email"),
+	)
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return err
+	}
+
+	ok, err := s.CheckPasswordHash(ctx, password, user.PasswordHash)
+	if err != nil {
+		l.Warn("ошибка проверки пароля", logger.Err(err))
+		return hashingError(err)
+	}
+	if !ok {
+		l.Debug("неверный пароль")
+		return ErrWrongPassword
+	}
+
+	// Занятость адреса не сообщаем: иначе смена email стала бы способом перебора
+	// зарегистрированных адресов. Конфликт обнаружится при подтверждении
+	token := randomToken(32)
+	err = models.CreateEmailChangeRequest(ctx, hashSecret(token), userID, newEmail, s.CanonicalEmail(newEmail),
+		time.Now().Add(s.cfg.EmailChange.TTL))
+	if err != nil {
+		l.Error("ошибка создания запроса смены email", logger.Err(err))
+		return errs.Internal("failed to change email", err)
+	}
+
+	s.sendMail(l, mailer.Message{
+		To:      newEmail,
+		Subject: "Подтверждение нового email",
+		Body: fmt.Sprintf("Чтобы сделать этот адрес адресом вашего аккаунта, перейдите по ссылке:\n\n%s\n\n"+
+			"Ссылка действует %d ч.\n",
+			s.cfg.EmailChange.URL+"?token="+url.QueryEscape(token), int(s.cfg.EmailChange.TTL.Hours())),
+	})
+	s.sendMail(l, mailer.Message{
+		To:      user.Email,
+		Subject: "Запрошена смена email",
+		Body: "Для вашего аккаунта запрошена смена email. Адрес изменится после подтверждения " +
+			"по ссылке, отправленной на новый адрес.\nЕсли это были не вы, смените пароль.\n",
+	})
+
+	l.Info("запрошена смена email")
+	return nil
+}
+
+// ConfirmEmailChange меняет email по токену из письма и отзывает выданные токены
+func (s *Service) ConfirmEmailChange(ctx context.Context, token string) (*models.AuthResponse, error) {
+	l := s.log.With(slog.String("op", "confirm_email_change"))
+
+	oldEmail, user, err := models.ConfirmEmailChange(ctx, hashSecret(token))
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrEmailChangeNotFound):
+			l.Debug("запрос смены email недействителен")
+			return nil, ErrLinkInvalid
+		case errors.Is(err, models.ErrEmailTaken):
+			l.Debug("новый email уже занят")
+			return nil, err
+		}
+		l.Error("ошибка подтверждения смены email", logger.Err(err))
+		return nil, errs.Internal("failed to confirm email change", err)
+	}
+
+	// Выданные токены отозваны, поэтому новый выпускается как при входе
+	rsp, err := s.issueLoginToken(ctx, l, user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sendMail(l, mailer.Message{
+		To:      oldEmail,
+		Subject: "Email аккаунта изменен",
+		Body: "Email вашего аккаунта был изменен, и этот адрес больше не используется для входа.\n" +
+			"Если это были не вы, обратитесь в поддержку.\n",
+	})
+
+	l.Info("email изменен",
+		slog.String("old_email_hash", s.HashEmail(oldEmail)),
+		slog.String("email_hash", s.HashEmail(user.Email)),
+	)
+	return rsp, nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"context"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+// EmailDuplicate аккаунты одного арендатора, адреса которых совпадают в каноническом виде
+type EmailDuplicate struct {
+	TenantId       uuid.UUID          `json:"tenant_id"`
+	EmailCanonical string             `json:"email_canonical"`
+	Users          []models.UserEmail `json:"users"` // По возрастанию даты регистрации
+}
+
+// EmailNormalizationReport результат проверки хранимых email текущими правилами нормализации
+type EmailNormalizationReport struct {
+	Applied    bool               `json:"applied"`
+	Total      int                `json:"total"`
+	Changed    int                `json:"changed"`    // Пользователей, у которых изменится адрес или канонический вид
+	Invalid    []models.UserEmail `json:"invalid"`    // Не удалось нормализовать, остаются как есть
+	Duplicates []EmailDuplicate   `json:"duplicates"` // Совпадают в каноническом виде; запись невозможна
+}
+
+// NormalizeStoredEmails пересчитывает канонический вид хранимых email текущими правилами
+// (punycode, правила провайдеров) и сообщает о дубликатах. С apply и без дубликатов записывает
+// нормализованные адреса и канонический вид. Правила провайдеров меняют только канонический
+// вид: адрес пользователя остается в его написании
+func (s *Service) NormalizeStoredEmails(ctx context.Context, apply bool) (*EmailNormalizationReport, error) {
+	l := s.log.With(slog.String("op", "normalize_stored_emails"))
+
+	users, err := models.ListUserEmails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EmailNormalizationReport{Total: len(users)}
+	// Канонический вид уникален в пределах арендатора, поэтому дубликаты ищутся по паре (арендатор, вид)
+	type groupKey struct {
+		tenantID  uuid.UUID
+		canonical string
+	}
+	groups := make(map[groupKey][]models.UserEmail)
+	var order []groupKey
+	changes := make(map[uuid.UUID]models.EmailUpdate)
+
+	for _, u := range users {
+		normalized, err := s.emails.Normalize(u.Email)
+		var canonical string
+		if err == nil {
+			canonical, err = s.emails.Canonical(u.Email)
+		}
+		if err != nil {
+			report.Invalid = append(report.Invalid, u)
+			normalized, canonical = u.Email, u.EmailCanonical
+		} else if normalized != u.Email || canonical != u.EmailCanonical {
+			changes[u.UserId] = models.EmailUpdate{Email: normalized, Canonical: canonical}
+		}
+
+		key := groupKey{tenantID: u.TenantId, canonical: canonical}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], u)
+	}
+	report.Changed = len(changes)
+
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			report.Duplicates = append(report.Duplicates, EmailDuplicate{
+				TenantId:       key.tenantID,
+				EmailCanonical: key.canonical,
+				Users:          groups[key],
+			})
+		}
+	}
+
+	if !apply || len(report.Duplicates) > 0 || len(changes) == 0 {
+		return report, nil
+	}
+
+	if err = models.UpdateUserEmails(ctx, changes); err != nil {
+		return report, err
+	}
+	report.Applied = true
+
+	l.Info("email пользователей нормализованы", slog.Int("changed", report.Changed))
+	return report, nil
+}
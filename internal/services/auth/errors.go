@@ -0,0 +1,41 @@
+package auth
+
+import "auth-service/internal/errs"
+
+// Ошибки сервиса авторизации. Причина (reason) уходит клиенту в ErrorInfo
+var (
+	ErrInvalidCredentials            = errs.New(errs.KindUnauthenticated, "INVALID_CREDENTIALS", "неверный email или пароль")
+	ErrWrongPassword                 = errs.New(errs.KindUnauthenticated, "WRONG_PASSWORD", "неверный пароль")
+	ErrInvalidClientCredentials      = errs.New(errs.KindUnauthenticated, "INVALID_CLIENT_CREDENTIALS", "неверные учетные данные клиента")
+	ErrInvalidToken                  = errs.New(errs.KindUnauthenticated, "TOKEN_INVALID", "недействительный токен")
+	ErrTokenRevoked                  = errs.New(errs.KindUnauthenticated, "TOKEN_REVOKED", "токен отозван")
+	ErrInvalidTenantSlug             = errs.New(errs.KindInvalidArgument, "INVALID_TENANT_SLUG", "slug арендатора: строчные латинские буквы, цифры и дефис, до 64 символов")
+	ErrTokenTenantMismatch           = errs.New(errs.KindUnauthenticated, "TOKEN_TENANT_MISMATCH", "токен выдан другому арендатору")
+	ErrTenantDisabled                = errs.New(errs.KindUnauthenticated, "TENANT_DISABLED", "арендатор отключен")
+	ErrTokenAccountBlocked           = errs.New(errs.KindUnauthenticated, "ACCOUNT_BLOCKED", "аккаунт заблокирован")
+	ErrInvalidApiKey                 = errs.New(errs.KindUnauthenticated, "API_KEY_INVALID", "недействительный API ключ")
+	ErrLinkInvalid                   = errs.New(errs.KindUnauthenticated, "LINK_INVALID", "ссылка недействительна или истекла")
+	ErrAccountSuspended              = errs.New(errs.KindFailedPrecondition, "ACCOUNT_SUSPENDED", "аккаунт заблокирован")
+	ErrAccountDisabled               = errs.New(errs.KindFailedPrecondition, "ACCOUNT_DISABLED", "аккаунт отключен")
+	ErrAccountPendingDeletion        = errs.New(errs.KindFailedPrecondition, "ACCOUNT_PENDING_DELETION", "аккаунт ожидает удаления")
+	ErrReauthRequired                = errs.New(errs.KindFailedPrecondition, "REAUTH_REQUIRED", "войдите заново, чтобы подтвердить действие")
+	ErrRoleNotAssigned               = errs.New(errs.KindPermissionDenied, "ROLE_NOT_ASSIGNED", "роль не назначена пользователю")
+	ErrModerationForbidden           = errs.New(errs.KindPermissionDenied, "MODERATION_FORBIDDEN", "нельзя менять статус пользователя с такой же или более высокой ролью")
+	ErrExpiryInPast                  = errs.New(errs.KindInvalidArgument, "EXPIRY_IN_PAST", "время окончания уже прошло")
+	ErrUnknownImportFormat           = errs.New(errs.KindInvalidArgument, "UNKNOWN_IMPORT_FORMAT", "неизвестный формат импорта")
+	ErrMalformedImportFile           = errs.New(errs.KindInvalidArgument, "MALFORMED_IMPORT_FILE", "ошибка чтения файла")
+	ErrUnknownProvider               = errs.New(errs.KindInvalidArgument, "UNKNOWN_PROVIDER", "провайдер не настроен")
+	ErrInvalidProviderToken          = errs.New(errs.KindUnauthenticated, "PROVIDER_TOKEN_INVALID", "недействительный ID токен провайдера")
+	ErrProviderUnavailable           = errs.New(errs.KindUnavailable, "PROVIDER_UNAVAILABLE", "провайдер идентификации недоступен")
+	ErrProviderEmailMissing          = errs.New(errs.KindFailedPrecondition, "PROVIDER_EMAIL_MISSING", "провайдер не предоставил email")
+	ErrProviderEmailUnverified       = errs.New(errs.KindFailedPrecondition, "PROVIDER_EMAIL_UNVERIFIED", "провайдер не подтвердил email")
+	ErrRateLimited                   = errs.New(errs.KindResourceExhausted, "RATE_LIMITED", "слишком много запросов, попробуйте позже")
+	ErrRegistrationClosed            = errs.New(errs.KindFailedPrecondition, "REGISTRATION_CLOSED", "регистрация закрыта")
+	ErrInviteRequired                = errs.New(errs.KindFailedPrecondition, "INVITE_REQUIRED", "регистрация только по приглашению")
+	ErrRegistrationDomain            = errs.New(errs.KindFailedPrecondition, "REGISTRATION_DOMAIN_NOT_ALLOWED", "регистрация с этого домена только по приглашению")
+	ErrInviteInvalid                 = errs.New(errs.KindFailedPrecondition, "INVITE_INVALID", "приглашение недействительно, истекло или уже использовано")
+	ErrOrganisationForbidden         = errs.New(errs.KindPermissionDenied, "ORGANISATION_FORBIDDEN", "недостаточно прав в организации")
+	ErrUnknownOrganisationRole       = errs.New(errs.KindInvalidArgument, "UNKNOWN_ORGANISATION_ROLE", "неизвестная роль в организации")
+	ErrOrganisationInvitationInvalid = errs.New(errs.KindFailedPrecondition, "ORGANISATION_INVITATION_INVALID", "приглашение недействительно, истекло, уже принято или отправлено на другой email")
+	ErrOverloaded                    = errs.New(errs.KindResourceExhausted, "OVERLOADED", "сервис перегружен, повторите попытку позже")
+)
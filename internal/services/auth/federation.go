@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/internal/services/federation"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+// unusablePasswordHash записывается пользователям, созданным через внешний провайдер
+const unusablePasswordHash = models.UnusablePasswordHash
+
+// LoginWithProvider выполняет вход по ID токену внешнего OpenID Connect провайдера.
+// checkEmail проверяет email нового аккаунта так же, как при регистрации (домены, MX)
+func (s *Service) LoginWithProvider(
+	ctx context.Context,
+	providerName, idToken, nonce string,
+	checkEmail func(ctx context.Context, email string) error,
+) (*models.AuthResponse, error) {
+	l := s.log.With(slog.String("provider", providerName), slog.String("op", "login_with_provider"))
+
+	l.Debug("попытка входа через внешний провайдер")
+
+	// 1. Проверяем ID токен провайдера
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		l.Debug("провайдер не настроен")
+		return nil, ErrUnknownProvider.Wrap(err)
+	}
+
+	claims, err := provider.Verify(ctx, idToken, nonce)
+	if err != nil {
+		if errors.Is(err, federation.ErrInvalidIDToken) {
+			l.Warn("недействительный ID токен провайдера", logger.Err(err))
+			return nil, ErrInvalidProviderToken.Wrap(err)
+		}
+		l.Error("ошибка проверки ID токена провайдера", logger.Err(err))
+		return nil, ErrProviderUnavailable.Wrap(err)
+	}
+	claims.Email = s.NormalizeEmail(claims.Email)
+	l = l.With(slog.String("email_hash", s.HashEmail(claims.Email)))
+
+	// 2. Ищем пользователя по привязке, при ее отсутствии привязываем или создаем
+	user, err := models.GetUserByExternalIdentity(ctx, provider.Name(), claims.Subject)
+	if err != nil {
+		if !errors.Is(err, models.ErrIdentityNotFound) {
+			l.Error("ошибка при поиске привязки", logger.Err(err))
+			return nil, err
+		}
+
+		user, err = s.linkOrCreateUser(ctx, l, provider, claims, checkEmail)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.checkUserActive(user); err != nil {
+		l.Debug("вход в неактивный аккаунт", logger.Err(err))
+		return nil, err
+	}
+
+	// 3. Выпускаем токен тем же путем, что и при входе по паролю
+	rsp, err := s.issueLoginToken(ctx, l, user)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.PasswordExpired {
+		return rsp, nil
+	}
+
+	s.recordLogin(ctx, l, user.UserId)
+
+	l.Info("успешный вход через внешний провайдер")
+	return rsp, nil
+}
+
+// linkOrCreateUser привязывает внешний аккаунт к пользователю с тем же email или создает нового
+func (s *Service) linkOrCreateUser(
+	ctx context.Context,
+	l *slog.Logger,
+	provider *federation.Provider,
+	claims *federation.Claims,
+	checkEmail func(ctx context.Context, email string) error,
+) (*models.User, error) {
+	if claims.Email == "" {
+		l.Debug("провайдер не вернул email")
+		return nil, ErrProviderEmailMissing
+	}
+
+	identity := &models.ExternalIdentity{
+		Provider: provider.Name(),
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+
+	canonical := s.CanonicalEmail(claims.Email)
+	existing, err := models.GetUserByEmail(ctx, canonical)
+	switch {
+	case err == nil:
+		if err = checkLinkByEmail(provider, claims); err != nil {
+			l.Debug("привязка по email запрещена")
+			return nil, err
+		}
+
+		identity.UserId = existing.UserId
+		if err = models.LinkExternalIdentity(ctx, identity); err != nil {
+			l.Error("ошибка привязки внешнего аккаунта", logger.Err(err))
+			return nil, err
+		}
+
+		l.Info("внешний аккаунт привязан к существующему пользователю")
+		return existing, nil
+
+	case errors.Is(err, models.ErrUserNotFound):
+		if err = s.admitProviderRegistration(ctx, claims, checkEmail); err != nil {
+			l.Debug("регистрация не разрешена", logger.Err(err))
+			return nil, err
+		}
+
+		identity.UserId = uuid.New()
+		user, err := models.CreateUserWithIdentity(ctx, identity, canonical, unusablePasswordHash)
+		if err != nil {
+			l.Error("ошибка создания пользователя", logger.Err(err))
+			return nil, err
+		}
+
+		l.Info("пользователь зарегистрирован через внешний провайдер")
+		return user, nil
+
+	default:
+		l.Error("ошибка при поиске пользователя", logger.Err(err))
+		return nil, err
+	}
+}
+
+// admitProviderRegistration можно ли создать аккаунт по данным провайдера. Email должен быть
+// подтвержден провайдером: иначе аккаунт можно завести на чужой адрес, а в domain_restricted -
+// обойти ограничение по домену. Домен проверяется той же политикой, что и при регистрации.
+// Приглашение через провайдера не передать, поэтому в invite_only новые аккаунты не создаются
+func (s *Service) admitProviderRegistration(
+	ctx context.Context,
+	claims *federation.Claims,
+	checkEmail func(ctx context.Context, email string) error,
+) error {
+	if !claims.EmailVerified {
+		return ErrProviderEmailUnverified
+	}
+	if err := s.admitRegistration(claims.Email, ""); err != nil {
+		return err
+	}
+	return checkEmail(ctx, claims.Email)
+}
+
+// checkLinkByEmail можно ли привязать внешний аккаунт к существующему пользователю с тем же email.
+// Привязываем только подтвержденный провайдером email, иначе владелец чужого адреса
+// у провайдера получил бы доступ к аккаунту
+func checkLinkByEmail(provider *federation.Provider, claims *federation.Claims) error {
+	if !provider.LinkByEmail() || !claims.EmailVerified {
+		return models.ErrEmailTaken
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"auth-service/config"
+	"auth-service/internal/models"
+	"auth-service/internal/services/federation"
+	"auth-service/internal/services/federation/federationtest"
+	"context"
+	"errors"
+	"testing"
+)
+
+const testClientID = "auth-service-client"
+
+// verifyFromIdP проверяет токен фейкового провайдера тем же путем, что и LoginWithProvider
+func verifyFromIdP(t *testing.T, registry *federation.Registry, idp *federationtest.IdP, name string, verified bool) (*federation.Provider, *federation.Claims) {
+	t.Helper()
+
+	provider, err := registry.Get(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := idp.Claims(testClientID, "ext-1", "user@example.com")
+	claims["email_verified"] = verified
+
+	got, err := provider.Verify(context.Background(), idp.Sign(t, claims), "")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	return provider, got
+}
+
+func TestCheckLinkByEmail(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	registry := federation.NewRegistry([]config.IdentityProvider{
+		idp.Config("linking", testClientID, true),
+		idp.Config("strict", testClientID, false),
+	}, idp.Server.Client())
+
+	tests := []struct {
+		name     string
+		provider string
+		verified bool
+		wantErr  error
+	}{
+		{name: "подтвержденный email", provider: "linking", verified: true},
+		{name: "неподтвержденный email", provider: "linking", verified: false, wantErr: models.ErrEmailTaken},
+		{name: "привязка выключена", provider: "strict", verified: true, wantErr: models.ErrEmailTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, claims := verifyFromIdP(t, registry, idp, tt.provider, tt.verified)
+			err := checkLinkByEmail(provider, claims)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("checkLinkByEmail = %v, ожидалась привязка", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkLinkByEmail = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderRegistrationMode(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	registry := federation.NewRegistry([]config.IdentityProvider{idp.Config("test", testClientID, false)}, idp.Server.Client())
+	_, claims := verifyFromIdP(t, registry, idp, "test", true)
+
+	tests := []struct {
+		mode    string
+		wantErr error
+	}{
+		{mode: RegistrationOpen},
+		{mode: RegistrationInviteOnly, wantErr: ErrInviteRequired},
+		{mode: RegistrationClosed, wantErr: ErrRegistrationClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			s := &Service{cfg: &config.Config{Registration: config.Registration{Mode: tt.mode}}}
+
+			// Приглашение через провайдера не передать: проверка режима идет без кода
+			err := s.admitProviderRegistration(context.Background(), claims, allowEmail)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("admitProviderRegistration = %v, ожидалось создание аккаунта", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("admitProviderRegistration = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderRegistrationUnverifiedEmail(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	registry := federation.NewRegistry([]config.IdentityProvider{idp.Config("test", testClientID, false)}, idp.Server.Client())
+	_, claims := verifyFromIdP(t, registry, idp, "test", false)
+
+	for _, mode := range []string{RegistrationOpen, RegistrationDomainRestricted} {
+		t.Run(mode, func(t *testing.T) {
+			// Домен из токена входит в список domain_restricted, но адрес не подтвержден
+			s := &Service{
+				cfg:                 &config.Config{Registration: config.Registration{Mode: mode}},
+				registrationDomains: []string{"example.com"},
+			}
+
+			checked := false
+			err := s.admitProviderRegistration(context.Background(), claims, func(context.Context, string) error {
+				checked = true
+				return nil
+			})
+			if !errors.Is(err, ErrProviderEmailUnverified) {
+				t.Fatalf("admitProviderRegistration = %v, ожидалась ErrProviderEmailUnverified", err)
+			}
+			if checked {
+				t.Fatal("домен неподтвержденного email проверен как адрес пользователя")
+			}
+		})
+	}
+}
+
+func TestProviderRegistrationEmailPolicy(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	registry := federation.NewRegistry([]config.IdentityProvider{idp.Config("test", testClientID, false)}, idp.Server.Client())
+	_, claims := verifyFromIdP(t, registry, idp, "test", true)
+
+	s := &Service{cfg: &config.Config{Registration: config.Registration{Mode: RegistrationOpen}}}
+	denied := errors.New("домен запрещен")
+
+	var got string
+	err := s.admitProviderRegistration(context.Background(), claims, func(_ context.Context, email string) error {
+		got = email
+		return denied
+	})
+	if !errors.Is(err, denied) {
+		t.Fatalf("admitProviderRegistration = %v, ожидалась ошибка политики доменов", err)
+	}
+	if got != claims.Email {
+		t.Fatalf("проверен email %q, ожидался %q", got, claims.Email)
+	}
+}
+
+func allowEmail(context.Context, string) error { return nil }
@@ -0,0 +1,472 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"auth-service/pkg/passhash"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"golang.org/x/crypto/bcrypt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Форматы файла импорта
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// Алгоритмы пароля в строке импорта
+const (
+	importAlgoPlain  = "plain"         // Пароль открытым текстом, хешируется при импорте
+	importAlgoBcrypt = "bcrypt"        // Хеш bcrypt с любой стоимостью
+	importAlgoSHA256 = "sha256_salted" // hex(sha256(salt + password)) из старого PHP приложения, соль в поле salt
+	importAlgoPHPass = "phpass"        // Переносимый хеш PHPass ($P$ / $H$)
+)
+
+const (
+	// ImportFailed результат строки, не прошедшей проверку или запись
+	ImportFailed = "error"
+
+	// importBatchSize строк в одной транзакции COPY
+	importBatchSize = 1000
+
+	// importRolesSeparator разделитель ролей в CSV
+	importRolesSeparator = ";"
+
+	// importHashQueue сколько хеширований импорта может ждать слота в пуле импорта
+	importHashQueue = 64
+)
+
+// ImportOptions параметры импорта
+type ImportOptions struct {
+	DryRun bool
+	// Overwrite заменяет пароли существующих пользователей и отзывает их токены.
+	// Без него существующие пользователи пропускаются
+	Overwrite bool
+	// CheckPassword проверяет открытый пароль по политике паролей. nil - строки
+	// с открытым паролем отклоняются
+	CheckPassword func(ctx context.Context, email, password string) error
+}
+
+// ImportRecord строка файла импорта. В CSV заголовок задает порядок столбцов с теми же именами,
+// роли перечисляются через ';'. Алгоритм можно не указывать: он определяется по заполненным полям
+type ImportRecord struct {
+	Email        string   `json:"email"`
+	Roles        []string `json:"roles"`
+	Password     string   `json:"password"`
+	PasswordHash string   `json:"password_hash"`
+	PasswordAlgo string   `json:"password_algo"`
+	Salt         string   `json:"salt"`
+}
+
+// ImportRowError ошибка в строке файла импорта
+type ImportRowError struct {
+	Line  int    // Номер строки файла (для CSV с учетом заголовка)
+	Email string // Пусто, если строку не удалось разобрать
+	Error string
+}
+
+// ImportReport итог импорта. В Errors попадают только строки с ошибками
+type ImportReport struct {
+	DryRun    bool
+	Total     int
+	Created   int
+	Updated   int
+	Unchanged int
+	Failed    int
+	Errors    []ImportRowError
+}
+
+// importRow строка, прошедшая проверку и ожидающая записи пакетом
+type importRow struct {
+	line     int
+	password string // Открытый пароль, хешируется перед записью пакета
+	user     models.ImportUser
+}
+
+// ImportUsers импортирует пользователей из CSV или JSONL. Строки проверяются по одной, ошибки
+// собираются в отчет и не прерывают импорт. Корректные строки пишутся пакетами по importBatchSize.
+// Существующие пользователи без Overwrite пропускаются и попадают в Unchanged.
+// При DryRun ничего не сохраняется и открытые пароли не хешируются, но отчет
+// показывает, какие пользователи были бы созданы или обновлены
+func (s *Service) ImportUsers(ctx context.Context, r io.Reader, format string, opts ImportOptions) (*ImportReport, error) {
+	l := s.log.With(slog.String("op", "import_users"), slog.Bool("dry_run", opts.DryRun), slog.Bool("overwrite", opts.Overwrite))
+
+	var next func() (int, *ImportRecord, error)
+	switch format {
+	case ImportFormatCSV:
+		next = csvRecords(r)
+	case ImportFormatJSONL:
+		next = jsonlRecords(r)
+	default:
+		return nil, ErrUnknownImportFormat.With("format", string(format))
+	}
+
+	roles, err := models.ListRoleNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: opts.DryRun}
+	seen := make(map[string]int)
+	batch := make([]importRow, 0, importBatchSize)
+
+	for {
+		line, rec, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var rowErr *importRowErr
+			if !errors.As(err, &rowErr) {
+				// Нарушена структура файла - дальше читать нельзя
+				return report, ErrMalformedImportFile.WithMessage(fmt.Sprintf("ошибка чтения файла в строке %d: %v", line, err)).With("line", strconv.Itoa(line))
+			}
+			report.Total++
+			report.fail(line, "", err)
+			continue
+		}
+		report.Total++
+
+		rec.Email = s.NormalizeEmail(rec.Email)
+		row, err := prepareImportRow(line, rec, roles)
+		if err != nil {
+			report.fail(line, rec.Email, err)
+			continue
+		}
+		// Дубликаты ищем по каноническому виду, как их проверит уникальный индекс
+		row.user.EmailCanonical = s.CanonicalEmail(row.user.Email)
+		if row.password != "" {
+			if err = checkImportPassword(ctx, opts, row); err != nil {
+				report.fail(line, row.user.Email, err)
+				continue
+			}
+		}
+		if first, ok := seen[row.user.EmailCanonical]; ok {
+			report.fail(line, row.user.Email, fmt.Errorf("email уже встречался в строке %d", first))
+			continue
+		}
+		seen[row.user.EmailCanonical] = line
+
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err = s.flushImport(ctx, batch, report, opts.Overwrite); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err = s.flushImport(ctx, batch, report, opts.Overwrite); err != nil {
+			return report, err
+		}
+	}
+
+	l.Info("импорт пользователей завершен",
+		slog.Int("total", report.Total),
+		slog.Int("created", report.Created),
+		slog.Int("updated", report.Updated),
+		slog.Int("unchanged", report.Unchanged),
+		slog.Int("failed", report.Failed),
+	)
+	return report, nil
+}
+
+// flushImport хеширует открытые пароли пакета и записывает его. Ошибка записи пакета
+// попадает в отчет для каждой его строки; прерывает импорт только отмена контекста
+func (s *Service) flushImport(ctx context.Context, batch []importRow, report *ImportReport, overwrite bool) error {
+	if err := s.hashImportPasswords(ctx, batch, report.DryRun); err != nil {
+		return err
+	}
+
+	users := make([]models.ImportUser, len(batch))
+	for i := range batch {
+		users[i] = batch[i].user
+	}
+
+	result, err := models.ImportUsers(ctx, users, report.DryRun, overwrite)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.log.Error("ошибка записи пакета импорта", slog.String("op", "import_users"), logger.Err(err))
+		for _, row := range batch {
+			report.fail(row.line, row.user.Email, err)
+		}
+		return nil
+	}
+
+	for _, row := range batch {
+		switch result[row.user.EmailCanonical] {
+		case models.ImportCreated:
+			report.Created++
+		case models.ImportUpdated:
+			report.Updated++
+		default:
+			report.Unchanged++
+		}
+	}
+	return nil
+}
+
+// hashImportPasswords хеширует открытые пароли в отдельном пуле импорта. Общий пул не используется,
+// иначе импорт занял бы очередь и входы пользователей отклонялись бы с ResourceExhausted; пул
+// импорта невелик, чтобы импорт, запущенный через RPC, не занимал все ядра. В dry-run хеш не нужен
+func (s *Service) hashImportPasswords(ctx context.Context, batch []importRow, dryRun bool) error {
+	jobs := make(chan *importRow)
+	var wg sync.WaitGroup
+	var hashErr error
+	var once sync.Once
+
+	for range s.importConcurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range jobs {
+				var hash string
+				var err error
+				if poolErr := s.importPool.Do(ctx, func() { hash, err = s.hasher.Hash(row.password) }); poolErr != nil {
+					err = poolErr
+				}
+				if err != nil {
+					once.Do(func() { hashErr = err })
+					continue
+				}
+				row.user.PasswordHash = hash
+			}
+		}()
+	}
+
+	for i := range batch {
+		row := &batch[i]
+		if row.password == "" {
+			continue
+		}
+		if dryRun {
+			row.user.PasswordHash = unusablePasswordHash
+			continue
+		}
+		select {
+		case jobs <- row:
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+			return ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if hashErr != nil {
+		return hashingError(hashErr)
+	}
+	return nil
+}
+
+// checkImportPassword проверяет открытый пароль строки по политике паролей
+func checkImportPassword(ctx context.Context, opts ImportOptions, row importRow) error {
+	if opts.CheckPassword == nil {
+		return errors.New("открытые пароли не принимаются, укажите хеш")
+	}
+	err := opts.CheckPassword(ctx, row.user.Email, row.password)
+	if err == nil {
+		return nil
+	}
+	// Отчет должен назвать нарушенные правила, а не только общий текст ошибки валидации
+	var e *errs.Error
+	if errors.As(err, &e) && e.Metadata()["password"] != "" {
+		return fmt.Errorf("пароль не соответствует политике: %s", e.Metadata()["password"])
+	}
+	return err
+}
+
+// prepareImportRow проверяет строку и приводит пароль к хранимому формату
+func prepareImportRow(line int, rec *ImportRecord, knownRoles []string) (importRow, error) {
+	row := importRow{line: line}
+
+	email := strings.TrimSpace(rec.Email)
+	if email == "" {
+		return row, errors.New("email обязателен")
+	}
+	if !govalidator.IsEmail(email) {
+		return row, errors.New("неверный формат email")
+	}
+	row.user.Email = email
+
+	for _, role := range rec.Roles {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		if !slices.Contains(knownRoles, role) {
+			return row, fmt.Errorf("неизвестная роль: %s", role)
+		}
+		row.user.Roles = append(row.user.Roles, role)
+	}
+
+	algo := rec.PasswordAlgo
+	if algo == "" {
+		algo = detectImportAlgo(rec)
+	}
+
+	switch algo {
+	case importAlgoPlain:
+		if rec.Password == "" {
+			return row, errors.New("пароль обязателен")
+		}
+		row.password = rec.Password
+	case importAlgoBcrypt:
+		if _, err := bcrypt.Cost([]byte(rec.PasswordHash)); err != nil {
+			return row, fmt.Errorf("неверный хеш bcrypt: %v", err)
+		}
+		// Хеш с низкой стоимостью пересчитается при первом входе
+		row.user.PasswordHash = rec.PasswordHash
+	case importAlgoSHA256:
+		if rec.Salt == "" {
+			return row, errors.New("для sha256_salted обязательна соль")
+		}
+		if len(rec.PasswordHash) != 64 || strings.Trim(strings.ToLower(rec.PasswordHash), "0123456789abcdef") != "" {
+			return row, errors.New("неверный хеш sha256: ожидается 64 hex символа")
+		}
+		row.user.PasswordHash = passhash.FormatSaltedSHA256(rec.Salt, rec.PasswordHash)
+	case importAlgoPHPass:
+		if !passhash.IsPHPass(rec.PasswordHash) {
+			return row, errors.New("неверный хеш PHPass")
+		}
+		row.user.PasswordHash = rec.PasswordHash
+	case "":
+		return row, errors.New("не указан ни пароль, ни хеш пароля")
+	default:
+		return row, fmt.Errorf("неизвестный алгоритм пароля: %s", algo)
+	}
+
+	return row, nil
+}
+
+// detectImportAlgo определяет алгоритм по заполненным полям, если он не указан явно
+func detectImportAlgo(rec *ImportRecord) string {
+	switch {
+	case rec.Password != "":
+		return importAlgoPlain
+	case strings.HasPrefix(rec.PasswordHash, "$2"):
+		return importAlgoBcrypt
+	case passhash.IsPHPass(rec.PasswordHash):
+		return importAlgoPHPass
+	case rec.PasswordHash != "" && rec.Salt != "":
+		return importAlgoSHA256
+	}
+	return ""
+}
+
+func (r *ImportReport) fail(line int, email string, err error) {
+	r.Failed++
+	r.Errors = append(r.Errors, ImportRowError{Line: line, Email: strings.TrimSpace(email), Error: err.Error()})
+}
+
+// importRowErr ошибка разбора отдельной строки, после которой чтение файла можно продолжить
+type importRowErr struct{ err error }
+
+func (e *importRowErr) Error() string { return e.err.Error() }
+
+// csvRecords читает CSV с заголовком. Пустые строки пропускаются
+func csvRecords(r io.Reader) func() (int, *ImportRecord, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	var columns map[string]int
+	return func() (int, *ImportRecord, error) {
+		if columns == nil {
+			header, err := cr.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return 0, nil, err
+				}
+				return 1, nil, err
+			}
+			columns = make(map[string]int, len(header))
+			for i, name := range header {
+				columns[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := columns["email"]; !ok {
+				return 1, nil, errors.New("в заголовке нет столбца email")
+			}
+			cr.FieldsPerRecord = len(header)
+		}
+
+		// FieldPos нельзя вызывать после ошибки: у неразобранной строки нет полей
+		fields, err := cr.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return 0, nil, err
+			}
+			if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				return parseErr.StartLine, nil, &importRowErr{err: errors.New("неверное число столбцов")}
+			}
+			return parseErr.StartLine, nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return fields[i]
+			}
+			return ""
+		}
+
+		rec := &ImportRecord{
+			Email:        field("email"),
+			Password:     field("password"),
+			PasswordHash: strings.TrimSpace(field("password_hash")),
+			PasswordAlgo: strings.TrimSpace(field("password_algo")),
+			Salt:         field("salt"),
+		}
+		if roles := field("roles"); roles != "" {
+			rec.Roles = strings.Split(roles, importRolesSeparator)
+		}
+		return line, rec, nil
+	}
+}
+
+// jsonlRecords читает по одному JSON объекту на строку. Пустые строки пропускаются
+func jsonlRecords(r io.Reader) func() (int, *ImportRecord, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	line := 0
+	return func() (int, *ImportRecord, error) {
+		for sc.Scan() {
+			line++
+			data := strings.TrimSpace(sc.Text())
+			if data == "" {
+				continue
+			}
+
+			rec := new(ImportRecord)
+			if err := json.Unmarshal([]byte(data), rec); err != nil {
+				return line, nil, &importRowErr{err: fmt.Errorf("неверный JSON: %v", err)}
+			}
+			return line, rec, nil
+		}
+		if err := sc.Err(); err != nil {
+			return line + 1, nil, err
+		}
+		return line, nil, io.EOF
+	}
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// importLine результат чтения одной строки файла импорта
+type importLine struct {
+	line   int
+	email  string
+	rowErr bool // ошибка строки, после которой чтение продолжается
+}
+
+// readImport читает все строки до конца файла или ошибки структуры файла
+func readImport(t *testing.T, next func() (int, *ImportRecord, error)) ([]importLine, error) {
+	t.Helper()
+
+	var got []importLine
+	for {
+		line, rec, err := next()
+		if errors.Is(err, io.EOF) {
+			return got, nil
+		}
+		if err != nil {
+			var rowErr *importRowErr
+			if !errors.As(err, &rowErr) {
+				return got, err
+			}
+			got = append(got, importLine{line: line, rowErr: true})
+			continue
+		}
+		got = append(got, importLine{line: line, email: rec.Email})
+	}
+}
+
+func TestCSVRecords(t *testing.T) {
+	data := "Email, roles,password_hash\n" +
+		"a@example.com,admin;user,$2a$10$hash\n" +
+		"\n" +
+		"b@example.com,user\n" +
+		"c@example.com,,\n"
+
+	got, err := readImport(t, csvRecords(strings.NewReader(data)))
+	if err != nil {
+		t.Fatalf("csvRecords: %v", err)
+	}
+
+	want := []importLine{
+		{line: 2, email: "a@example.com"},
+		{line: 4, rowErr: true}, // неверное число столбцов
+		{line: 5, email: "c@example.com"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("прочитано %+v, ожидалось %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("строка %d: %+v, ожидалось %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestCSVRecordsFields(t *testing.T) {
+	data := "email,roles,password,password_hash,password_algo,salt\n" +
+		"a@example.com,admin;user,,  abcdef  , sha256_salted ,s$1\n"
+
+	_, rec, err := csvRecords(strings.NewReader(data))()
+	if err != nil {
+		t.Fatalf("csvRecords: %v", err)
+	}
+	if rec.PasswordHash != "abcdef" || rec.PasswordAlgo != importAlgoSHA256 || rec.Salt != "s$1" {
+		t.Fatalf("запись %+v", rec)
+	}
+	if len(rec.Roles) != 2 || rec.Roles[0] != "admin" || rec.Roles[1] != "user" {
+		t.Fatalf("роли %v", rec.Roles)
+	}
+}
+
+func TestCSVRecordsMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "нет столбца email", data: "login,password\nuser,secret\n"},
+		{name: "незакрытая кавычка", data: "email\n\"a@example.com\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := readImport(t, csvRecords(strings.NewReader(tt.data))); err == nil {
+				t.Fatal("csvRecords принял файл с нарушенной структурой")
+			}
+		})
+	}
+}
+
+func TestJSONLRecords(t *testing.T) {
+	data := `{"email": "a@example.com", "roles": ["user"]}` + "\n" +
+		"\n" +
+		`{"email": ` + "\n" +
+		`  {"email": "b@example.com", "password": "secret"}  ` + "\n"
+
+	got, err := readImport(t, jsonlRecords(strings.NewReader(data)))
+	if err != nil {
+		t.Fatalf("jsonlRecords: %v", err)
+	}
+
+	want := []importLine{
+		{line: 1, email: "a@example.com"},
+		{line: 3, rowErr: true},
+		{line: 4, email: "b@example.com"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("прочитано %+v, ожидалось %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("строка %d: %+v, ожидалось %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPrepareImportRow(t *testing.T) {
+	const (
+		bcryptHash = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+		phpassHash = "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0"
+		sha256Hash = "27ED680FC360A2FE316D42D49186ED118A25FDD817A3900336282A9EF7ECA841"
+	)
+	roles := []string{"admin", "user"}
+
+	tests := []struct {
+		name     string
+		rec      ImportRecord
+		wantHash string // ожидаемый хранимый хеш
+		wantErr  string // часть текста ошибки строки
+	}{
+		{name: "открытый пароль", rec: ImportRecord{Email: "a@example.com", Password: "secret"}},
+		{name: "bcrypt", rec: ImportRecord{Email: "a@example.com", PasswordHash: bcryptHash}, wantHash: bcryptHash},
+		{name: "phpass", rec: ImportRecord{Email: "a@example.com", PasswordHash: phpassHash}, wantHash: phpassHash},
+		{name: "sha256 с солью", rec: ImportRecord{Email: "a@example.com", PasswordHash: sha256Hash, Salt: "abc$1"},
+			wantHash: "$sha256s$abc$1$" + strings.ToLower(sha256Hash)},
+		{name: "нет email", rec: ImportRecord{Password: "secret"}, wantErr: "email обязателен"},
+		{name: "неверный email", rec: ImportRecord{Email: "not-an-email", Password: "secret"}, wantErr: "неверный формат email"},
+		{name: "неизвестная роль", rec: ImportRecord{Email: "a@example.com", Password: "secret", Roles: []string{"root"}}, wantErr: "неизвестная роль"},
+		{name: "нет пароля", rec: ImportRecord{Email: "a@example.com"}, wantErr: "не указан ни пароль"},
+		{name: "plain без пароля", rec: ImportRecord{Email: "a@example.com", PasswordAlgo: importAlgoPlain}, wantErr: "пароль обязателен"},
+		{name: "неверный bcrypt", rec: ImportRecord{Email: "a@example.com", PasswordHash: "$2a$xx"}, wantErr: "неверный хеш bcrypt"},
+		{name: "sha256 без соли", rec: ImportRecord{Email: "a@example.com", PasswordHash: sha256Hash, PasswordAlgo: importAlgoSHA256}, wantErr: "обязательна соль"},
+		{name: "sha256 не hex", rec: ImportRecord{Email: "a@example.com", PasswordHash: strings.Repeat("z", 64), Salt: "s"}, wantErr: "неверный хеш sha256"},
+		{name: "неверный phpass", rec: ImportRecord{Email: "a@example.com", PasswordHash: "$P$short", PasswordAlgo: importAlgoPHPass}, wantErr: "неверный хеш PHPass"},
+		{name: "неизвестный алгоритм", rec: ImportRecord{Email: "a@example.com", PasswordHash: "x", PasswordAlgo: "md5"}, wantErr: "неизвестный алгоритм"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row, err := prepareImportRow(7, &tt.rec, roles)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("prepareImportRow = %v, ожидалась ошибка %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("prepareImportRow: %v", err)
+			}
+			if row.line != 7 || row.user.PasswordHash != tt.wantHash || (tt.wantHash == "") != (row.password != "") {
+				t.Fatalf("строка %+v", row)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Режимы регистрации (config registration.mode)
+const (
+	RegistrationOpen             = "open"
+	RegistrationInviteOnly       = "invite_only"
+	RegistrationClosed           = "closed"
+	RegistrationDomainRestricted = "domain_restricted"
+)
+
+var registrationModes = []string{
+	RegistrationOpen,
+	RegistrationInviteOnly,
+	RegistrationClosed,
+	RegistrationDomainRestricted,
+}
+
+// invitePrefix отличает коды приглашений от других секретов сервиса
+const invitePrefix = "inv_"
+
+// admitRegistration проверяет, можно ли создать аккаунт для email в текущем режиме.
+// Сам код приглашения проверяется при создании пользователя, в одной транзакции с ним
+func (s *Service) admitRegistration(email, inviteCode string) error {
+	switch s.cfg.Registration.Mode {
+	case RegistrationClosed:
+		return ErrRegistrationClosed
+	case RegistrationInviteOnly:
+		if inviteCode == "" {
+			return ErrInviteRequired
+		}
+	case RegistrationDomainRestricted:
+		// С доменов не из списка можно зарегистрироваться только по приглашению
+		if inviteCode == "" && !s.registrationDomainAllowed(email) {
+			return ErrRegistrationDomain.With("domain", email[strings.LastIndexByte(email, '@')+1:])
+		}
+	}
+	return nil
+}
+
+// registrationDomainAllowed входит ли домен email или его родительский домен в список режима domain_restricted
+func (s *Service) registrationDomainAllowed(email string) bool {
+	domain := strings.ToLower(email[strings.LastIndexByte(email, '@')+1:])
+	for _, allowed := range s.registrationDomains {
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateInvite создает приглашение и возвращает его код (единственный раз). Если задан email,
+// по приглашению может зарегистрироваться только он; roles назначаются сверх роли по умолчанию
+func (s *Service) CreateInvite(
+	ctx context.Context,
+	createdBy string,
+	email string,
+	roles []string,
+	maxUses int,
+	expiresAt *time.Time,
+) (*models.Invite, string, error) {
+	l := s.log.With(slog.String("op", "create_invite"))
+
+	if expiresAt == nil && s.cfg.Registration.InviteTTL > 0 {
+		t := time.Now().Add(s.cfg.Registration.InviteTTL)
+		expiresAt = &t
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrExpiryInPast.WithMessage("срок действия приглашения уже истек")
+	}
+	if maxUses <= 0 {
+		maxUses = 1
+	}
+
+	code := invitePrefix + randomToken(16)
+	invite := &models.Invite{
+		InviteId: uuid.New(),
+		CodeHash: hashSecret(code),
+		Roles:    pq.StringArray(roles),
+		MaxUses:  maxUses,
+	}
+	if email != "" {
+		invite.Email = sql.NullString{String: email, Valid: true}
+	}
+	// Машинный клиент с ролью admin тоже может создавать приглашения, но не является пользователем
+	if id, err := uuid.Parse(createdBy); err == nil {
+		invite.CreatedBy = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if expiresAt != nil {
+		invite.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	if err := models.CreateInvite(ctx, invite); err != nil {
+		l.Debug("ошибка создания приглашения", logger.Err(err))
+		return nil, "", err
+	}
+
+	l.Info("приглашение создано", slog.String("invite_id", invite.InviteId.String()))
+	return invite, code, nil
+}
+
+// ListInvites возвращает неотозванные приглашения
+func (s *Service) ListInvites(ctx context.Context) ([]*models.Invite, error) {
+	invites, err := models.ListInvites(ctx)
+	if err != nil {
+		s.log.Error("ошибка при получении приглашений", logger.Err(err))
+		return nil, err
+	}
+	return invites, nil
+}
+
+// RevokeInvite отзывает приглашение; уже созданные по нему аккаунты не затрагиваются
+func (s *Service) RevokeInvite(ctx context.Context, inviteID uuid.UUID) error {
+	l := s.log.With(slog.String("op", "revoke_invite"), slog.String("invite_id", inviteID.String()))
+
+	if err := models.RevokeInvite(ctx, inviteID); err != nil {
+		l.Debug("ошибка отзыва приглашения", logger.Err(err))
+		return err
+	}
+
+	l.Info("приглашение отозвано")
+	return nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/services/mailer"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// RequestMagicLink отправляет одноразовую ссылку входа на email.
+// Ответ не зависит от существования пользователя, чтобы не раскрывать зарегистрированные адреса:
+// после проверки лимита поиск пользователя, создание ссылки и отправка выполняются в фоне,
+// и запрос для известного и неизвестного адреса завершается одинаково и за одно время
+func (s *Service) RequestMagicLink(ctx context.Context, email string) error {
+	hashedEmail := s.HashEmail(email)
+	l := s.log.With(slog.String("email_hash", hashedEmail), slog.String("op", "request_magic_link"))
+
+	l.Debug("запрос ссылки для входа")
+
+	// Лимит действует и для несуществующих адресов, иначе его срабатывание раскрывало бы регистрацию.
+	// Ключ - канонический вид, чтобы +метки не обходили лимит
+	canonical := s.CanonicalEmail(email)
+	if !s.magicLinkLimiter.Allow(canonical) {
+		l.Warn("превышен лимит запросов ссылки для входа")
+		return ErrRateLimited
+	}
+
+	// Контекст запроса отменяется вместе с ответом; арендатор из него сохраняется
+	bgCtx := context.WithoutCancel(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(bgCtx, timeOutSendMail)
+		defer cancel()
+		s.sendMagicLink(ctx, l, canonical)
+	}()
+
+	return nil
+}
+
+// sendMagicLink создает ссылку входа и отправляет ее пользователю с адресом canonical.
+// Неизвестным и неактивным аккаунтам письмо не отправляется
+func (s *Service) sendMagicLink(ctx context.Context, l *slog.Logger, canonical string) {
+	user, err := models.GetUserByEmail(ctx, canonical)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			l.Debug("пользователь не найден, письмо не отправляется")
+			return
+		}
+		l.Error("ошибка при поиске пользователя", logger.Err(err))
+		return
+	}
+
+	if s.checkUserActive(user) != nil {
+		l.Debug("аккаунт неактивен, письмо не отправляется")
+		return
+	}
+
+	token := randomToken(32)
+	if err = models.CreateMagicLink(ctx, hashSecret(token), user.UserId, time.Now().Add(s.cfg.MagicLink.TTL)); err != nil {
+		l.Error("ошибка создания ссылки для входа", logger.Err(err))
+		return
+	}
+
+	link := s.cfg.MagicLink.URL + "?token=" + url.QueryEscape(token)
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Вход в аккаунт",
+		Body: fmt.Sprintf("Для входа перейдите по ссылке:\n\n%s\n\n"+
+			"Ссылка действует %d мин. и может быть использована один раз.\n"+
+			"Если вы не запрашивали вход, просто проигнорируйте это письмо.\n",
+			link, int(s.cfg.MagicLink.TTL.Minutes())),
+	}
+
+	if err = s.mailer.Send(ctx, msg); err != nil {
+		l.Error("ошибка отправки письма", slog.String("subject", msg.Subject), logger.Err(err))
+	}
+}
+
+// ConsumeMagicLink обменивает одноразовый токен из ссылки на JWT токен
+func (s *Service) ConsumeMagicLink(ctx context.Context, token string) (*models.AuthResponse, error) {
+	l := s.log.With(slog.String("op", "consume_magic_link"))
+
+	userID, err := models.ConsumeMagicLink(ctx, hashSecret(token))
+	if err != nil {
+		if errors.Is(err, models.ErrMagicLinkNotFound) {
+			l.Debug("ссылка недействительна или уже использована")
+			return nil, ErrLinkInvalid
+		}
+		l.Error("ошибка при использовании ссылки", logger.Err(err))
+		return nil, errs.Internal("failed to consume magic link", err)
+	}
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	if err = s.checkUserActive(user); err != nil {
+		l.Debug("вход в неактивный аккаунт", logger.Err(err))
+		return nil, err
+	}
+
+	rsp, err := s.issueLoginToken(ctx, l, user)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.PasswordExpired {
+		return rsp, nil
+	}
+
+	s.recordLogin(ctx, l, user.UserId)
+
+	l.Info("успешный вход по ссылке", slog.String("email_hash", s.HashEmail(user.Email)))
+	return rsp, nil
+}
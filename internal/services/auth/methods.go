@@ -1,17 +1,17 @@
 package auth
 
 import (
+	"auth-service/internal/errs"
 	"auth-service/internal/models"
 	"auth-service/pkg/logger"
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"log/slog"
+	"strings"
+	"time"
 )
 
 // Register выполняет регистрацию пользователя и возвращает JWT токен
@@ -22,26 +22,43 @@ func (s *Service) Register(ctx context.Context, request *models.AuthRequest) (*m
 
 	l.Debug("начало регистрации пользователя")
 
-	// 1. Хеширование пароля
-	hashedPwd, err := s.HashPassword(request.Password)
+	// 1. Проверка режима регистрации
+	if err := s.admitRegistration(request.Email, request.InviteCode); err != nil {
+		l.Debug("регистрация не разрешена", logger.Err(err))
+		return nil, err
+	}
+
+	// 2. Хеширование пароля
+	hashedPwd, err := s.HashPassword(ctx, request.Password)
 	if err != nil {
 		l.Error("ошибка хеширования пароля", logger.Err(err))
-		return nil, status.Error(codes.Internal, "failed to hash password")
+		return nil, hashingError(err)
 	}
 
-	// 2. Создание записи пользователя
+	// 3. Создание записи пользователя; приглашение используется, даже если режим его не требует,
+	// чтобы назначить роли из него
 	userID := uuid.New()
-	user, err := models.CreateUser(ctx, userID, request.Email, hashedPwd)
+	canonical := s.CanonicalEmail(request.Email)
+	var user *models.User
+	if request.InviteCode != "" {
+		user, err = models.CreateUserWithInvite(ctx, userID, request.Email, canonical, hashedPwd, hashSecret(request.InviteCode))
+		if errors.Is(err, models.ErrInviteNotFound) {
+			l.Debug("недействительное приглашение")
+			return nil, ErrInviteInvalid
+		}
+	} else {
+		user, err = models.CreateUser(ctx, userID, request.Email, canonical, hashedPwd)
+	}
 	if err != nil {
 		l.Error("ошибка создания пользователя", logger.Err(err))
 		return nil, err
 	}
 
-	// 3. Генерация JWT токена
-	token, err := s.CreateToken(user)
+	// 4. Генерация JWT токена
+	token, err := s.CreateToken(ctx, user)
 	if err != nil {
 		l.Error("ошибка создания токена", logger.Err(err))
-		return nil, status.Error(codes.Internal, "failed to create token")
+		return nil, errs.Internal("failed to create token", err)
 	}
 
 	l.Info("пользователь успешно зарегистрирован")
@@ -59,37 +76,74 @@ func (s *Service) Login(ctx context.Context, request *models.AuthRequest) (*mode
 	l.Debug("попытка входа в систему")
 
 	// 1. Получаем пользователя по email
-	user, err := models.GetUserByEmail(ctx, request.Email)
+	user, err := models.GetUserByEmail(ctx, s.CanonicalEmail(request.Email))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, models.ErrUserNotFound) {
+			// Сравниваем с фиктивным хешем, чтобы время ответа и ошибка не раскрывали,
+			// зарегистрирован ли email
+			if _, err = s.CheckPasswordHash(ctx, request.Password, s.dummyHash()); err != nil {
+				return nil, hashingError(err)
+			}
 			l.Debug("пользователь не найден")
-			return nil, status.Error(codes.NotFound, "пользователь не найден")
+			return nil, ErrInvalidCredentials
 		}
 		l.Error("ошибка при поиске пользователя", logger.Err(err))
-		return nil, status.Errorf(codes.Internal, "ошибка при поиске пользователя: %v", err)
+		return nil, err
 	}
 
 	// 2. Проверяем пароль
-	if !s.CheckPasswordHash(request.Password, user.PasswordHash) {
+	ok, rehash, err := s.verifyPassword(ctx, request.Password, user.PasswordHash)
+	if err != nil {
+		l.Warn("ошибка проверки пароля", logger.Err(err))
+		return nil, hashingError(err)
+	}
+	if !ok {
 		l.Debug("неверный пароль")
-		return nil, status.Error(codes.Unauthenticated, "неверный пароль")
+		return nil, ErrInvalidCredentials
+	}
+
+	if err = s.checkUserActive(user); err != nil {
+		l.Debug("вход в неактивный аккаунт", logger.Err(err))
+		return nil, err
 	}
 
-	// 3. Создаем JWT токен (роли будут получены внутри CreateToken)
-	token, err := s.CreateToken(user)
+	// Хеш старого алгоритма или со слабыми параметрами пересчитываем, пока знаем пароль
+	if rehash {
+		s.rehashPassword(l, user, request.Password)
+	}
+
+	// 3. Создаем JWT токен (роли будут получены внутри CreateToken).
+	// С истекшим паролем выдается только токен для смены пароля
+	authTime := time.Now()
+	rsp, err := s.issueLoginToken(ctx, l, user)
 	if err != nil {
-		l.Error("ошибка при создании токена", logger.Err(err))
-		return nil, status.Errorf(codes.Internal, "ошибка при создании токена: %v", err)
+		return nil, err
+	}
+	if rsp.PasswordExpired {
+		return rsp, nil
 	}
 
+	// 4. Для OIDC клиентов дополнительно выпускаем ID токен
+	if request.ClientID != "" {
+		rsp.IDToken, err = s.CreateIDToken(user, request.ClientID, request.Nonce, rsp.JWTToken, authTime)
+		if err != nil {
+			if errors.Is(err, ErrOIDCDisabled) || errors.Is(err, ErrUnknownClient) {
+				l.Debug("ID токен не выпущен", logger.Err(err))
+				return nil, err
+			}
+			l.Error("ошибка при создании ID токена", logger.Err(err))
+			return nil, errs.Internal("failed to create id token", err)
+		}
+	}
+
+	s.recordLogin(ctx, l, user.UserId)
+
 	l.Info("успешный вход в систему")
-	return &models.AuthResponse{
-		JWTToken: token,
-	}, nil
+	return rsp, nil
 }
 
-// VerifyToken проверяет JWT токен и извлекает данные пользователя
-func (s *Service) VerifyToken(_ context.Context, tokenString string) (*models.TokenInfo, error) {
+// parseJWT проверяет подпись и срок действия JWT и возвращает его claims
+func (s *Service) parseJWT(tokenString string) (jwt.MapClaims, error) {
 	// Парсим токен
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// Проверяем, что используется правильный алгоритм подписи
@@ -102,46 +156,88 @@ func (s *Service) VerifyToken(_ context.Context, tokenString string) (*models.To
 	// Обрабатываем ошибки парсинга
 	if err != nil {
 		s.log.Warn("Ошибка при парсинге токена", slog.String("ошибка", err.Error()))
-		return nil, fmt.Errorf("недействительный токен: %w", err)
+		return nil, ErrInvalidToken.Wrap(err)
 	}
 
 	// Проверяем валидность токена
 	if !token.Valid {
 		s.log.Warn("Токен недействителен")
-		return nil, errors.New("токен недействителен")
+		return nil, ErrInvalidToken
 	}
 
 	// Извлекаем claims
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok {
 		s.log.Warn("Не удалось извлечь claims из токена")
-		return nil, errors.New("не удалось извлечь данные из токена")
+		return nil, ErrInvalidToken.WithMessage("не удалось извлечь данные из токена")
+	}
+
+	return claims, nil
+}
+
+// VerifyToken проверяет JWT токен и извлекает данные пользователя
+func (s *Service) VerifyToken(ctx context.Context, tokenString string) (*models.TokenInfo, error) {
+	// API ключи принимаются наравне с JWT и возвращают те же данные
+	if strings.HasPrefix(tokenString, apiKeyPrefix) {
+		return s.verifyApiKey(ctx, tokenString)
+	}
+
+	claims, err := s.parseJWT(tokenString)
+	if err != nil {
+		return nil, err
 	}
 
 	// Проверяем issuer
 	if iss, ok := claims["iss"].(string); !ok || iss != "auth-service" {
 		s.log.Warn("Недействительный издатель токена", slog.String("издатель", fmt.Sprintf("%v", claims["iss"])))
-		return nil, errors.New("недействительный издатель токена")
+		return nil, ErrInvalidToken.WithMessage("недействительный издатель токена")
 	}
 
 	// Проверяем audience
 	if aud, ok := claims["aud"].(string); !ok || aud != "chef-app-services" {
 		s.log.Warn("Недействительная аудитория токена", slog.String("аудитория", fmt.Sprintf("%v", claims["aud"])))
-		return nil, errors.New("недействительная аудитория токена")
+		return nil, ErrInvalidToken.WithMessage("недействительная аудитория токена")
+	}
+
+	// Проверки токена выполняются в пределах арендатора, которому он выдан
+	ctx, tenantID, err := tokenTenant(ctx, claims)
+	if err != nil {
+		s.log.Warn("Токен другого арендатора", slog.String("tid", fmt.Sprintf("%v", claims["tid"])))
+		return nil, err
+	}
+	if err = s.checkTenantActive(ctx, tenantID); err != nil {
+		s.log.Warn("Токен отключенного арендатора", slog.String("tid", tenantID.String()))
+		return nil, err
 	}
 
 	// Извлекаем userId
 	userID, ok := claims["sub"].(string)
 	if !ok || userID == "" {
 		s.log.Warn("Недействительный или отсутствующий ID пользователя в токене")
-		return nil, errors.New("недействительный ID пользователя в токене")
+		return nil, ErrInvalidToken.WithMessage("недействительный ID пользователя в токене")
 	}
 
-	// Извлекаем email
-	email, ok := claims["email"].(string)
-	if !ok || email == "" {
+	// Токены без sub_type выпущены до появления машинных клиентов и принадлежат пользователям
+	subType, _ := claims["sub_type"].(string)
+	isService := subType == subTypeService
+
+	// Извлекаем email (у машинных клиентов его нет)
+	email, _ := claims["email"].(string)
+	if !isService && email == "" {
 		s.log.Warn("Недействительный или отсутствующий email в токене")
-		return nil, errors.New("недействительный email в токене")
+		return nil, ErrInvalidToken.WithMessage("недействительный email в токене")
+	}
+
+	// Токены пользователя отзываются увеличением token_version (смена пароля или email)
+	// и перестают действовать сразу после блокировки аккаунта; токены сервиса - отзывом клиента
+	if isService {
+		err = s.checkServiceClient(ctx, userID)
+	} else {
+		err = s.checkTokenState(ctx, userID, claims["ver"])
+	}
+	if err != nil {
+		s.log.Warn("Токен отозван", slog.String("ошибка", err.Error()))
+		return nil, err
 	}
 
 	// Извлекаем роли
@@ -157,15 +253,29 @@ func (s *Service) VerifyToken(_ context.Context, tokenString string) (*models.To
 	}
 
 	// Логируем успешную проверку
-	s.log.Info("Токен успешно проверен",
-		slog.String("email", s.HashEmail(email)),
-	)
+	if isService {
+		s.log.Info("Токен успешно проверен", slog.String("client_id", userID))
+	} else {
+		s.log.Info("Токен успешно проверен",
+			slog.String("email", s.HashEmail(email)),
+		)
+	}
+
+	info := &models.TokenInfo{
+		UserID:    userID,
+		Email:     email,
+		Roles:     roles,
+		IsValid:   true,
+		IsService: isService,
+		TenantID:  tenantID.String(),
+	}
+	if authTime, ok := claims["auth_time"].(float64); ok {
+		info.AuthTime = time.Unix(int64(authTime), 0)
+	} else if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		// Токены, выданные до появления auth_time, выдавались только при входе
+		info.AuthTime = iat.Time
+	}
 
 	// Возвращаем информацию о токене
-	return &models.TokenInfo{
-		UserID:  userID,
-		Email:   email,
-		Roles:   roles,
-		IsValid: true,
-	}, nil
+	return info, nil
 }
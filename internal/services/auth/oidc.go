@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/tenant"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOIDCDisabled  = errs.New(errs.KindFailedPrecondition, "OIDC_DISABLED", "OIDC провайдер не настроен")
+	ErrUnknownClient = errs.New(errs.KindInvalidArgument, "UNKNOWN_CLIENT", "неизвестный client_id")
+
+	ErrInvalidRedirectURI          = errs.New(errs.KindInvalidArgument, "INVALID_REDIRECT_URI", "redirect_uri не зарегистрирован для клиента")
+	ErrInvalidAuthorizationRequest = errs.New(errs.KindInvalidArgument, "INVALID_AUTHORIZATION_REQUEST", "запрос авторизации недействителен или истек")
+	ErrInvalidGrant                = errs.New(errs.KindUnauthenticated, "INVALID_GRANT", "код авторизации недействителен, истек или уже использован")
+)
+
+// JWK публичный ключ в формате JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS набор публичных ключей для проверки ID токенов
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Discovery документ /.well-known/openid-configuration (OpenID Connect Discovery 1.0 §3)
+type Discovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// loadSigningKey читает RSA ключ для подписи ID токенов (PKCS#1 или PKCS#8)
+func loadSigningKey(path string) (*rsa.PrivateKey, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, "", errors.New("файл ключа не содержит PEM блок")
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		var parsed any
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err == nil {
+			var ok bool
+			if key, ok = parsed.(*rsa.PrivateKey); !ok {
+				err = errors.New("ключ не является RSA ключом")
+			}
+		}
+	default:
+		err = fmt.Errorf("неподдерживаемый тип PEM блока: %s", block.Type)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	// kid вычисляем из публичного ключа, чтобы он менялся вместе с ротацией ключа
+	pub := x509.MarshalPKCS1PublicKey(&key.PublicKey)
+	sum := sha256.Sum256(pub)
+
+	return key, base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+// CreateIDToken создает OIDC ID токен для клиента, подписанный RS256
+func (s *Service) CreateIDToken(user *models.User, clientID, nonce, accessToken string, authTime time.Time) (string, error) {
+	if s.idKey == nil {
+		return "", ErrOIDCDisabled
+	}
+	if !slices.Contains(s.cfg.OIDC.ClientIDs, clientID) {
+		return "", ErrUnknownClient
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":                s.cfg.OIDC.Issuer,
+		"sub":                user.UserId,
+		"aud":                clientID,
+		"exp":                now.Add(s.cfg.OIDC.IDTokenTTL).Unix(),
+		"iat":                now.Unix(),
+		"auth_time":          authTime.Unix(),
+		"email":              user.Email,
+		"preferred_username": user.Username,
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	if accessToken != "" {
+		claims["at_hash"] = atHash(accessToken)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.idKeyID
+
+	return token.SignedString(s.idKey)
+}
+
+// atHash вычисляет at_hash: левая половина SHA-256 от access токена в base64url
+func atHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
+
+// JWKS возвращает публичные ключи для проверки ID токенов
+func (s *Service) JWKS() (*JWKS, error) {
+	if s.idKey == nil {
+		return nil, ErrOIDCDisabled
+	}
+
+	pub := s.idKey.PublicKey
+	return &JWKS{Keys: []JWK{{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: jwt.SigningMethodRS256.Alg(),
+		Kid: s.idKeyID,
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}}, nil
+}
+
+// Discovery возвращает документ OIDC discovery. Вход пользователей - authorization code flow
+// с обязательным PKCE; client_credentials - для машинных клиентов
+func (s *Service) Discovery() (*Discovery, error) {
+	if s.idKey == nil {
+		return nil, ErrOIDCDisabled
+	}
+
+	issuer := strings.TrimSuffix(s.cfg.OIDC.Issuer, "/")
+	return &Discovery{
+		Issuer:                           issuer,
+		AuthorizationEndpoint:            issuer + "/authorize",
+		TokenEndpoint:                    issuer + "/token",
+		UserinfoEndpoint:                 issuer + "/userinfo",
+		JwksURI:                          issuer + "/.well-known/jwks.json",
+		ScopesSupported:                  []string{"openid", "email", "profile"},
+		ResponseTypesSupported:           []string{"code"},
+		GrantTypesSupported:              []string{"authorization_code", "client_credentials"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{jwt.SigningMethodRS256.Alg()},
+		// none - публичные клиенты с PKCE; секреты есть только у машинных клиентов
+		TokenEndpointAuthMethodsSupported: []string{"none", "client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{pkceMethodS256},
+		ClaimsSupported: []string{
+			"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "at_hash",
+			"email", "preferred_username",
+		},
+	}, nil
+}
+
+// UserInfo возвращает claims пользователя по access токену
+func (s *Service) UserInfo(ctx context.Context, accessToken string) (*models.UserInfo, error) {
+	tokenInfo, err := s.VerifyToken(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if tokenInfo.IsService {
+		return nil, ErrInvalidToken.WithMessage("userinfo недоступен для токенов сервисов")
+	}
+
+	userID, err := uuid.Parse(tokenInfo.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken.WithMessage("недействительный ID пользователя в токене").Wrap(err)
+	}
+
+	// Данные берем из auth.users, а не из токена, чтобы отдавать актуальный профиль
+	tenantID, err := uuid.Parse(tokenInfo.TenantID)
+	if err != nil {
+		return nil, ErrInvalidToken.WithMessage("недействительный арендатор в токене").Wrap(err)
+	}
+	user, err := models.GetUserByID(tenant.With(ctx, tenantID), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserInfo{
+		Sub:               user.UserId.String(),
+		Email:             user.Email,
+		PreferredUsername: user.Username,
+		Roles:             tokenInfo.Roles,
+	}, nil
+}
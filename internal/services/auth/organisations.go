@@ -0,0 +1,328 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/services/mailer"
+	"auth-service/internal/tenant"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"log/slog"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// orgRoleManager роль, которая вместе с owner дает право приглашать участников и менять их роли
+const orgRoleManager = "manager"
+
+// OrgActor вызывающий в операциях с организацией. Администратор арендатора управляет любой
+// организацией, не состоя в ней; у машинного клиента с ролью admin UserID пустой
+type OrgActor struct {
+	UserID uuid.UUID
+	Admin  bool
+}
+
+// orgAccess права вызывающего в организации
+type orgAccess struct {
+	manage bool // приглашать, менять роли и исключать участников
+	owner  bool // назначать и снимать роль owner
+}
+
+// orgAccess определяет права вызывающего в организации
+func (s *Service) orgAccess(ctx context.Context, actor OrgActor, orgID uuid.UUID) (orgAccess, error) {
+	if actor.Admin {
+		if _, err := models.GetOrganisation(ctx, orgID); err != nil {
+			return orgAccess{}, err
+		}
+		return orgAccess{manage: true, owner: true}, nil
+	}
+
+	roles, err := models.GetOrganisationRoles(ctx, orgID, actor.UserID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganisationMemberNotFound) {
+			return orgAccess{}, ErrOrganisationForbidden
+		}
+		return orgAccess{}, err
+	}
+
+	owner := slices.Contains(roles, models.OrgRoleOwner)
+	return orgAccess{manage: owner || slices.Contains(roles, orgRoleManager), owner: owner}, nil
+}
+
+// checkOrgRoles проверяет роли по набору из конфигурации и убирает повторы
+func (s *Service) checkOrgRoles(roles []string) ([]string, error) {
+	checked := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if !slices.Contains(s.orgRoles, role) {
+			return nil, ErrUnknownOrganisationRole.With("role", role)
+		}
+		if !slices.Contains(checked, role) {
+			checked = append(checked, role)
+		}
+	}
+	return checked, nil
+}
+
+// CreateOrganisation создает организацию, ownerID становится ее владельцем
+func (s *Service) CreateOrganisation(ctx context.Context, createdBy string, name string, ownerID uuid.UUID) (*models.Organisation, error) {
+	l := s.log.With(slog.String("op", "create_organisation"))
+
+	org := &models.Organisation{OrgId: uuid.New(), Name: name}
+	// Машинный клиент с ролью admin тоже может создавать организации, но не является пользователем
+	if id, err := uuid.Parse(createdBy); err == nil {
+		org.CreatedBy = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	if err := models.CreateOrganisation(ctx, org, ownerID); err != nil {
+		l.Debug("ошибка создания организации", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("организация создана",
+		slog.String("org_id", org.OrgId.String()),
+		slog.String("owner_id", ownerID.String()),
+	)
+	return org, nil
+}
+
+// ListMyOrganisations возвращает организации пользователя с его ролями в каждой
+func (s *Service) ListMyOrganisations(ctx context.Context, userID uuid.UUID) ([]*models.OrganisationMembership, error) {
+	memberships, err := models.ListUserOrganisations(ctx, userID)
+	if err != nil {
+		s.log.Error("ошибка при получении организаций пользователя", logger.Err(err))
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// OrganisationRoles возвращает роли владельца проверенного токена в организации. Роли ищутся
+// у арендатора токена; если владелец токена не состоит в организации, это машинный клиент
+// или API ключ с ограниченным набором ролей, ролей нет, а токен остается действительным
+func (s *Service) OrganisationRoles(ctx context.Context, tokenInfo *models.TokenInfo, orgID uuid.UUID) ([]string, error) {
+	if tokenInfo.IsService || tokenInfo.ApiKeyScoped {
+		return nil, nil
+	}
+	userID, err := uuid.Parse(tokenInfo.UserID)
+	if err != nil {
+		return nil, nil
+	}
+	tenantID, err := uuid.Parse(tokenInfo.TenantID)
+	if err != nil {
+		return nil, nil
+	}
+
+	roles, err := models.GetOrganisationRoles(tenant.With(ctx, tenantID), orgID, userID)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganisationMemberNotFound) {
+			return nil, nil
+		}
+		s.log.Error("ошибка при получении ролей в организации", logger.Err(err))
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ListOrganisationMembers возвращает участников организации (владельцам, менеджерам и admin)
+func (s *Service) ListOrganisationMembers(ctx context.Context, actor OrgActor, orgID uuid.UUID) ([]*models.OrganisationMember, error) {
+	access, err := s.orgAccess(ctx, actor, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.manage {
+		return nil, ErrOrganisationForbidden
+	}
+
+	return models.ListOrganisationMembers(ctx, orgID)
+}
+
+// InviteToOrganisation создает приглашение и отправляет ссылку на email. Назначить роль owner
+// может только владелец или admin
+func (s *Service) InviteToOrganisation(
+	ctx context.Context,
+	actor OrgActor,
+	orgID uuid.UUID,
+	email string,
+	roles []string,
+) (*models.OrganisationInvitation, error) {
+	l := s.log.With(slog.String("op", "invite_to_organisation"), slog.String("org_id", orgID.String()))
+
+	access, err := s.orgAccess(ctx, actor, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.manage {
+		return nil, ErrOrganisationForbidden
+	}
+	if roles, err = s.checkOrgRoles(roles); err != nil {
+		return nil, err
+	}
+	if slices.Contains(roles, models.OrgRoleOwner) && !access.owner {
+		return nil, ErrOrganisationForbidden
+	}
+
+	org, err := models.GetOrganisation(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := randomToken(32)
+	invitation := &models.OrganisationInvitation{
+		InvitationId: uuid.New(),
+		OrgId:        orgID,
+		Email:        email,
+		Roles:        pq.StringArray(roles),
+		TokenHash:    hashSecret(token),
+		ExpiresAt:    time.Now().Add(s.cfg.Organisations.InviteTTL),
+	}
+	if actor.UserID != uuid.Nil {
+		invitation.InvitedBy = uuid.NullUUID{UUID: actor.UserID, Valid: true}
+	}
+
+	if err = models.CreateOrganisationInvitation(ctx, invitation); err != nil {
+		l.Error("ошибка создания приглашения в организацию", logger.Err(err))
+		return nil, err
+	}
+
+	s.sendMail(l, mailer.Message{
+		To:      email,
+		Subject: "Приглашение в " + org.Name,
+		Body: fmt.Sprintf("Вас пригласили в «%s». Чтобы принять приглашение, войдите или зарегистрируйтесь "+
+			"с этим адресом и перейдите по ссылке:\n\n%s\n\nСсылка действует %d ч.\n",
+			org.Name, s.cfg.Organisations.InviteURL+"?token="+url.QueryEscape(token),
+			int(s.cfg.Organisations.InviteTTL.Hours())),
+	})
+
+	l.Info("приглашение в организацию создано",
+		slog.String("invitation_id", invitation.InvitationId.String()),
+		slog.String("email", s.HashEmail(email)),
+	)
+	return invitation, nil
+}
+
+// RevokeOrganisationInvitation отзывает непринятое приглашение
+func (s *Service) RevokeOrganisationInvitation(ctx context.Context, actor OrgActor, orgID, invitationID uuid.UUID) error {
+	l := s.log.With(slog.String("op", "revoke_organisation_invitation"), slog.String("org_id", orgID.String()))
+
+	access, err := s.orgAccess(ctx, actor, orgID)
+	if err != nil {
+		return err
+	}
+	if !access.manage {
+		return ErrOrganisationForbidden
+	}
+
+	if err = models.RevokeOrganisationInvitation(ctx, orgID, invitationID); err != nil {
+		l.Debug("ошибка отзыва приглашения в организацию", logger.Err(err))
+		return err
+	}
+
+	l.Info("приглашение в организацию отозвано", slog.String("invitation_id", invitationID.String()))
+	return nil
+}
+
+// AcceptOrganisationInvitation добавляет пользователя в организацию по токену из письма.
+// Приглашение принимается только аккаунтом с тем email, на который оно отправлено
+func (s *Service) AcceptOrganisationInvitation(ctx context.Context, userID uuid.UUID, token string) (*models.OrganisationMembership, error) {
+	l := s.log.With(slog.String("op", "accept_organisation_invitation"), slog.String("user_id", userID.String()))
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Debug("пользователь не найден", logger.Err(err))
+		return nil, err
+	}
+
+	membership, err := models.AcceptOrganisationInvitation(ctx, hashSecret(token), userID, user.Email)
+	if err != nil {
+		if errors.Is(err, models.ErrOrganisationInvitationNotFound) {
+			l.Debug("приглашение недействительно")
+			return nil, ErrOrganisationInvitationInvalid
+		}
+		l.Error("ошибка принятия приглашения в организацию", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("приглашение в организацию принято", slog.String("org_id", membership.OrgId.String()))
+	return membership, nil
+}
+
+// UpdateOrganisationMember заменяет роли участника. Назначать и снимать роль owner
+// может только владелец или admin
+func (s *Service) UpdateOrganisationMember(
+	ctx context.Context,
+	actor OrgActor,
+	orgID, userID uuid.UUID,
+	roles []string,
+) (*models.OrganisationMember, error) {
+	l := s.log.With(slog.String("op", "update_organisation_member"), slog.String("org_id", orgID.String()))
+
+	access, err := s.orgAccess(ctx, actor, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !access.manage {
+		return nil, ErrOrganisationForbidden
+	}
+	if roles, err = s.checkOrgRoles(roles); err != nil {
+		return nil, err
+	}
+	if !access.owner {
+		current, err := models.GetOrganisationRoles(ctx, orgID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(current, models.OrgRoleOwner) != slices.Contains(roles, models.OrgRoleOwner) {
+			return nil, ErrOrganisationForbidden
+		}
+	}
+
+	member, err := models.SetOrganisationMemberRoles(ctx, orgID, userID, roles)
+	if err != nil {
+		if errs.KindOf(err) == errs.KindInternal {
+			l.Error("ошибка изменения ролей участника", logger.Err(err))
+		}
+		return nil, err
+	}
+
+	l.Info("роли участника изменены", slog.String("user_id", userID.String()), slog.Any("roles", roles))
+	return member, nil
+}
+
+// RemoveOrganisationMember исключает участника. Участник может выйти из организации сам;
+// исключить владельца может только владелец или admin
+func (s *Service) RemoveOrganisationMember(ctx context.Context, actor OrgActor, orgID, userID uuid.UUID) error {
+	l := s.log.With(slog.String("op", "remove_organisation_member"), slog.String("org_id", orgID.String()))
+
+	if actor.UserID != userID || actor.Admin {
+		access, err := s.orgAccess(ctx, actor, orgID)
+		if err != nil {
+			return err
+		}
+		if !access.manage {
+			return ErrOrganisationForbidden
+		}
+		if !access.owner {
+			current, err := models.GetOrganisationRoles(ctx, orgID, userID)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(current, models.OrgRoleOwner) {
+				return ErrOrganisationForbidden
+			}
+		}
+	}
+
+	if err := models.RemoveOrganisationMember(ctx, orgID, userID); err != nil {
+		if errs.KindOf(err) == errs.KindInternal {
+			l.Error("ошибка исключения участника", logger.Err(err))
+		}
+		return err
+	}
+
+	l.Info("участник исключен", slog.String("user_id", userID.String()))
+	return nil
+}
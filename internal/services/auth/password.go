@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/internal/services/validator"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/golang-jwt/jwt/v5"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// passwordChangeAudience аудитория токена, выдаваемого при входе с истекшим паролем.
+	// Сервисы проверяют aud "chef-app-services", поэтому такой токен годится только для ChangePassword
+	passwordChangeAudience = "auth-service/password-change"
+	passwordChangeTokenTTL = 15 * time.Minute
+)
+
+// passwordExpired истек ли срок действия пароля по политике
+func (s *Service) passwordExpired(user *models.User) bool {
+	maxAge := s.cfg.PasswordPolicy.MaxAge
+	return maxAge > 0 && user.PasswordHash != models.UnusablePasswordHash &&
+		time.Since(user.PasswordChangedAt) > maxAge
+}
+
+// issueLoginToken выпускает токен при любом способе входа. С истекшим паролем выдается только
+// токен для смены пароля, иначе вход по ссылке или через провайдера обходил бы политику
+func (s *Service) issueLoginToken(ctx context.Context, l *slog.Logger, user *models.User) (*models.AuthResponse, error) {
+	if s.passwordExpired(user) {
+		token, err := s.CreatePasswordChangeToken(user)
+		if err != nil {
+			l.Error("ошибка при создании токена смены пароля", logger.Err(err))
+			return nil, errs.Internal("failed to create token", err)
+		}
+		l.Info("вход с истекшим паролем, требуется смена пароля")
+		return &models.AuthResponse{JWTToken: token, PasswordExpired: true}, nil
+	}
+
+	token, err := s.CreateToken(ctx, user)
+	if err != nil {
+		l.Error("ошибка при создании токена", logger.Err(err))
+		return nil, errs.Internal("failed to create token", err)
+	}
+	return &models.AuthResponse{JWTToken: token}, nil
+}
+
+// CreatePasswordChangeToken создает короткоживущий токен, которым можно только сменить пароль
+func (s *Service) CreatePasswordChangeToken(user *models.User) (string, error) {
+	now := time.Now()
+	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":      user.UserId,
+		"sub_type": subTypeUser,
+		"email":    user.Email,
+		"iss":      "auth-service",
+		"aud":      passwordChangeAudience,
+		"ver":      user.TokenVersion,
+		"tid":      user.TenantId.String(),
+		"exp":      now.Add(passwordChangeTokenTTL).Unix(),
+		"iat":      now.Unix(),
+	})
+
+	return claims.SignedString([]byte(s.cfg.Cert.Jwt))
+}
+
+// VerifyPasswordChangeToken проверяет токен для ChangePassword: обычный токен пользователя
+// или токен, выданный при входе с истекшим паролем. После смены пароля версия токенов
+// увеличивается, и ограниченный токен перестает действовать
+func (s *Service) VerifyPasswordChangeToken(ctx context.Context, tokenString string) (*models.TokenInfo, error) {
+	if strings.HasPrefix(tokenString, apiKeyPrefix) {
+		return s.VerifyToken(ctx, tokenString)
+	}
+
+	claims, err := s.parseJWT(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if aud, _ := claims["aud"].(string); aud != passwordChangeAudience {
+		return s.VerifyToken(ctx, tokenString)
+	}
+
+	if iss, _ := claims["iss"].(string); iss != "auth-service" {
+		return nil, ErrInvalidToken.WithMessage("недействительный издатель токена")
+	}
+	userID, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	if userID == "" || email == "" {
+		return nil, ErrInvalidToken.WithMessage("недействительный токен смены пароля")
+	}
+	ctx, tenantID, err := tokenTenant(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkTokenState(ctx, userID, claims["ver"]); err != nil {
+		return nil, err
+	}
+
+	return &models.TokenInfo{UserID: userID, Email: email, IsValid: true, TenantID: tenantID.String()}, nil
+}
+
+// checkPasswordReuse запрещает пароль, совпадающий с одним из HistoryDepth последних;
+// текущий пароль считается первым из них
+func (s *Service) checkPasswordReuse(ctx context.Context, user *models.User, password string) error {
+	depth := s.cfg.PasswordPolicy.HistoryDepth
+	if depth <= 0 {
+		return nil
+	}
+
+	hashes := []string{user.PasswordHash}
+	if depth > 1 {
+		history, err := models.GetPasswordHistory(ctx, user.UserId, depth-1)
+		if err != nil {
+			return err
+		}
+		hashes = append(hashes, history...)
+	}
+
+	for _, hash := range hashes {
+		reused, err := s.CheckPasswordHash(ctx, password, hash)
+		if err != nil {
+			return hashingError(err)
+		}
+		if reused {
+			return passwordReusedError(ctx, depth)
+		}
+	}
+
+	return nil
+}
+
+// passwordReusedError ошибка валидации поля new_password в формате validator
+func passwordReusedError(ctx context.Context, depth int) error {
+	return validator.FieldError(ctx, "new_password", "PASSWORD_REUSED", depth).
+		With("history_depth", strconv.Itoa(depth))
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+// GetProfile возвращает профиль пользователя вместе с ролями
+func (s *Service) GetProfile(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "get_profile"))
+
+	user, err := models.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Debug("ошибка при получении пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	user.Roles, err = models.GetUserRoles(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении ролей пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdateUsername меняет username пользователя
+func (s *Service) UpdateUsername(ctx context.Context, userID uuid.UUID, username string) (*models.User, error) {
+	l := s.log.With(slog.String("user_id", userID.String()), slog.String("op", "update_username"))
+
+	user, err := models.UpdateUsername(ctx, userID, username)
+	if err != nil {
+		l.Debug("ошибка обновления имени пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	user.Roles, err = models.GetUserRoles(ctx, userID)
+	if err != nil {
+		l.Error("ошибка при получении ролей пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("имя пользователя изменено")
+	return user, nil
+}
@@ -3,16 +3,52 @@ package auth
 import (
 	"auth-service/config"
 	"auth-service/internal/models"
+	"auth-service/internal/services/federation"
+	"auth-service/internal/services/mailer"
+	"auth-service/pkg/emailnorm"
 	"auth-service/pkg/logger"
+	"auth-service/pkg/passhash"
+	"auth-service/pkg/ratelimit"
 	pgClient "auth-service/pkg/storage/pg-client"
+	"auth-service/pkg/workpool"
+	"crypto/rsa"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 type Service struct {
-	log *slog.Logger
-	cfg *config.Config
+	log     *slog.Logger
+	cfg     *config.Config
+	idKey   *rsa.PrivateKey // ключ подписи OIDC ID токенов, nil если OIDC выключен
+	idKeyID string
+
+	providers *federation.Registry // внешние OIDC провайдеры для входа через соцсети
+	mailer    mailer.Sender
+	emails    *emailnorm.Normalizer // приводит email к виду, в котором он хранится
+
+	hasher    *passhash.Registry // алгоритмы хеширования паролей, целевой задается в конфиге
+	hashPool  *workpool.Pool     // ограничивает число одновременных хеширований
+	dummyHash func() string      // хеш случайного пароля для выравнивания времени ответа при неизвестном email
+
+	importPool        *workpool.Pool // хеширование открытых паролей импорта, отдельно от входов
+	importConcurrency int
+
+	magicLinkLimiter *ratelimit.Limiter // лимит запросов ссылки входа на email
+
+	registrationDomains []string // домены режима domain_restricted в нижнем регистре
+
+	tenants *tenantCache        // арендаторы по ID и slug из запросов
+	clients *serviceClientCache // неотозванные машинные клиенты для проверки их токенов
+
+	orgRoles []string // роли, которые можно назначить в организации, включая owner
+
+	stop chan struct{} // останавливает фоновые задачи
+	wg   sync.WaitGroup
 }
 
 func New(log *slog.Logger, cfg *config.Config) *Service {
@@ -27,14 +63,90 @@ func New(log *slog.Logger, cfg *config.Config) *Service {
 	)
 	models.SetDB(db)
 
-	return &Service{log: log.With("proc", "auth"), cfg: cfg}
+	s := &Service{
+		log:       log.With("proc", "auth"),
+		cfg:       cfg,
+		providers: federation.NewRegistry(cfg.IdentityProviders, nil),
+		emails:    emailnorm.New(cfg.Email.ProviderRules),
+
+		magicLinkLimiter: ratelimit.New(cfg.MagicLink.RateLimit, cfg.MagicLink.RateWindow),
+		tenants:          &tenantCache{entries: make(map[string]tenantEntry)},
+		clients:          &serviceClientCache{entries: make(map[string]time.Time)},
+
+		stop: make(chan struct{}),
+	}
+
+	s.mailer, err = mailer.New(log, cfg.Env, cfg.Mail)
+	if err != nil {
+		log.Warn("SMTP is not configured. Check config.yaml!", slog.String("env", cfg.Env))
+		os.Exit(2)
+	}
+
+	s.hasher, err = newPasswordHasher(cfg.PasswordHashing)
+	if err != nil {
+		log.Warn("Invalid password hashing settings. Check config.yaml!", logger.Err(err))
+		os.Exit(2)
+	}
+	concurrency := cfg.PasswordHashing.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultHashConcurrency(cfg.PasswordHashing)
+	}
+	s.hashPool = workpool.New("password_hashing", concurrency, cfg.PasswordHashing.QueueDepth)
+	s.importConcurrency = max(1, concurrency/4)
+	s.importPool = workpool.New("import_password_hashing", s.importConcurrency, importHashQueue)
+
+	if !slices.Contains(registrationModes, cfg.Registration.Mode) {
+		log.Warn("Unknown registration mode. Check config.yaml!", slog.String("mode", cfg.Registration.Mode))
+		os.Exit(2)
+	}
+	for _, domain := range cfg.Registration.Domains {
+		s.registrationDomains = append(s.registrationDomains, strings.ToLower(strings.TrimSpace(domain)))
+	}
+
+	s.orgRoles = []string{models.OrgRoleOwner}
+	for _, role := range cfg.Organisations.Roles {
+		role = strings.TrimSpace(role)
+		if role == "" || slices.Contains(s.orgRoles, role) {
+			log.Warn("Invalid organisation roles. Check config.yaml!", slog.String("role", role))
+			os.Exit(2)
+		}
+		s.orgRoles = append(s.orgRoles, role)
+	}
+
+	s.dummyHash = sync.OnceValue(func() string {
+		hash, _ := s.hasher.Hash(randomToken(16))
+		return hash
+	})
+
+	// ключ OIDC необязателен: без него ID токены и discovery не выдаются
+	if cfg.OIDC.SigningKeyFile != "" {
+		// Без страницы входа authorization endpoint некуда перенаправить, а discovery его объявляет
+		if cfg.OIDC.LoginURL == "" {
+			log.Warn("OIDC login_url is required with signing_key_file. Check config.yaml!")
+			os.Exit(2)
+		}
+		s.idKey, s.idKeyID, err = loadSigningKey(cfg.OIDC.SigningKeyFile)
+		if err != nil {
+			log.Warn("Failed to load OIDC signing key. Check config.yaml!", logger.Err(err))
+			os.Exit(2)
+		}
+		log.Info("OIDC provider ready", slog.String("issuer", cfg.OIDC.Issuer))
+	}
+
+	return s
 }
 
 // Start запускает службы
-func (s *Service) Start() {}
+func (s *Service) Start() {
+	s.wg.Add(1)
+	go s.purgeLoop()
+}
 
 // Close закрывает службы
 func (s *Service) Close() {
+	close(s.stop)
+	s.wg.Wait()
+
 	if models.GetDB() != nil {
 		err := models.CloseDB()
 		if err != nil {
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// accountStatusErrors причины отказа во входе по статусу аккаунта
+var accountStatusErrors = map[string]*errs.Error{
+	models.UserStatusSuspended:       ErrAccountSuspended,
+	models.UserStatusDisabled:        ErrAccountDisabled,
+	models.UserStatusPendingDeletion: ErrAccountPendingDeletion,
+}
+
+// moderationRanks старшинство ролей при изменении статуса аккаунта; остальные роли имеют ранг 0
+var moderationRanks = map[string]int{
+	"moderator": 1,
+	"admin":     2,
+}
+
+// Moderator вызывающий в операциях со статусом аккаунта. Модератор может менять статус только
+// пользователей с младшей ролью; администратор - любых, кроме себя
+type Moderator struct {
+	UserID uuid.UUID
+	Roles  []string
+}
+
+// moderationRank старшая из ролей по moderationRanks
+func moderationRank(roles []string) int {
+	rank := 0
+	for _, role := range roles {
+		rank = max(rank, moderationRanks[role])
+	}
+	return rank
+}
+
+// checkModeration запрещает модератору действовать на пользователя с такой же или более высокой ролью
+func (s *Service) checkModeration(ctx context.Context, moderator Moderator, userID uuid.UUID) error {
+	callerRank := moderationRank(moderator.Roles)
+	if callerRank >= moderationRanks["admin"] {
+		return nil
+	}
+
+	roles, err := models.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if moderationRank(roles) >= callerRank {
+		return ErrModerationForbidden
+	}
+	return nil
+}
+
+// SuspendUser блокирует аккаунт. Без until блокировка бессрочная, disable отключает аккаунт совсем
+func (s *Service) SuspendUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	reason string,
+	until *time.Time,
+	disable bool,
+	moderator Moderator,
+) (*models.User, error) {
+	l := s.log.With(
+		slog.String("user_id", userID.String()),
+		slog.String("moderator_id", moderator.UserID.String()),
+		slog.String("op", "suspend_user"),
+	)
+
+	newStatus := models.UserStatusSuspended
+	if disable {
+		newStatus = models.UserStatusDisabled
+		until = nil
+	}
+	if until != nil && !until.After(time.Now()) {
+		return nil, ErrExpiryInPast.WithMessage("время окончания блокировки уже прошло")
+	}
+
+	if err := s.checkModeration(ctx, moderator, userID); err != nil {
+		l.Debug("блокировка запрещена", logger.Err(err))
+		return nil, err
+	}
+
+	user, err := models.SetUserStatus(ctx, userID, newStatus, reason, until, moderator.UserID)
+	if err != nil {
+		l.Debug("ошибка блокировки пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("пользователь заблокирован", slog.String("status", newStatus), slog.String("reason", reason))
+	return user, nil
+}
+
+// ReactivateUser снимает блокировку и отменяет запрошенное удаление аккаунта
+func (s *Service) ReactivateUser(ctx context.Context, userID uuid.UUID, reason string, moderator Moderator) (*models.User, error) {
+	l := s.log.With(
+		slog.String("user_id", userID.String()),
+		slog.String("moderator_id", moderator.UserID.String()),
+		slog.String("op", "reactivate_user"),
+	)
+
+	if err := s.checkModeration(ctx, moderator, userID); err != nil {
+		l.Debug("разблокировка запрещена", logger.Err(err))
+		return nil, err
+	}
+
+	user, err := models.SetUserStatus(ctx, userID, models.UserStatusActive, reason, nil, moderator.UserID)
+	if err != nil {
+		l.Debug("ошибка разблокировки пользователя", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("пользователь разблокирован", slog.String("reason", reason))
+	return user, nil
+}
+
+// checkUserActive запрещает вход в неактивный аккаунт. Ошибка содержит ErrorInfo с причиной,
+// чтобы клиент мог показать пользователю, почему вход невозможен
+func (s *Service) checkUserActive(user *models.User) error {
+	if user.IsActive() {
+		return nil
+	}
+
+	err, ok := accountStatusErrors[user.Status]
+	if !ok {
+		err = ErrAccountSuspended
+	}
+	err = err.With("status", user.Status)
+	if user.SuspendedUntil.Valid {
+		err = err.With("until", user.SuspendedUntil.Time.UTC().Format(time.RFC3339))
+	}
+	if user.StatusReason.Valid {
+		err = err.With("reason", user.StatusReason.String)
+	}
+	return err
+}
+
+// checkTokenState сверяет claim ver с текущей версией токенов и проверяет статус пользователя
+func (s *Service) checkTokenState(ctx context.Context, userID string, claim any) error {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return ErrInvalidToken.WithMessage("недействительный ID пользователя в токене")
+	}
+
+	// Токены, выпущенные до появления версий, не содержат ver и соответствуют версии 0
+	var version int
+	if v, ok := claim.(float64); ok {
+		version = int(v)
+	}
+
+	state, err := models.GetUserTokenState(ctx, id)
+	if err != nil {
+		if errors.Is(err, models.ErrUserNotFound) {
+			return ErrTokenRevoked
+		}
+		return err
+	}
+	if version != state.TokenVersion {
+		return ErrTokenRevoked
+	}
+
+	user := models.User{Status: state.Status, SuspendedUntil: state.SuspendedUntil}
+	if !user.IsActive() {
+		return ErrTokenAccountBlocked
+	}
+
+	return nil
+}
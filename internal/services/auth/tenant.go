@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/internal/tenant"
+	"context"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tenantCacheTTL сколько помнить соответствие slug и ID арендатора и то, что арендатор не найден;
+// отключенный арендатор и выданные ему токены перестают приниматься не позже чем через это время
+const tenantCacheTTL = time.Minute
+
+// tenantSlugRe допустимый slug арендатора: он передается в metadata и заголовках
+var tenantSlugRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+type tenantEntry struct {
+	id      uuid.UUID
+	err     error // арендатор не найден или отключен
+	expires time.Time
+}
+
+// tenantCache кеш арендаторов по ID или slug из metadata, чтобы не ходить в БД на каждый запрос
+type tenantCache struct {
+	mu      sync.Mutex
+	entries map[string]tenantEntry
+}
+
+// TenantContext определяет арендатора запроса и возвращает контекст, ограниченный им.
+// Арендатор берется из ID или slug (metadata x-tenant, заголовок X-Tenant), иначе из claim tid
+// предъявленного токена или у владельца API ключа. Подпись токена и секрет ключа здесь не проверяются:
+// VerifyToken отклонит токен, если его арендатор не совпадет с арендатором контекста.
+// Без того и другого используется арендатор по умолчанию
+func (s *Service) TenantContext(ctx context.Context, idOrSlug, bearerToken string) (context.Context, error) {
+	if idOrSlug != "" {
+		id, err := s.resolveTenant(ctx, idOrSlug)
+		if err != nil {
+			return ctx, err
+		}
+		return tenant.With(ctx, id), nil
+	}
+
+	// У API ключа нет claims: арендатор берется у владельца ключа, секрет проверит VerifyToken
+	if rest, ok := strings.CutPrefix(bearerToken, apiKeyPrefix); ok {
+		if len(rest) <= apiKeyIDLen {
+			return ctx, nil
+		}
+		key, err := models.GetApiKeyByPrefix(ctx, rest[:apiKeyIDLen])
+		if err != nil {
+			if errors.Is(err, models.ErrApiKeyNotFound) {
+				return ctx, nil
+			}
+			return ctx, err
+		}
+		return tenant.With(ctx, key.TenantId), nil
+	}
+
+	if bearerToken != "" {
+		claims := jwt.MapClaims{}
+		if _, _, err := jwt.NewParser().ParseUnverified(bearerToken, claims); err == nil {
+			if id, ok := claimTenant(claims); ok {
+				return tenant.With(ctx, id), nil
+			}
+		}
+	}
+
+	return ctx, nil
+}
+
+// CreateTenant создает арендатора с ролью по умолчанию и ролями roles
+func (s *Service) CreateTenant(ctx context.Context, slug, name string, roles []string) (*models.Tenant, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if !tenantSlugRe.MatchString(slug) {
+		return nil, ErrInvalidTenantSlug
+	}
+	if _, err := uuid.Parse(slug); err == nil {
+		return nil, ErrInvalidTenantSlug
+	}
+	if name = strings.TrimSpace(name); name == "" {
+		name = slug
+	}
+
+	t := &models.Tenant{TenantId: uuid.New(), Slug: slug, Name: name}
+	if err := models.CreateTenant(ctx, t, roles); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("создан арендатор", slog.String("tenant_id", t.TenantId.String()), slog.String("slug", slug))
+	return t, nil
+}
+
+func (s *Service) resolveTenant(ctx context.Context, idOrSlug string) (uuid.UUID, error) {
+	s.tenants.mu.Lock()
+	entry, ok := s.tenants.entries[idOrSlug]
+	s.tenants.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.id, entry.err
+	}
+
+	entry = tenantEntry{expires: time.Now().Add(tenantCacheTTL)}
+	t, err := models.GetTenant(ctx, idOrSlug)
+	switch {
+	case errors.Is(err, models.ErrTenantNotFound):
+		// Запоминаем и отсутствие: токены отключенного арендатора не должны каждый раз идти в БД
+		s.log.Debug("арендатор не найден", slog.String("tenant", idOrSlug))
+		entry.err = err
+	case err != nil:
+		return uuid.Nil, err
+	default:
+		entry.id = t.TenantId
+	}
+
+	s.tenants.mu.Lock()
+	s.tenants.entries[idOrSlug] = entry
+	s.tenants.mu.Unlock()
+
+	return entry.id, entry.err
+}
+
+// checkTenantActive проверяет, что арендатор токена или API ключа не отключен
+func (s *Service) checkTenantActive(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.resolveTenant(ctx, id.String()); err != nil {
+		if errors.Is(err, models.ErrTenantNotFound) {
+			return ErrTenantDisabled
+		}
+		return err
+	}
+	return nil
+}
+
+// tokenTenant сверяет арендатора токена с арендатором запроса и возвращает контекст,
+// в котором выполняются проверки токена. Токены без tid выпущены до появления арендаторов
+// и принадлежат арендатору по умолчанию
+func tokenTenant(ctx context.Context, claims jwt.MapClaims) (context.Context, uuid.UUID, error) {
+	id, ok := claimTenant(claims)
+	if !ok {
+		if _, present := claims["tid"]; present {
+			return ctx, uuid.Nil, ErrInvalidToken.WithMessage("недействительный арендатор в токене")
+		}
+		id = tenant.Default
+	}
+
+	if requested, explicit := tenant.FromContext(ctx); explicit && requested != id {
+		return ctx, uuid.Nil, ErrTokenTenantMismatch
+	}
+
+	return tenant.With(ctx, id), id, nil
+}
+
+func claimTenant(claims jwt.MapClaims) (uuid.UUID, bool) {
+	raw, _ := claims["tid"].(string)
+	id, err := uuid.Parse(raw)
+	return id, err == nil
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestCheckTenantActive(t *testing.T) {
+	active, disabled := uuid.New(), uuid.New()
+	expires := time.Now().Add(tenantCacheTTL)
+
+	// Кеш заполнен заранее: проверка не должна ходить в БД
+	s := &Service{
+		log: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		tenants: &tenantCache{entries: map[string]tenantEntry{
+			active.String():   {id: active, expires: expires},
+			disabled.String(): {err: models.ErrTenantNotFound, expires: expires},
+		}},
+	}
+
+	if err := s.checkTenantActive(context.Background(), active); err != nil {
+		t.Fatalf("checkTenantActive(активный) = %v", err)
+	}
+	if err := s.checkTenantActive(context.Background(), disabled); !errors.Is(err, ErrTenantDisabled) {
+		t.Fatalf("checkTenantActive(отключенный) = %v, ожидалась ErrTenantDisabled", err)
+	}
+}
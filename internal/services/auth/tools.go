@@ -1,27 +1,150 @@
 package auth
 
 import (
+	"auth-service/config"
+	"auth-service/internal/errs"
 	"auth-service/internal/models"
+	"auth-service/internal/services/mailer"
+	"auth-service/internal/tenant"
+	"auth-service/pkg/logger"
+	"auth-service/pkg/passhash"
+	"auth-service/pkg/workpool"
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/mussyaroslav/libs/helper"
-	"golang.org/x/crypto/bcrypt"
 	"log/slog"
+	"runtime"
+	"slices"
 	"strings"
 	"time"
 )
 
-// HashPassword хэширует пароль
-func (s *Service) HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+const (
+	timeOutSendMail = 30 * time.Second
+	timeOutRehash   = 10 * time.Second
+)
+
+// HashPassword хэширует пароль целевым алгоритмом из конфига в общем пуле хеширования
+func (s *Service) HashPassword(ctx context.Context, password string) (hash string, err error) {
+	if poolErr := s.hashPool.Do(ctx, func() { hash, err = s.hasher.Hash(password) }); poolErr != nil {
+		return "", poolErr
+	}
+	return hash, err
+}
+
+// CheckPasswordHash проверяет пароль по хешу любого известного алгоритма в общем пуле хеширования
+func (s *Service) CheckPasswordHash(ctx context.Context, password, hash string) (bool, error) {
+	ok, _, err := s.verifyPassword(ctx, password, hash)
+	return ok, err
+}
+
+// verifyPassword проверяет пароль и сообщает, нужно ли пересчитать хеш по текущей политике
+func (s *Service) verifyPassword(ctx context.Context, password, hash string) (ok, rehash bool, err error) {
+	err = s.hashPool.Do(ctx, func() { ok, rehash = s.hasher.Verify(password, hash) })
+	return ok, rehash, err
+}
+
+// hashingError переводит ошибку хеширования в ответ клиенту: переполненная очередь -
+// ResourceExhausted, чтобы клиент повторил запрос позже, а не ждал таймаута
+func hashingError(err error) error {
+	switch {
+	case errors.Is(err, workpool.ErrQueueFull):
+		return ErrOverloaded
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return err
+	}
+	return errs.Internal("failed to hash password", err)
+}
+
+// defaultHashConcurrency число одновременных хеширований по умолчанию: половина CPU, чтобы
+// хеширование не забирало процессор у остальных запросов. Один хеш argon2id занимает
+// Parallelism потоков, поэтому для него число делится на Parallelism
+func defaultHashConcurrency(cfg config.PasswordHashing) int {
+	n := runtime.NumCPU() / 2
+	if cfg.Algorithm == "argon2id" && cfg.Argon2id.Parallelism > 1 {
+		n /= int(cfg.Argon2id.Parallelism)
+	}
+	return max(1, n)
+}
+
+// newPasswordHasher собирает реестр алгоритмов: целевой из конфига хеширует новые пароли,
+// остальные распознаются для проверки старых и импортированных хешей
+func newPasswordHasher(cfg config.PasswordHashing) (*passhash.Registry, error) {
+	hashers := []passhash.Hasher{
+		passhash.Bcrypt{Cost: cfg.BcryptCost},
+		passhash.Argon2id{
+			Memory:      cfg.Argon2id.Memory,
+			Time:        cfg.Argon2id.Time,
+			Parallelism: cfg.Argon2id.Parallelism,
+			SaltLength:  passhash.DefaultArgon2id.SaltLength,
+			KeyLength:   passhash.DefaultArgon2id.KeyLength,
+		},
+		passhash.Scrypt{LogN: cfg.Scrypt.LogN, R: cfg.Scrypt.R, P: cfg.Scrypt.P, SaltLength: 16, KeyLength: 32},
+		passhash.SaltedSHA256{},
+		passhash.PHPass{},
+	}
+
+	for i, h := range hashers {
+		if h.Name() == cfg.Algorithm {
+			_, err := h.Hash("") // проверка параметров до первого пароля
+			if err != nil {
+				return nil, err
+			}
+			known := append(slices.Clone(hashers[:i]), hashers[i+1:]...)
+			return passhash.NewRegistry(h, known...), nil
+		}
+	}
+	return nil, passhash.ErrUnknownAlgorithm
 }
 
-func (s *Service) CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// rehashPassword пересчитывает хеш пароля по текущей политике после успешного входа.
+// Выполняется в фоне, чтобы не удваивать время входа; ошибки только логируются
+func (s *Service) rehashPassword(l *slog.Logger, user *models.User, password string) {
+	userID, tenantID, oldHash := user.UserId, user.TenantId, user.PasswordHash
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		// Запрос уже завершен, поэтому арендатор переносится в новый контекст явно
+		ctx, cancel := context.WithTimeout(tenant.With(context.Background(), tenantID), timeOutRehash)
+		defer cancel()
+
+		// При перегрузке пул откажет, и хеш пересчитается при одном из следующих входов
+		hash, err := s.HashPassword(ctx, password)
+		if err != nil {
+			l.Warn("хеш пароля не пересчитан", logger.Err(err))
+			return
+		}
+
+		if err = models.RehashPassword(ctx, userID, oldHash, hash); err != nil {
+			l.Error("ошибка сохранения нового хеша пароля", logger.Err(err))
+			return
+		}
+		l.Info("хеш пароля обновлен по текущей политике", slog.String("algorithm", s.cfg.PasswordHashing.Algorithm))
+	}()
+}
+
+// NormalizeEmail приводит email к виду, в котором он хранится и на который уходят письма. Адрес,
+// который нельзя нормализовать, возвращается обрезанным и в нижнем регистре - его отклонит валидация
+func (s *Service) NormalizeEmail(email string) string {
+	normalized, err := s.emails.Normalize(email)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(email))
+	}
+	return normalized
+}
+
+// CanonicalEmail приводит email к каноническому виду для поиска и проверки уникальности
+// (с правилами провайдеров, если они включены)
+func (s *Service) CanonicalEmail(email string) string {
+	canonical, err := s.emails.Canonical(email)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(email))
+	}
+	return canonical
 }
 
 // HashEmail возвращает безопасный хеш email для логирования
@@ -53,21 +176,34 @@ func (s *Service) HashEmail(email string) string {
 	return prefix + "@" + encodedDomain
 }
 
-// CreateToken создает jwt token
-func (s *Service) CreateToken(user *models.User) (string, error) {
-	userRoles, err := models.GetUserRoles(context.Background(), user.UserId)
+// userTokenTTL время жизни токена пользователя
+const userTokenTTL = 30 * 24 * time.Hour
+
+// CreateToken создает jwt token. Роли читаются у арендатора пользователя, а не запроса
+func (s *Service) CreateToken(ctx context.Context, user *models.User) (string, error) {
+	return s.createToken(ctx, user, time.Now())
+}
+
+// createToken создает jwt token для входа, выполненного в authTime. Время входа отличается от
+// времени выдачи, когда токен выдается по коду авторизации
+func (s *Service) createToken(ctx context.Context, user *models.User, authTime time.Time) (string, error) {
+	userRoles, err := models.GetUserRoles(tenant.With(ctx, user.TenantId), user.UserId)
 	if err != nil {
 		return "", err
 	}
 
 	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub":   user.UserId,
-		"email": user.Email,
-		"iss":   "auth-service",
-		"aud":   "chef-app-services",
-		"roles": userRoles,
-		"exp":   time.Now().Add(30 * 24 * time.Hour).Unix(),
-		"iat":   time.Now().Unix(),
+		"sub":       user.UserId,
+		"sub_type":  subTypeUser,
+		"email":     user.Email,
+		"iss":       "auth-service",
+		"aud":       "chef-app-services",
+		"roles":     userRoles,
+		"ver":       user.TokenVersion,
+		"tid":       user.TenantId.String(),
+		"exp":       time.Now().Add(userTokenTTL).Unix(),
+		"iat":       time.Now().Unix(),
+		"auth_time": authTime.Unix(),
 	})
 
 	// Sign the token with the secret key
@@ -83,3 +219,14 @@ func (s *Service) CreateToken(user *models.User) (string, error) {
 
 	return tokenString, nil
 }
+
+// sendMail отправляет письмо в фоне, ошибки только логируются
+func (s *Service) sendMail(l *slog.Logger, msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), timeOutSendMail)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			l.Error("ошибка отправки письма", slog.String("subject", msg.Subject), logger.Err(err))
+		}
+	}()
+}
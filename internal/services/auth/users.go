@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+const (
+	defaultUsersPageSize = 50
+	maxUsersPageSize     = 500
+)
+
+// ListUsers возвращает страницу пользователей для администратора вместе с их ролями
+func (s *Service) ListUsers(ctx context.Context, filter models.ListUsersFilter) (*models.ListUsersPage, error) {
+	l := s.log.With(slog.String("op", "list_users"))
+
+	if filter.PageSize <= 0 {
+		filter.PageSize = defaultUsersPageSize
+	}
+	filter.PageSize = min(filter.PageSize, maxUsersPageSize)
+
+	page, err := models.ListUsers(ctx, filter)
+	if err != nil {
+		l.Debug("ошибка получения списка пользователей", logger.Err(err))
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(page.Users))
+	for i, user := range page.Users {
+		ids[i] = user.UserId
+	}
+	roles, err := models.GetUsersRoles(ctx, ids)
+	if err != nil {
+		l.Error("ошибка при получении ролей пользователей", logger.Err(err))
+		return nil, err
+	}
+	for _, user := range page.Users {
+		user.Roles = roles[user.UserId]
+	}
+
+	return page, nil
+}
+
+// recordLogin запоминает время входа; ошибка не должна мешать самому входу
+func (s *Service) recordLogin(ctx context.Context, l *slog.Logger, userID uuid.UUID) {
+	if err := models.TouchLastLogin(ctx, userID); err != nil {
+		l.Warn("не удалось обновить время входа", logger.Err(err))
+	}
+}
@@ -0,0 +1,95 @@
+package breach
+
+import (
+	"auth-service/config"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Источники базы утекших паролей
+const (
+	SourceNone     = ""
+	SourceDir      = "dir"      // Каталог файлов-диапазонов HIBP: <префикс>.txt со строками SUFFIX:COUNT
+	SourceFile     = "file"     // Один отсортированный файл HIBP со строками HASH:COUNT, читается через mmap
+	SourcePostgres = "postgres" // Таблица auth.breached_password_hashes
+)
+
+// Source отдает диапазон утекших SHA-1 хешей по первым 5 hex символам (k-anonymity).
+// Полный хеш пароля за пределы Checker не передается, совпадение ищется локально
+type Source interface {
+	// Range возвращает 35-символьные суффиксы хешей диапазона (верхний регистр) и число утечек
+	Range(ctx context.Context, prefix string) (map[string]int, error)
+	Close() error
+}
+
+// Checker проверяет пароли по базе утечек
+type Checker struct {
+	source   Source
+	minCount int
+}
+
+// New создает проверку по источнику из конфига. Без источника возвращает nil:
+// методы nil Checker считают любой пароль не найденным
+func New(cfg config.BreachedPasswords) (*Checker, error) {
+	var (
+		source Source
+		err    error
+	)
+	switch cfg.Source {
+	case SourceNone:
+		return nil, nil
+	case SourceDir:
+		source, err = NewDirSource(cfg.Path)
+	case SourceFile:
+		source, err = NewFileSource(cfg.Path)
+	case SourcePostgres:
+		source = NewPostgresSource()
+	default:
+		return nil, fmt.Errorf("неизвестный источник базы утечек: %s", cfg.Source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checker{source: source, minCount: max(cfg.MinCount, 1)}, nil
+}
+
+// Breached сообщает, встречался ли пароль в утечках не реже minCount раз
+func (c *Checker) Breached(ctx context.Context, password string) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	hashes, err := c.source.Range(ctx, hash[:5])
+	if err != nil {
+		return false, err
+	}
+	return hashes[hash[5:]] >= c.minCount, nil
+}
+
+// Close освобождает источник
+func (c *Checker) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.source.Close()
+}
+
+// parseLine разбирает строку "HASH:COUNT"; строки без счетчика считаются одной утечкой
+func parseLine(line string) (hash string, count int) {
+	line = strings.TrimRight(line, "\r")
+	hash, countStr, ok := strings.Cut(line, ":")
+	if !ok {
+		return strings.ToUpper(hash), 1
+	}
+	if _, err := fmt.Sscanf(countStr, "%d", &count); err != nil || count < 1 {
+		count = 1
+	}
+	return strings.ToUpper(hash), count
+}
@@ -0,0 +1,52 @@
+package breach
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsData string
+
+// commonPasswords самые частые пароли в нижнем регистре
+var commonPasswords = func() map[string]struct{} {
+	m := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordsData, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			m[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return m
+}()
+
+// IsCommon сообщает, что пароль из списка частых без учета регистра, в том числе
+// с дописанными в конце цифрами и символами ("Password123!" - это "password")
+func IsCommon(password string) bool {
+	p := strings.ToLower(password)
+	if _, ok := commonPasswords[p]; ok {
+		return true
+	}
+
+	base := strings.TrimRightFunc(p, func(r rune) bool { return !unicode.IsLetter(r) })
+	if base == "" || base == p {
+		return false
+	}
+	_, ok := commonPasswords[base]
+	return ok
+}
+
+// ContainsEmailLocalPart сообщает, что пароль содержит часть email до '@' (без +метки).
+// Короткие имена не проверяются, иначе совпадения будут случайными
+func ContainsEmailLocalPart(password, email string) bool {
+	local, _, ok := strings.Cut(email, "@")
+	if !ok {
+		return false
+	}
+	local, _, _ = strings.Cut(local, "+")
+	local = strings.ToLower(strings.TrimSpace(local))
+	if len([]rune(local)) < 3 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(password), local)
+}
@@ -0,0 +1,50 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DirSource каталог файлов-диапазонов, как их сохраняет PwnedPasswordsDownloader:
+// 00000.txt ... FFFFF.txt, в каждом строки SUFFIX:COUNT
+type DirSource struct {
+	dir string
+}
+
+func NewDirSource(dir string) (*DirSource, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s не является каталогом", dir)
+	}
+	return &DirSource{dir: dir}, nil
+}
+
+func (d *DirSource) Range(_ context.Context, prefix string) (map[string]int, error) {
+	f, err := os.Open(filepath.Join(d.dir, prefix+".txt"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil // Диапазон пуст
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	hashes := make(map[string]int)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		suffix, count := parseLine(sc.Text())
+		if suffix != "" {
+			hashes[suffix] = count
+		}
+	}
+	return hashes, sc.Err()
+}
+
+func (d *DirSource) Close() error { return nil }
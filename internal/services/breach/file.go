@@ -0,0 +1,76 @@
+package breach
+
+import (
+	"bytes"
+	"context"
+	"os"
+)
+
+// FileSource один файл HASH:COUNT, отсортированный по хешу (вывод PwnedPasswordsDownloader
+// в режиме single). Файл отображается в память, диапазон ищется двоичным поиском по строкам,
+// поэтому память процесса не растет, а горячие страницы держит кеш ОС
+type FileSource struct {
+	data  []byte
+	unmap func() error
+}
+
+func NewFileSource(path string) (*FileSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, unmap, err := mapFile(f)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSource{data: data, unmap: unmap}, nil
+}
+
+func (s *FileSource) Range(_ context.Context, prefix string) (map[string]int, error) {
+	p := []byte(prefix)
+
+	// Первая строка, префикс которой не меньше искомого
+	lo, hi := 0, len(s.data)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		start := s.lineStart(mid)
+		if bytes.Compare(s.linePrefix(start), p) < 0 {
+			lo = s.lineEnd(start) + 1
+		} else {
+			hi = start
+		}
+	}
+
+	hashes := make(map[string]int)
+	for start := lo; start < len(s.data); start = s.lineEnd(start) + 1 {
+		if !bytes.Equal(s.linePrefix(start), p) {
+			break
+		}
+		hash, count := parseLine(string(s.data[start:s.lineEnd(start)]))
+		if len(hash) > len(prefix) {
+			hashes[hash[len(prefix):]] = count
+		}
+	}
+	return hashes, nil
+}
+
+func (s *FileSource) Close() error {
+	return s.unmap()
+}
+
+func (s *FileSource) lineStart(i int) int {
+	return bytes.LastIndexByte(s.data[:i], '\n') + 1
+}
+
+func (s *FileSource) lineEnd(start int) int {
+	if i := bytes.IndexByte(s.data[start:], '\n'); i >= 0 {
+		return start + i
+	}
+	return len(s.data)
+}
+
+func (s *FileSource) linePrefix(start int) []byte {
+	return bytes.ToUpper(s.data[start:min(start+5, s.lineEnd(start))])
+}
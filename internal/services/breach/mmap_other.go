@@ -0,0 +1,17 @@
+//go:build !unix
+
+package breach
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile читает файл в память целиком там, где mmap недоступен
+func mapFile(f *os.File) ([]byte, func() error, error) {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,19 @@
+package breach
+
+import (
+	"auth-service/internal/models"
+	"context"
+)
+
+// PostgresSource таблица auth.breached_password_hashes (migrations/012_breached_passwords.sql)
+type PostgresSource struct{}
+
+func NewPostgresSource() *PostgresSource {
+	return &PostgresSource{}
+}
+
+func (PostgresSource) Range(ctx context.Context, prefix string) (map[string]int, error) {
+	return models.GetBreachedHashRange(ctx, prefix)
+}
+
+func (PostgresSource) Close() error { return nil }
@@ -0,0 +1,116 @@
+package challenge
+
+import (
+	"auth-service/config"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Адреса siteverify по умолчанию
+var captchaVerifyURLs = map[string]string{
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// Captcha проверяет ответ виджета через siteverify. У hCaptcha и reCAPTCHA одинаковый
+// протокол: POST формы secret, response, remoteip и JSON с полем success
+type Captcha struct {
+	secret    string
+	verifyURL string
+	minScore  float64
+	client    *http.Client
+}
+
+type siteverifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score"` // reCAPTCHA v3 и hCaptcha Enterprise
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// NewCaptcha создает проверку капчи. Если client не задан, используется клиент с таймаутом из конфигурации
+func NewCaptcha(cfg config.Captcha, client *http.Client) (*Captcha, error) {
+	verifyURL := cfg.VerifyURL
+	if verifyURL == "" {
+		var ok bool
+		if verifyURL, ok = captchaVerifyURLs[cfg.Provider]; !ok {
+			return nil, fmt.Errorf("неизвестный провайдер капчи %q", cfg.Provider)
+		}
+	}
+	if cfg.Secret == "" {
+		return nil, fmt.Errorf("не задан секретный ключ капчи")
+	}
+
+	if client == nil {
+		timeout := cfg.Timeout
+		if timeout <= 0 {
+			timeout = 5 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &Captcha{
+		secret:    cfg.Secret,
+		verifyURL: verifyURL,
+		minScore:  cfg.MinScore,
+		client:    client,
+	}, nil
+}
+
+// Check проверяет наличие ответа виджета. Сам ответ проверяет только провайдер в Verify
+func (c *Captcha) Check(solution Solution) error {
+	if solution.CaptchaToken == "" {
+		return ErrRequired
+	}
+	return nil
+}
+
+// Verify отправляет ответ виджета провайдеру. Недоступность провайдера возвращается
+// как ErrUnavailable: клиент может повторить запрос или пройти proof-of-work
+func (c *Captcha) Verify(ctx context.Context, solution Solution) error {
+	if err := c.Check(solution); err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"secret":   {c.secret},
+		"response": {solution.CaptchaToken},
+	}
+	if solution.RemoteIP != "" {
+		form.Set("remoteip", solution.RemoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return ErrUnavailable.Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return ErrUnavailable.Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ErrUnavailable.Wrap(fmt.Errorf("siteverify ответил %s", resp.Status))
+	}
+
+	var result siteverifyResponse
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return ErrUnavailable.Wrap(err)
+	}
+
+	if !result.Success {
+		return ErrFailed.Wrap(fmt.Errorf("siteverify: %s", strings.Join(result.ErrorCodes, ", ")))
+	}
+	if result.Score != nil && *result.Score < c.minScore {
+		return ErrFailed.Wrap(fmt.Errorf("оценка капчи %.2f ниже %.2f", *result.Score, c.minScore))
+	}
+
+	return nil
+}
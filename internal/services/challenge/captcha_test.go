@@ -0,0 +1,149 @@
+package challenge_test
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/challenge"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var testLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// siteverify запускает фейковый siteverify с заданным статусом и телом ответа
+func siteverify(t *testing.T, status int, body string) (*httptest.Server, *http.Request) {
+	t.Helper()
+
+	var got http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		got = *r
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func newCaptcha(t *testing.T, srv *httptest.Server) *challenge.Captcha {
+	t.Helper()
+
+	captcha, err := challenge.NewCaptcha(config.Captcha{
+		Provider:  "hcaptcha",
+		Secret:    "captcha-secret",
+		VerifyURL: srv.URL,
+		MinScore:  0.5,
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return captcha
+}
+
+func TestCaptchaSuccess(t *testing.T) {
+	srv, req := siteverify(t, http.StatusOK, `{"success": true, "score": 0.9}`)
+	captcha := newCaptcha(t, srv)
+
+	err := captcha.Verify(context.Background(), challenge.Solution{CaptchaToken: "token", RemoteIP: "192.0.2.1"})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	if req.Method != http.MethodPost {
+		t.Errorf("метод %s, ожидался POST", req.Method)
+	}
+	for key, want := range map[string]string{"secret": "captcha-secret", "response": "token", "remoteip": "192.0.2.1"} {
+		if got := req.PostForm.Get(key); got != want {
+			t.Errorf("%s = %q, ожидалось %q", key, got, want)
+		}
+	}
+}
+
+func TestCaptchaRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "неуспешная проверка", status: http.StatusOK, body: `{"success": false, "error-codes": ["invalid-input-response"]}`, wantErr: challenge.ErrFailed},
+		{name: "низкая оценка", status: http.StatusOK, body: `{"success": true, "score": 0.1}`, wantErr: challenge.ErrFailed},
+		{name: "ответ 5xx", status: http.StatusBadGateway, body: `{}`, wantErr: challenge.ErrUnavailable},
+		{name: "не JSON", status: http.StatusOK, body: `<html>`, wantErr: challenge.ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := siteverify(t, tt.status, tt.body)
+			captcha := newCaptcha(t, srv)
+
+			err := captcha.Verify(context.Background(), challenge.Solution{CaptchaToken: "token"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCaptchaUnreachable(t *testing.T) {
+	srv, _ := siteverify(t, http.StatusOK, `{"success": true}`)
+	captcha := newCaptcha(t, srv)
+	srv.Close()
+
+	err := captcha.Verify(context.Background(), challenge.Solution{CaptchaToken: "token"})
+	if !errors.Is(err, challenge.ErrUnavailable) {
+		t.Fatalf("Verify = %v, ожидалась ErrUnavailable", err)
+	}
+}
+
+func TestCaptchaEmptyToken(t *testing.T) {
+	srv, _ := siteverify(t, http.StatusOK, `{"success": true}`)
+	captcha := newCaptcha(t, srv)
+
+	if err := captcha.Verify(context.Background(), challenge.Solution{}); !errors.Is(err, challenge.ErrRequired) {
+		t.Fatalf("Verify = %v, ожидалась ErrRequired", err)
+	}
+}
+
+func TestServiceRequire(t *testing.T) {
+	srv, _ := siteverify(t, http.StatusOK, `{"success": true}`)
+	cfg := config.RegistrationChallenge{Captcha: config.Captcha{Provider: "hcaptcha", Secret: "captcha-secret", VerifyURL: srv.URL}}
+	s, err := challenge.New(testLog, cfg, "secret", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	tests := []struct {
+		name     string
+		solution challenge.Solution
+		wantErr  error
+	}{
+		{name: "нет решения", wantErr: challenge.ErrRequired},
+		{name: "proof-of-work не настроен", solution: challenge.Solution{ProofOfWorkChallenge: "c", ProofOfWorkNonce: "1"}, wantErr: challenge.ErrRequired},
+		{name: "ответ капчи", solution: challenge.Solution{CaptchaToken: "token"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Require(tt.solution)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Require = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Require = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	var disabled *challenge.Service
+	if err = disabled.Require(challenge.Solution{}); err != nil {
+		t.Fatalf("Require без испытаний = %v", err)
+	}
+}
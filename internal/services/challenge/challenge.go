@@ -0,0 +1,182 @@
+package challenge
+
+import (
+	"auth-service/config"
+	"auth-service/internal/errs"
+	"auth-service/pkg/logger"
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Ошибки проверки испытания. Причина (reason) уходит клиенту в ErrorInfo
+var (
+	ErrRequired    = errs.New(errs.KindFailedPrecondition, "CHALLENGE_REQUIRED", "требуется пройти проверку")
+	ErrFailed      = errs.New(errs.KindPermissionDenied, "CHALLENGE_FAILED", "проверка не пройдена")
+	ErrExpired     = errs.New(errs.KindFailedPrecondition, "CHALLENGE_EXPIRED", "время на решение истекло, запросите новое испытание")
+	ErrUnavailable = errs.New(errs.KindUnavailable, "CHALLENGE_UNAVAILABLE", "сервис проверки недоступен, повторите попытку позже")
+)
+
+// Solution решение испытания из запроса регистрации. Заполнено одно из полей:
+// ответ виджета капчи или решение proof-of-work
+type Solution struct {
+	CaptchaToken         string
+	ProofOfWorkChallenge string
+	ProofOfWorkNonce     string
+	RemoteIP             string // Адрес клиента, передается провайдеру капчи
+}
+
+// Verifier проверяет решение испытания одного вида
+type Verifier interface {
+	// Check выполняет проверки, не требующие обращения к базе и провайдеру
+	Check(solution Solution) error
+	// Verify полностью проверяет решение и расходует его
+	Verify(ctx context.Context, solution Solution) error
+}
+
+// Info способы пройти испытание для GetRegistrationChallenge
+type Info struct {
+	Required        bool
+	CaptchaProvider string
+	CaptchaSiteKey  string
+}
+
+// Service выбирает проверку по виду решения. nil-сервис и сервис без настроенных
+// испытаний пропускают все запросы
+type Service struct {
+	log     *slog.Logger
+	info    Info
+	captcha Verifier
+	pow     *ProofOfWork
+
+	stop chan struct{} // останавливает очистку решенных задач
+	wg   sync.WaitGroup
+}
+
+// New собирает испытания из конфигурации. secret подписывает задачи proof-of-work,
+// чтобы их не нужно было хранить; решенные задачи хранятся в базе до истечения срока.
+// client используется для запросов к провайдеру капчи
+func New(log *slog.Logger, cfg config.RegistrationChallenge, secret string, client *http.Client) (*Service, error) {
+	s := &Service{
+		log:  log.With(slog.String("op", "registration_challenge")),
+		stop: make(chan struct{}),
+	}
+
+	if cfg.Captcha.Provider != "" {
+		captcha, err := NewCaptcha(cfg.Captcha, client)
+		if err != nil {
+			return nil, err
+		}
+		s.captcha = captcha
+		s.info.CaptchaProvider = cfg.Captcha.Provider
+		s.info.CaptchaSiteKey = cfg.Captcha.SiteKey
+	}
+
+	if cfg.ProofOfWork.Enabled {
+		pow, err := NewProofOfWork(cfg.ProofOfWork, []byte(secret), dbSpentStore{})
+		if err != nil {
+			return nil, err
+		}
+		s.pow = pow
+
+		s.wg.Add(1)
+		go s.sweepLoop()
+	}
+
+	s.info.Required = s.captcha != nil || s.pow != nil
+	return s, nil
+}
+
+// verifier выбирает испытание по виду решения; nil - решения нет или испытание не настроено
+func (s *Service) verifier(solution Solution) Verifier {
+	switch {
+	case solution.CaptchaToken != "" && s.captcha != nil:
+		return s.captcha
+	case solution.ProofOfWorkChallenge != "" && s.pow != nil:
+		return s.pow
+	}
+	return nil
+}
+
+// Close останавливает очистку решенных задач
+func (s *Service) Close() {
+	if s == nil {
+		return
+	}
+	close(s.stop)
+	s.wg.Wait()
+}
+
+// sweepLoop раз в срок действия задачи удаляет решенные задачи с истекшим сроком
+func (s *Service) sweepLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pow.ttl)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			n, err := s.pow.spent.Sweep(context.Background())
+			if err != nil {
+				s.log.Error("ошибка очистки решенных задач", logger.Err(err))
+				continue
+			}
+			if n > 0 {
+				s.log.Debug("решенные задачи очищены", slog.Int64("count", n))
+			}
+		}
+	}
+}
+
+// Info возвращает способы пройти испытание
+func (s *Service) Info() Info {
+	if s == nil {
+		return Info{}
+	}
+	return s.info
+}
+
+// Issue выдает новую задачу proof-of-work; ok = false, если proof-of-work выключен
+func (s *Service) Issue() (puzzle Puzzle, ok bool) {
+	if s == nil || s.pow == nil {
+		return Puzzle{}, false
+	}
+	return s.pow.Issue(), true
+}
+
+// Require отклоняет запрос без решения подходящего испытания или с решением, которое не проходит
+// проверки без обращения к базе и провайдеру: подпись, срок и сложность proof-of-work. Решение
+// не расходуется, поэтому Require вызывается до валидации формы, а Verify - после
+func (s *Service) Require(solution Solution) error {
+	if s == nil || !s.info.Required {
+		return nil
+	}
+	verifier := s.verifier(solution)
+	if verifier == nil {
+		return ErrRequired
+	}
+	return verifier.Check(solution)
+}
+
+// Verify проверяет решение тем испытанием, к которому оно относится
+func (s *Service) Verify(ctx context.Context, solution Solution) error {
+	if s == nil || !s.info.Required {
+		return nil
+	}
+
+	verifier := s.verifier(solution)
+	if verifier == nil {
+		return ErrRequired
+	}
+	err := verifier.Verify(ctx, solution)
+
+	if errs.KindOf(err) == errs.KindUnavailable {
+		s.log.Error("проверка испытания недоступна", logger.Err(err))
+	}
+	return err
+}
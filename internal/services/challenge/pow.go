@@ -0,0 +1,145 @@
+package challenge
+
+import (
+	"auth-service/config"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"time"
+)
+
+const (
+	powMaxDifficulty = 32 // больше - решение в браузере займет минуты
+	powRandomSize    = 16
+	powMACSize       = 16
+	powChallengeSize = 8 + powRandomSize + powMACSize // срок действия, случайные байты, подпись
+)
+
+// Puzzle задача proof-of-work для клиента
+type Puzzle struct {
+	Challenge  string
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+// ProofOfWork выдает и проверяет задачи proof-of-work. Задача подписана HMAC и содержит
+// срок действия, поэтому выданные задачи не хранятся; в SpentStore хранятся только решенные,
+// чтобы одно решение нельзя было использовать повторно
+type ProofOfWork struct {
+	key        []byte
+	difficulty int
+	ttl        time.Duration
+	spent      SpentStore
+}
+
+// NewProofOfWork создает испытание. key подписывает задачи и, как и spent, должен быть общим
+// для всех экземпляров сервиса
+func NewProofOfWork(cfg config.ProofOfWork, key []byte, spent SpentStore) (*ProofOfWork, error) {
+	if cfg.Difficulty < 1 || cfg.Difficulty > powMaxDifficulty {
+		return nil, fmt.Errorf("сложность proof-of-work должна быть от 1 до %d", powMaxDifficulty)
+	}
+	if cfg.TTL <= 0 {
+		return nil, fmt.Errorf("время на решение proof-of-work должно быть положительным")
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("не задан ключ подписи задач proof-of-work")
+	}
+
+	// Отдельный ключ, чтобы подпись задачи нельзя было использовать в другом контексте
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("registration-proof-of-work"))
+
+	return &ProofOfWork{
+		key:        mac.Sum(nil),
+		difficulty: cfg.Difficulty,
+		ttl:        cfg.TTL,
+		spent:      spent,
+	}, nil
+}
+
+// Issue выдает новую задачу
+func (p *ProofOfWork) Issue() Puzzle {
+	expiresAt := time.Now().Add(p.ttl).Truncate(time.Second)
+
+	raw := make([]byte, 8+powRandomSize, powChallengeSize)
+	binary.BigEndian.PutUint64(raw, uint64(expiresAt.Unix()))
+	_, _ = rand.Read(raw[8:])
+	raw = append(raw, p.sign(raw)...)
+
+	return Puzzle{
+		Challenge:  base64.RawURLEncoding.EncodeToString(raw),
+		Difficulty: p.difficulty,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// Check проверяет подпись и срок задачи и решение, не расходуя задачу
+func (p *ProofOfWork) Check(solution Solution) error {
+	_, err := p.check(solution)
+	return err
+}
+
+// Verify проверяет задачу и решение и отмечает задачу использованной
+func (p *ProofOfWork) Verify(ctx context.Context, solution Solution) error {
+	expiresAt, err := p.check(solution)
+	if err != nil {
+		return err
+	}
+
+	fresh, err := p.spent.Spend(ctx, solution.ProofOfWorkChallenge, expiresAt)
+	if err != nil {
+		return ErrUnavailable.Wrap(err)
+	}
+	if !fresh {
+		return ErrFailed
+	}
+
+	return nil
+}
+
+// check проверяет подпись, срок и решение задачи и возвращает срок ее действия
+func (p *ProofOfWork) check(solution Solution) (time.Time, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(solution.ProofOfWorkChallenge)
+	if err != nil || len(raw) != powChallengeSize {
+		return time.Time{}, ErrFailed
+	}
+
+	payload, signature := raw[:8+powRandomSize], raw[8+powRandomSize:]
+	if !hmac.Equal(signature, p.sign(payload)) {
+		return time.Time{}, ErrFailed
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload)), 0)
+	if time.Now().After(expiresAt) {
+		return time.Time{}, ErrExpired
+	}
+
+	sum := sha256.Sum256([]byte(solution.ProofOfWorkChallenge + ":" + solution.ProofOfWorkNonce))
+	if leadingZeroBits(sum[:]) < p.difficulty {
+		return time.Time{}, ErrFailed
+	}
+
+	return expiresAt, nil
+}
+
+func (p *ProofOfWork) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write(payload)
+	return mac.Sum(nil)[:powMACSize]
+}
+
+func leadingZeroBits(b []byte) int {
+	n := 0
+	for _, x := range b {
+		if x != 0 {
+			return n + bits.LeadingZeros8(x)
+		}
+		n += 8
+	}
+	return n
+}
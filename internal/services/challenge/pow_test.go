@@ -0,0 +1,236 @@
+package challenge_test
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/challenge"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"math/bits"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memorySpentStore хранилище решенных задач в памяти
+type memorySpentStore struct {
+	mu    sync.Mutex
+	spent map[string]time.Time
+	err   error
+}
+
+func newMemorySpentStore() *memorySpentStore {
+	return &memorySpentStore{spent: make(map[string]time.Time)}
+}
+
+func (m *memorySpentStore) Spend(_ context.Context, challenge string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return false, m.err
+	}
+	if _, ok := m.spent[challenge]; ok {
+		return false, nil
+	}
+	m.spent[challenge] = expiresAt
+	return true, nil
+}
+
+func (m *memorySpentStore) Sweep(context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for challenge, expiresAt := range m.spent {
+		if time.Now().After(expiresAt) {
+			delete(m.spent, challenge)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func newProofOfWork(t *testing.T, store challenge.SpentStore) *challenge.ProofOfWork {
+	t.Helper()
+
+	pow, err := challenge.NewProofOfWork(config.ProofOfWork{Enabled: true, Difficulty: 8, TTL: time.Minute}, []byte("secret"), store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pow
+}
+
+// solve подбирает nonce так же, как клиент
+func solve(puzzle challenge.Puzzle) string {
+	for i := 0; ; i++ {
+		nonce := strconv.Itoa(i)
+		sum := sha256.Sum256([]byte(puzzle.Challenge + ":" + nonce))
+		zeros := 0
+		for _, b := range sum {
+			zeros += bits.LeadingZeros8(b)
+			if b != 0 {
+				break
+			}
+		}
+		if zeros >= puzzle.Difficulty {
+			return nonce
+		}
+	}
+}
+
+func TestProofOfWork(t *testing.T) {
+	pow := newProofOfWork(t, newMemorySpentStore())
+
+	puzzle := pow.Issue()
+	solution := challenge.Solution{ProofOfWorkChallenge: puzzle.Challenge, ProofOfWorkNonce: solve(puzzle)}
+
+	// Check не расходует задачу
+	for range 2 {
+		if err := pow.Check(solution); err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+	}
+	if err := pow.Verify(context.Background(), solution); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	// Одно решение принимается один раз
+	if err := pow.Verify(context.Background(), solution); !errors.Is(err, challenge.ErrFailed) {
+		t.Fatalf("повторный Verify = %v, ожидалась ErrFailed", err)
+	}
+}
+
+func TestProofOfWorkRejects(t *testing.T) {
+	pow := newProofOfWork(t, newMemorySpentStore())
+	puzzle := pow.Issue()
+	nonce := solve(puzzle)
+
+	other := newProofOfWork(t, newMemorySpentStore())
+	otherPuzzle := other.Issue()
+
+	// Подпись задачи с другим ключом
+	foreign, err := challenge.NewProofOfWork(config.ProofOfWork{Difficulty: 8, TTL: time.Minute}, []byte("other"), newMemorySpentStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreignPuzzle := foreign.Issue()
+
+	tests := []struct {
+		name     string
+		solution challenge.Solution
+	}{
+		{name: "не base64", solution: challenge.Solution{ProofOfWorkChallenge: "!!!", ProofOfWorkNonce: nonce}},
+		{name: "чужой ключ", solution: challenge.Solution{ProofOfWorkChallenge: foreignPuzzle.Challenge, ProofOfWorkNonce: solve(foreignPuzzle)}},
+		{name: "неверный nonce", solution: challenge.Solution{ProofOfWorkChallenge: otherPuzzle.Challenge, ProofOfWorkNonce: wrongNonce(otherPuzzle)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := pow.Check(tt.solution); !errors.Is(err, challenge.ErrFailed) {
+				t.Fatalf("Check = %v, ожидалась ErrFailed", err)
+			}
+			if err := pow.Verify(context.Background(), tt.solution); !errors.Is(err, challenge.ErrFailed) {
+				t.Fatalf("Verify = %v, ожидалась ErrFailed", err)
+			}
+		})
+	}
+}
+
+func TestProofOfWorkExpired(t *testing.T) {
+	pow, err := challenge.NewProofOfWork(config.ProofOfWork{Difficulty: 1, TTL: time.Nanosecond}, []byte("secret"), newMemorySpentStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Срок задачи округляется до секунды вниз, поэтому через секунду он точно истек
+	puzzle := pow.Issue()
+	time.Sleep(time.Until(puzzle.ExpiresAt) + time.Second)
+
+	solution := challenge.Solution{ProofOfWorkChallenge: puzzle.Challenge, ProofOfWorkNonce: solve(puzzle)}
+	if err = pow.Check(solution); !errors.Is(err, challenge.ErrExpired) {
+		t.Fatalf("Check = %v, ожидалась ErrExpired", err)
+	}
+	if err = pow.Verify(context.Background(), solution); !errors.Is(err, challenge.ErrExpired) {
+		t.Fatalf("Verify = %v, ожидалась ErrExpired", err)
+	}
+}
+
+func TestServiceRequireProofOfWork(t *testing.T) {
+	s, err := challenge.New(testLog, config.RegistrationChallenge{
+		ProofOfWork: config.ProofOfWork{Enabled: true, Difficulty: 8, TTL: time.Minute},
+	}, "secret", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	puzzle, ok := s.Issue()
+	if !ok {
+		t.Fatal("proof-of-work не включен")
+	}
+
+	tests := []struct {
+		name     string
+		solution challenge.Solution
+		wantErr  error
+	}{
+		{name: "решение", solution: challenge.Solution{ProofOfWorkChallenge: puzzle.Challenge, ProofOfWorkNonce: solve(puzzle)}},
+		{name: "неверный nonce", solution: challenge.Solution{ProofOfWorkChallenge: puzzle.Challenge, ProofOfWorkNonce: wrongNonce(puzzle)}, wantErr: challenge.ErrFailed},
+		{name: "поддельная задача", solution: challenge.Solution{ProofOfWorkChallenge: "AAAA", ProofOfWorkNonce: "1"}, wantErr: challenge.ErrFailed},
+		{name: "только капча", solution: challenge.Solution{CaptchaToken: "token"}, wantErr: challenge.ErrRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Require(tt.solution)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Require = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Require = %v, ожидалась %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProofOfWorkStoreFailure(t *testing.T) {
+	store := newMemorySpentStore()
+	store.err = errors.New("db down")
+	pow := newProofOfWork(t, store)
+
+	puzzle := pow.Issue()
+	solution := challenge.Solution{ProofOfWorkChallenge: puzzle.Challenge, ProofOfWorkNonce: solve(puzzle)}
+	if err := pow.Verify(context.Background(), solution); !errors.Is(err, challenge.ErrUnavailable) {
+		t.Fatalf("Verify = %v, ожидалась ErrUnavailable", err)
+	}
+}
+
+func TestNewProofOfWorkConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ProofOfWork
+		key  []byte
+	}{
+		{name: "нулевая сложность", cfg: config.ProofOfWork{Difficulty: 0, TTL: time.Minute}, key: []byte("k")},
+		{name: "слишком сложно", cfg: config.ProofOfWork{Difficulty: 33, TTL: time.Minute}, key: []byte("k")},
+		{name: "нет срока", cfg: config.ProofOfWork{Difficulty: 8}, key: []byte("k")},
+		{name: "нет ключа", cfg: config.ProofOfWork{Difficulty: 8, TTL: time.Minute}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := challenge.NewProofOfWork(tt.cfg, tt.key, newMemorySpentStore()); err == nil {
+				t.Fatal("NewProofOfWork принял неверную конфигурацию")
+			}
+		})
+	}
+}
+
+// wrongNonce подбирает nonce, который не решает задачу
+func wrongNonce(puzzle challenge.Puzzle) string {
+	for i := 0; ; i++ {
+		nonce := "x" + strconv.Itoa(i)
+		sum := sha256.Sum256([]byte(puzzle.Challenge + ":" + nonce))
+		if sum[0] != 0 {
+			return nonce
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package challenge
+
+import (
+	"auth-service/internal/models"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// SpentStore хранит решенные задачи proof-of-work до истечения их срока. Хранилище должно быть
+// общим для всех экземпляров сервиса, иначе одно решение принял бы каждый экземпляр
+type SpentStore interface {
+	// Spend отмечает задачу использованной; false - задача уже была использована
+	Spend(ctx context.Context, challenge string, expiresAt time.Time) (bool, error)
+	// Sweep удаляет задачи с истекшим сроком
+	Sweep(ctx context.Context) (int64, error)
+}
+
+// dbSpentStore хранит решенные задачи в auth.spent_challenges
+type dbSpentStore struct{}
+
+func (dbSpentStore) Spend(ctx context.Context, challenge string, expiresAt time.Time) (bool, error) {
+	sum := sha256.Sum256([]byte(challenge))
+	return models.SpendChallenge(ctx, hex.EncodeToString(sum[:]), expiresAt)
+}
+
+func (dbSpentStore) Sweep(ctx context.Context) (int64, error) {
+	return models.DeleteExpiredChallenges(ctx)
+}
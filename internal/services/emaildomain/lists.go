@@ -0,0 +1,140 @@
+package emaildomain
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/idna"
+)
+
+// mxCacheTTL сколько помнить результат поиска MX, mxCacheSize - сколько доменов
+const (
+	mxCacheTTL  = 10 * time.Minute
+	mxCacheSize = 10000
+)
+
+//go:embed disposable_domains.txt
+var disposableDomainsData string
+
+// disposableDomains встроенный список доменов одноразовой почты
+var disposableDomains = func() map[string]struct{} {
+	m := make(map[string]struct{})
+	for _, line := range strings.Split(disposableDomainsData, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			m[strings.ToLower(line)] = struct{}{}
+		}
+	}
+	return m
+}()
+
+type lists struct {
+	allow map[string]struct{}
+	deny  map[string]struct{}
+}
+
+func newLists(allow, deny []string) *lists {
+	l := &lists{allow: make(map[string]struct{}), deny: make(map[string]struct{})}
+	for _, d := range allow {
+		l.allow[normalizeDomain(d)] = struct{}{}
+	}
+	for _, d := range deny {
+		l.deny[normalizeDomain(d)] = struct{}{}
+	}
+	return l
+}
+
+// readFile добавляет домены из файла со строками "allow домен" и "deny домен";
+// пустые строки и строки с # пропускаются
+func (l *lists) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return fmt.Errorf("%s:%d: ожидается \"allow домен\" или \"deny домен\"", path, line)
+		}
+		switch fields[0] {
+		case "allow":
+			l.allow[normalizeDomain(fields[1])] = struct{}{}
+		case "deny":
+			l.deny[normalizeDomain(fields[1])] = struct{}{}
+		default:
+			return fmt.Errorf("%s:%d: неизвестное правило %q", path, line, fields[0])
+		}
+	}
+	return scanner.Err()
+}
+
+// matches ищет домен или любой из его родительских доменов в наборе
+func matches(set map[string]struct{}, domain string) bool {
+	for {
+		if _, ok := set[domain]; ok {
+			return true
+		}
+		_, parent, ok := strings.Cut(domain, ".")
+		if !ok {
+			return false
+		}
+		domain = parent
+	}
+}
+
+// normalizeDomain приводит домен из списка к виду домена нормализованного email
+func normalizeDomain(domain string) string {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+		return ascii
+	}
+	return domain
+}
+
+type mxEntry struct {
+	ok      bool
+	expires time.Time
+}
+
+// mxCache запоминает результаты поиска MX: волна регистраций обычно идет с нескольких доменов
+type mxCache struct {
+	mu      sync.Mutex
+	entries map[string]mxEntry
+}
+
+func newMXCache() *mxCache {
+	return &mxCache{entries: make(map[string]mxEntry)}
+}
+
+func (c *mxCache) get(domain string) (ok, cached bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, found := c.entries[domain]
+	if !found || time.Now().After(e.expires) {
+		return false, false
+	}
+	return e.ok, true
+}
+
+func (c *mxCache) put(domain string, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= mxCacheSize {
+		clear(c.entries)
+	}
+	c.entries[domain] = mxEntry{ok: ok, expires: time.Now().Add(mxCacheTTL)}
+}
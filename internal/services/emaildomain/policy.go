@@ -0,0 +1,192 @@
+package emaildomain
+
+import (
+	"auth-service/config"
+	"auth-service/pkg/logger"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Причины отказа, они же коды нарушений валидатора
+const (
+	ReasonDenied     = "EMAIL_DOMAIN_DENIED"      // Домен в списке запрещенных
+	ReasonNotAllowed = "EMAIL_DOMAIN_NOT_ALLOWED" // Задан список разрешенных, домена в нем нет
+	ReasonDisposable = "EMAIL_DOMAIN_DISPOSABLE"  // Домен одноразовой почты
+	ReasonNoMX       = "EMAIL_DOMAIN_NO_MX"       // У домена нет ни MX, ни A/AAAA записей, письма не будут доставлены
+)
+
+// Resolver ищет MX и адресные записи домена. net.Resolver подходит как есть, в тестах подставляется заглушка
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// Policy проверяет домен email по спискам, встроенному списку одноразовой почты и MX записям
+type Policy struct {
+	log   *slog.Logger
+	cfg   config.EmailDomains
+	lists atomic.Pointer[lists] // списки из конфига и файла, заменяются целиком при перечитывании
+
+	resolver Resolver
+	mx       *mxCache
+
+	fileMod time.Time // время изменения файла при последней загрузке
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New создает политику и загружает файл списков. Если задан файл, он перечитывается
+// при изменении раз в ReloadInterval до вызова Close
+func New(log *slog.Logger, cfg config.EmailDomains, resolver Resolver) (*Policy, error) {
+	p := &Policy{
+		log:      log.With(slog.String("op", "email_domain_policy")),
+		cfg:      cfg,
+		resolver: resolver,
+		mx:       newMXCache(),
+		stop:     make(chan struct{}),
+	}
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	if cfg.File != "" && cfg.ReloadInterval > 0 {
+		p.wg.Add(1)
+		go p.reloadLoop()
+	}
+
+	return p, nil
+}
+
+// Check возвращает причину отказа для домена в нормализованном виде (нижний регистр, punycode)
+// или пустую строку, если домен разрешен. Явно разрешенные домены не проверяются
+// по списку одноразовой почты и MX
+func (p *Policy) Check(ctx context.Context, domain string) string {
+	if p == nil {
+		return ""
+	}
+
+	l := p.lists.Load()
+	switch {
+	case matches(l.deny, domain):
+		return ReasonDenied
+	case matches(l.allow, domain):
+		return ""
+	case len(l.allow) > 0:
+		return ReasonNotAllowed
+	case p.cfg.BlockDisposable && matches(disposableDomains, domain):
+		return ReasonDisposable
+	case p.cfg.RequireMX && !p.hasMX(ctx, domain):
+		return ReasonNoMX
+	}
+	return ""
+}
+
+// Close останавливает перечитывание файла
+func (p *Policy) Close() {
+	if p == nil {
+		return
+	}
+	close(p.stop)
+	p.wg.Wait()
+}
+
+// load собирает списки из конфига и файла и заменяет действующие
+func (p *Policy) load() error {
+	l := newLists(p.cfg.Allow, p.cfg.Deny)
+
+	if p.cfg.File != "" {
+		info, err := os.Stat(p.cfg.File)
+		if err != nil {
+			return err
+		}
+		if err = l.readFile(p.cfg.File); err != nil {
+			return err
+		}
+		p.fileMod = info.ModTime()
+	}
+
+	p.lists.Store(l)
+	return nil
+}
+
+func (p *Policy) reloadLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			info, err := os.Stat(p.cfg.File)
+			if err != nil {
+				p.log.Error("файл списков доменов недоступен", logger.Err(err))
+				continue
+			}
+			if info.ModTime().Equal(p.fileMod) {
+				continue
+			}
+
+			// При ошибке в файле продолжают действовать прежние списки
+			if err = p.load(); err != nil {
+				p.log.Error("ошибка перечитывания списков доменов", logger.Err(err))
+				continue
+			}
+			p.log.Info("списки доменов перечитаны")
+		}
+	}
+}
+
+// hasMX сообщает, принимает ли домен почту. Без MX записей почта доставляется на A/AAAA
+// записи домена (неявный MX, RFC 5321 5.1). Сбой DNS не повод отказать в регистрации,
+// поэтому отклоняются только домены, для которых DNS явно ответил, что записей нет
+func (p *Policy) hasMX(ctx context.Context, domain string) bool {
+	if ok, cached := p.mx.get(domain); cached {
+		return ok
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.MXTimeout)
+	defer cancel()
+
+	records, err := p.resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		p.log.Warn("ошибка поиска MX записей", slog.String("domain", domain), logger.Err(err))
+		return true
+	}
+
+	var ok bool
+	if len(records) > 0 {
+		// MX "." (RFC 7505) означает, что домен почту не принимает; неявный MX тогда не действует
+		for _, mx := range records {
+			if mx.Host != "." && mx.Host != "" {
+				ok = true
+				break
+			}
+		}
+	} else {
+		addrs, err := p.resolver.LookupHost(ctx, domain)
+		if err != nil && !isNotFound(err) {
+			p.log.Warn("ошибка поиска адресов домена", slog.String("domain", domain), logger.Err(err))
+			return true
+		}
+		ok = len(addrs) > 0
+	}
+
+	p.mx.put(domain, ok)
+	return ok
+}
+
+// isNotFound ответил ли DNS, что записей нет (в отличие от сбоя или таймаута)
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,209 @@
+package emaildomain_test
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/emaildomain"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// fakeResolver отвечает из таблиц; домена нет в таблице - DNS ответил, что записей нет
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error // сбой DNS для любого запроса
+
+	mxCalls atomic.Int32
+}
+
+func (r *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	r.mxCalls.Add(1)
+	if r.err != nil {
+		return nil, r.err
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func newPolicy(t *testing.T, cfg config.EmailDomains, resolver emaildomain.Resolver) *emaildomain.Policy {
+	t.Helper()
+
+	if cfg.MXTimeout == 0 {
+		cfg.MXTimeout = time.Second
+	}
+	p, err := emaildomain.New(testLog, cfg, resolver)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestCheckLists(t *testing.T) {
+	cfg := config.EmailDomains{
+		Deny:            []string{"evil.com"},
+		BlockDisposable: true,
+	}
+	p := newPolicy(t, cfg, &fakeResolver{})
+
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "example.com", want: ""},
+		{domain: "evil.com", want: emaildomain.ReasonDenied},
+		{domain: "mail.evil.com", want: emaildomain.ReasonDenied},
+		{domain: "10minutemail.com", want: emaildomain.ReasonDisposable},
+	}
+	for _, tt := range tests {
+		if got := p.Check(context.Background(), tt.domain); got != tt.want {
+			t.Errorf("Check(%q) = %q, ожидалось %q", tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAllowList(t *testing.T) {
+	cfg := config.EmailDomains{
+		Allow:           []string{"corp.example", "10minutemail.com"},
+		BlockDisposable: true,
+		RequireMX:       true,
+	}
+	p := newPolicy(t, cfg, &fakeResolver{})
+
+	// Явно разрешенные домены не проверяются по одноразовой почте и MX
+	for _, domain := range []string{"corp.example", "dev.corp.example", "10minutemail.com"} {
+		if got := p.Check(context.Background(), domain); got != "" {
+			t.Errorf("Check(%q) = %q, ожидалось разрешение", domain, got)
+		}
+	}
+	if got := p.Check(context.Background(), "example.com"); got != emaildomain.ReasonNotAllowed {
+		t.Errorf("Check(example.com) = %q, ожидалось %q", got, emaildomain.ReasonNotAllowed)
+	}
+}
+
+func TestCheckMX(t *testing.T) {
+	resolver := &fakeResolver{
+		mx: map[string][]*net.MX{
+			"mx.example":     {{Host: "mail.mx.example.", Pref: 10}},
+			"nullmx.example": {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"implicit.example": {"192.0.2.1"},
+			"nullmx.example":   {"192.0.2.2"},
+		},
+	}
+	p := newPolicy(t, config.EmailDomains{RequireMX: true}, resolver)
+
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "mx.example", want: ""},
+		{domain: "implicit.example", want: ""},                   // нет MX, есть A: неявный MX
+		{domain: "nullmx.example", want: emaildomain.ReasonNoMX}, // MX "." запрещает почту и при наличии A
+		{domain: "nothing.example", want: emaildomain.ReasonNoMX},
+	}
+	for _, tt := range tests {
+		if got := p.Check(context.Background(), tt.domain); got != tt.want {
+			t.Errorf("Check(%q) = %q, ожидалось %q", tt.domain, got, tt.want)
+		}
+	}
+
+	// Повторная проверка берется из кеша
+	calls := resolver.mxCalls.Load()
+	p.Check(context.Background(), "nothing.example")
+	if resolver.mxCalls.Load() != calls {
+		t.Error("результат поиска MX не закеширован")
+	}
+}
+
+func TestCheckMXResolverFailure(t *testing.T) {
+	resolver := &fakeResolver{err: errors.New("timeout")}
+	p := newPolicy(t, config.EmailDomains{RequireMX: true}, resolver)
+
+	// Сбой DNS не повод отказать в регистрации
+	if got := p.Check(context.Background(), "example.com"); got != "" {
+		t.Fatalf("Check = %q при сбое DNS, ожидалось разрешение", got)
+	}
+}
+
+func TestFileReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	writeList(t, path, "# список\ndeny spam.example\n", time.Now().Add(-time.Hour))
+
+	p := newPolicy(t, config.EmailDomains{File: path, ReloadInterval: 10 * time.Millisecond}, &fakeResolver{})
+	if got := p.Check(context.Background(), "spam.example"); got != emaildomain.ReasonDenied {
+		t.Fatalf("Check(spam.example) = %q до перечитывания", got)
+	}
+
+	// Ошибка в файле: продолжают действовать прежние списки
+	writeList(t, path, "block spam.example\n", time.Now().Add(-30*time.Minute))
+	time.Sleep(50 * time.Millisecond)
+	if got := p.Check(context.Background(), "spam.example"); got != emaildomain.ReasonDenied {
+		t.Fatalf("Check(spam.example) = %q после ошибочного файла", got)
+	}
+
+	writeList(t, path, "deny other.example\n", time.Now())
+	waitFor(t, func() bool {
+		return p.Check(context.Background(), "other.example") == emaildomain.ReasonDenied
+	})
+	if got := p.Check(context.Background(), "spam.example"); got != "" {
+		t.Fatalf("Check(spam.example) = %q после перечитывания", got)
+	}
+}
+
+func TestNewInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.txt")
+	writeList(t, path, "deny\n", time.Now())
+
+	if _, err := emaildomain.New(testLog, config.EmailDomains{File: path}, &fakeResolver{}); err == nil {
+		t.Fatal("New принял файл с ошибкой")
+	}
+}
+
+// writeList записывает файл списков с заданным временем изменения, чтобы перечитывание
+// не зависело от точности времени файловой системы
+func writeList(t *testing.T, path, data string, mod time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mod, mod); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("условие не выполнилось за 2s")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
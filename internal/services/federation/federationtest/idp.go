@@ -0,0 +1,108 @@
+// Package federationtest локальный OpenID Connect провайдер для тестов входа через внешних провайдеров
+package federationtest
+
+import (
+	"auth-service/config"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// KeyID kid ключа, которым подписываются токены
+const KeyID = "test-key"
+
+// IdP отдает discovery и JWKS и подписывает ID токены своим ключом
+type IdP struct {
+	Server *httptest.Server
+	Key    *rsa.PrivateKey
+}
+
+// NewIdP запускает провайдер; он останавливается по завершении теста
+func NewIdP(t testing.TB) *IdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &IdP{Key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{
+			"issuer":   idp.Issuer(),
+			"jwks_uri": idp.Issuer() + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": KeyID,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Server.Close)
+	return idp
+}
+
+// Issuer адрес провайдера, он же ожидаемый iss
+func (idp *IdP) Issuer() string {
+	return idp.Server.URL
+}
+
+// Config настройки провайдера name для федерации с ожидаемым aud clientID
+func (idp *IdP) Config(name, clientID string, linkByEmail bool) config.IdentityProvider {
+	return config.IdentityProvider{
+		Name:        name,
+		Issuer:      idp.Issuer(),
+		ClientID:    clientID,
+		LinkByEmail: linkByEmail,
+	}
+}
+
+// Claims claims действительного ID токена для aud clientID; тест меняет нужные поля
+func (idp *IdP) Claims(clientID, subject, email string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            idp.Issuer(),
+		"aud":            clientID,
+		"sub":            subject,
+		"email":          email,
+		"email_verified": true,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+// Sign подписывает claims ключом провайдера
+func (idp *IdP) Sign(t testing.TB, claims jwt.MapClaims) string {
+	return SignWith(t, idp.Key, claims)
+}
+
+// SignWith подписывает claims произвольным ключом с kid провайдера
+func SignWith(t testing.TB, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = KeyID
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
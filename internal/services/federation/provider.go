@@ -0,0 +1,217 @@
+package federation
+
+import (
+	"auth-service/config"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// keysRefreshInterval ограничивает частоту перезапроса JWKS при неизвестном kid
+	keysRefreshInterval = time.Minute
+	keysCacheTTL        = 24 * time.Hour
+)
+
+var (
+	ErrUnknownProvider = errors.New("неизвестный провайдер идентификации")
+	ErrInvalidIDToken  = errors.New("недействительный ID токен провайдера")
+)
+
+// Claims данные пользователя из проверенного ID токена внешнего провайдера
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// Provider внешний OpenID Connect провайдер с кешем публичных ключей
+type Provider struct {
+	cfg    config.IdentityProvider
+	client *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+// Name возвращает имя провайдера из конфигурации
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// LinkByEmail разрешено ли привязывать внешний аккаунт к существующему по подтвержденному email
+func (p *Provider) LinkByEmail() bool {
+	return p.cfg.LinkByEmail
+}
+
+// Verify проверяет подпись и claims ID токена провайдера
+func (p *Provider) Verify(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.key(ctx, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512"}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+
+	// nonce защищает от повторного использования токена, выданного другому запросу
+	if nonce != "" {
+		if got, _ := claims["nonce"].(string); got != nonce {
+			return nil, fmt.Errorf("%w: nonce не совпадает", ErrInvalidIDToken)
+		}
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, fmt.Errorf("%w: отсутствует sub", ErrInvalidIDToken)
+	}
+
+	email, _ := claims["email"].(string)
+
+	// Часть провайдеров отдает email_verified строкой
+	var verified bool
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		verified = v
+	case string:
+		verified = v == "true"
+	}
+
+	return &Claims{Subject: sub, Email: email, EmailVerified: verified}, nil
+}
+
+// key возвращает публичный ключ по kid, при необходимости обновляя JWKS
+func (p *Provider) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	stale := time.Since(p.fetchedAt) > keysCacheTTL
+	canRefresh := time.Since(p.fetchedAt) > keysRefreshInterval
+	p.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+	if !canRefresh && !stale {
+		return nil, fmt.Errorf("неизвестный kid %q", kid)
+	}
+
+	if err := p.refreshKeys(ctx); err != nil {
+		if ok {
+			// провайдер недоступен, но ключ уже известен: продолжаем работать на кеше
+			return key, nil
+		}
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if key, ok = p.keys[kid]; !ok {
+		return nil, fmt.Errorf("неизвестный kid %q", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// refreshKeys загружает JWKS провайдера (адрес берется из конфига или из discovery)
+func (p *Provider) refreshKeys(ctx context.Context) error {
+	jwksURL := p.cfg.JWKSURL
+	if jwksURL == "" {
+		var doc struct {
+			Issuer  string `json:"issuer"`
+			JwksURI string `json:"jwks_uri"`
+		}
+		discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+		if err := p.getJSON(ctx, discoveryURL, &doc); err != nil {
+			return fmt.Errorf("discovery провайдера %s: %w", p.cfg.Name, err)
+		}
+		if doc.Issuer != p.cfg.Issuer {
+			return fmt.Errorf("discovery провайдера %s: issuer %q не совпадает с конфигурацией", p.cfg.Name, doc.Issuer)
+		}
+		jwksURL = doc.JwksURI
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, jwksURL, &set); err != nil {
+		return fmt.Errorf("JWKS провайдера %s: %w", p.cfg.Name, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := rsaPublicKey(k)
+		if err != nil {
+			return fmt.Errorf("JWKS провайдера %s: ключ %q: %w", p.cfg.Name, k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("неожиданный статус ответа %d", rsp.StatusCode)
+	}
+
+	return json.NewDecoder(rsp.Body).Decode(v)
+}
+
+func rsaPublicKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
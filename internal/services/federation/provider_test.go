@@ -0,0 +1,173 @@
+package federation_test
+
+import (
+	"auth-service/config"
+	"auth-service/internal/services/federation"
+	"auth-service/internal/services/federation/federationtest"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"testing"
+	"time"
+)
+
+const clientID = "auth-service-client"
+
+func newProvider(t *testing.T, idp *federationtest.IdP) *federation.Provider {
+	t.Helper()
+
+	registry := federation.NewRegistry([]config.IdentityProvider{idp.Config("test", clientID, false)}, idp.Server.Client())
+	provider, err := registry.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestVerify(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	provider := newProvider(t, idp)
+
+	claims := idp.Claims(clientID, "user-1", "user@example.com")
+	claims["nonce"] = "n-1"
+
+	got, err := provider.Verify(context.Background(), idp.Sign(t, claims), "n-1")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.Subject != "user-1" || got.Email != "user@example.com" || !got.EmailVerified {
+		t.Fatalf("Verify = %+v", got)
+	}
+}
+
+func TestVerifyEmailVerifiedString(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	provider := newProvider(t, idp)
+
+	claims := idp.Claims(clientID, "user-1", "user@example.com")
+	claims["email_verified"] = "false"
+
+	got, err := provider.Verify(context.Background(), idp.Sign(t, claims), "")
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.EmailVerified {
+		t.Fatal("email_verified \"false\" прочитан как подтвержденный")
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+	provider := newProvider(t, idp)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token func() string
+		nonce string
+	}{
+		{
+			name: "чужая подпись",
+			token: func() string {
+				return federationtest.SignWith(t, otherKey, idp.Claims(clientID, "user-1", "user@example.com"))
+			},
+		},
+		{
+			name: "чужой aud",
+			token: func() string {
+				return idp.Sign(t, idp.Claims("other-client", "user-1", "user@example.com"))
+			},
+		},
+		{
+			name: "чужой iss",
+			token: func() string {
+				claims := idp.Claims(clientID, "user-1", "user@example.com")
+				claims["iss"] = "https://evil.example.com"
+				return idp.Sign(t, claims)
+			},
+		},
+		{
+			name: "истекший токен",
+			token: func() string {
+				claims := idp.Claims(clientID, "user-1", "user@example.com")
+				claims["exp"] = time.Now().Add(-time.Hour).Unix()
+				return idp.Sign(t, claims)
+			},
+		},
+		{
+			name: "nonce не совпадает",
+			token: func() string {
+				claims := idp.Claims(clientID, "user-1", "user@example.com")
+				claims["nonce"] = "n-1"
+				return idp.Sign(t, claims)
+			},
+			nonce: "n-2",
+		},
+		{
+			name: "nonce отсутствует",
+			token: func() string {
+				return idp.Sign(t, idp.Claims(clientID, "user-1", "user@example.com"))
+			},
+			nonce: "n-1",
+		},
+		{
+			name: "нет sub",
+			token: func() string {
+				claims := idp.Claims(clientID, "", "user@example.com")
+				return idp.Sign(t, claims)
+			},
+		},
+		{
+			name: "HS256",
+			token: func() string {
+				signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.Claims(clientID, "user-1", "user@example.com")).
+					SignedString([]byte("secret"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return signed
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.Verify(context.Background(), tt.token(), tt.nonce)
+			if !errors.Is(err, federation.ErrInvalidIDToken) {
+				t.Fatalf("Verify = %v, ожидалась ErrInvalidIDToken", err)
+			}
+		})
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	idp := federationtest.NewIdP(t)
+
+	// Провайдер ждет другой issuer: discovery фейка вернет свой, ключи не загрузятся
+	cfg := idp.Config("test", clientID, false)
+	cfg.Issuer = idp.Issuer() + "/"
+	registry := federation.NewRegistry([]config.IdentityProvider{cfg}, idp.Server.Client())
+	provider, err := registry.Get("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := idp.Claims(clientID, "user-1", "user@example.com")
+	claims["iss"] = cfg.Issuer
+	if _, err = provider.Verify(context.Background(), idp.Sign(t, claims), ""); err == nil {
+		t.Fatal("Verify принял токен при несовпадении issuer в discovery")
+	}
+}
+
+func TestUnknownProvider(t *testing.T) {
+	registry := federation.NewRegistry(nil, nil)
+	if _, err := registry.Get("google"); !errors.Is(err, federation.ErrUnknownProvider) {
+		t.Fatalf("Get = %v, ожидалась ErrUnknownProvider", err)
+	}
+}
@@ -0,0 +1,36 @@
+package federation
+
+import (
+	"auth-service/config"
+	"net/http"
+	"time"
+)
+
+// Registry набор настроенных внешних провайдеров по имени
+type Registry struct {
+	providers map[string]*Provider
+}
+
+// NewRegistry создает провайдеры из конфигурации. client позволяет подменить
+// HTTP транспорт (например, на локальный fake IdP); nil означает клиент по умолчанию
+func NewRegistry(cfgs []config.IdentityProvider, client *http.Client) *Registry {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	r := &Registry{providers: make(map[string]*Provider, len(cfgs))}
+	for _, c := range cfgs {
+		r.providers[c.Name] = &Provider{cfg: c, client: client}
+	}
+
+	return r
+}
+
+// Get возвращает провайдер по имени
+func (r *Registry) Get(name string) (*Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
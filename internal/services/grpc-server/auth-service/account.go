@@ -0,0 +1,98 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// DeleteAccount удаляет аккаунт текущего пользователя
+func (s *serverAPI) DeleteAccount(
+	ctx context.Context,
+	req *apiAuthServices.DeleteAccountRequest,
+) (*apiAuthServices.DeleteAccountResponse, error) {
+	l := s.log.With("op", "api_delete_account")
+
+	tokenInfo, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	if tokenInfo.IsApiKey {
+		return nil, errApiKeyForbidden
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	// Пароль проверяется для аккаунтов с паролем, для остальных - давность входа по JWT
+	purgeAfter, err := s.authApp.DeleteAccount(ctx, userID, req.GetPassword(), tokenInfo.AuthTime)
+	if err != nil {
+		l.Warn("неудачная попытка удаления аккаунта", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.DeleteAccountResponse{
+		PurgeAfter: timestamppb.New(purgeAfter),
+	}, nil
+}
+
+// ExportMyData выгружает все данные текущего пользователя
+func (s *serverAPI) ExportMyData(
+	ctx context.Context,
+	_ *apiAuthServices.ExportMyDataRequest,
+) (*apiAuthServices.ExportDataResponse, error) {
+	l := s.log.With("op", "api_export_my_data")
+
+	_, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	data, err := s.authApp.ExportUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiAuthServices.ExportDataResponse{Data: data}, nil
+}
+
+// ExportUserData выгружает все данные пользователя по запросу администратора
+func (s *serverAPI) ExportUserData(
+	ctx context.Context,
+	req *apiAuthServices.ExportUserDataRequest,
+) (*apiAuthServices.ExportDataResponse, error) {
+	l := s.log.With("op", "api_export_user_data")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	data, err := s.authApp.ExportUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("данные пользователя выгружены администратором",
+		slog.String("user_id", userID.String()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return &apiAuthServices.ExportDataResponse{Data: data}, nil
+}
@@ -0,0 +1,130 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// CreateApiKey создает персональный API ключ текущего пользователя
+func (s *serverAPI) CreateApiKey(
+	ctx context.Context,
+	req *apiAuthServices.CreateApiKeyRequest,
+) (*apiAuthServices.CreateApiKeyResponse, error) {
+	l := s.log.With("op", "api_create_api_key")
+
+	_, userID, err := s.authenticateApiKeyOwner(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetName() == "" {
+		return nil, missingField("name")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	key, secret, err := s.authApp.CreateApiKey(ctx, userID, req.GetName(), req.GetRoles(), optionalTime(req.ExpiresAt))
+	if err != nil {
+		l.Debug("ошибка создания API ключа", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("API ключ создан", slog.String("key_id", key.KeyId.String()))
+	return &apiAuthServices.CreateApiKeyResponse{
+		Key:    apiKeyToProto(key),
+		Secret: secret,
+	}, nil
+}
+
+// ListApiKeys возвращает активные API ключи текущего пользователя
+func (s *serverAPI) ListApiKeys(
+	ctx context.Context,
+	_ *apiAuthServices.ListApiKeysRequest,
+) (*apiAuthServices.ListApiKeysResponse, error) {
+	l := s.log.With("op", "api_list_api_keys")
+
+	_, userID, err := s.authenticateApiKeyOwner(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	keys, err := s.authApp.ListApiKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &apiAuthServices.ListApiKeysResponse{Keys: make([]*apiAuthServices.ApiKey, 0, len(keys))}
+	for _, key := range keys {
+		rsp.Keys = append(rsp.Keys, apiKeyToProto(key))
+	}
+	return rsp, nil
+}
+
+// RevokeApiKey отзывает API ключ текущего пользователя
+func (s *serverAPI) RevokeApiKey(
+	ctx context.Context,
+	req *apiAuthServices.RevokeApiKeyRequest,
+) (*apiAuthServices.RevokeApiKeyResponse, error) {
+	l := s.log.With("op", "api_revoke_api_key")
+
+	_, userID, err := s.authenticateApiKeyOwner(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	keyID, err := uuid.Parse(req.GetKeyId())
+	if err != nil {
+		return nil, invalidField("key_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.RevokeApiKey(ctx, userID, keyID); err != nil {
+		return nil, err
+	}
+
+	return &apiAuthServices.RevokeApiKeyResponse{}, nil
+}
+
+// authenticateApiKeyOwner пускает к управлению ключами только по JWT пользователя,
+// чтобы утекший API ключ нельзя было использовать для выпуска новых ключей
+func (s *serverAPI) authenticateApiKeyOwner(ctx context.Context) (*models.TokenInfo, uuid.UUID, error) {
+	tokenInfo, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	if tokenInfo.IsApiKey {
+		return nil, uuid.Nil, errApiKeyForbidden
+	}
+	return tokenInfo, userID, nil
+}
+
+func apiKeyToProto(key *models.ApiKey) *apiAuthServices.ApiKey {
+	pb := &apiAuthServices.ApiKey{
+		KeyId:     key.KeyId.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Roles:     key.Roles,
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if key.ExpiresAt.Valid {
+		pb.ExpiresAt = timestamppb.New(key.ExpiresAt.Time)
+	}
+	if key.LastUsedAt.Valid {
+		pb.LastUsedAt = timestamppb.New(key.LastUsedAt.Time)
+	}
+	return pb
+}
@@ -0,0 +1,103 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/pkg/logger"
+	"context"
+	"log/slog"
+)
+
+// IssueServiceToken выдает токен машинному клиенту (client credentials grant)
+func (s *serverAPI) IssueServiceToken(
+	ctx context.Context,
+	req *apiAuthServices.IssueServiceTokenRequest,
+) (*apiAuthServices.IssueServiceTokenResponse, error) {
+	l := s.log.With("client_id", req.GetClientId(), "op", "api_issue_service_token")
+
+	if req.GetClientId() == "" || req.GetClientSecret() == "" {
+		l.Debug("ошибка валидации: пустые учетные данные клиента")
+		return nil, missingField("client_credentials")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	token, err := s.authApp.IssueServiceToken(ctx, req.GetClientId(), req.GetClientSecret())
+	if err != nil {
+		l.Warn("неудачная попытка получения токена сервиса", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.IssueServiceTokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(token.ExpiresIn.Seconds()),
+	}, nil
+}
+
+// CreateServiceClient регистрирует машинного клиента (только admin)
+func (s *serverAPI) CreateServiceClient(
+	ctx context.Context,
+	req *apiAuthServices.CreateServiceClientRequest,
+) (*apiAuthServices.CreateServiceClientResponse, error) {
+	l := s.log.With("op", "api_create_service_client")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetName() == "" {
+		return nil, missingField("name")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	client, secret, err := s.authApp.CreateServiceClient(ctx, req.GetName(), req.GetRoles())
+	if err != nil {
+		l.Debug("ошибка создания клиента", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("машинный клиент создан",
+		slog.String("client_id", client.ClientID),
+		slog.String("admin_id", caller.UserID),
+	)
+	return &apiAuthServices.CreateServiceClientResponse{
+		ClientId:     client.ClientID,
+		ClientSecret: secret,
+	}, nil
+}
+
+// RevokeServiceClient отзывает машинного клиента (только admin)
+func (s *serverAPI) RevokeServiceClient(
+	ctx context.Context,
+	req *apiAuthServices.RevokeServiceClientRequest,
+) (*apiAuthServices.RevokeServiceClientResponse, error) {
+	l := s.log.With("op", "api_revoke_service_client")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetClientId() == "" {
+		return nil, missingField("client_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.RevokeServiceClient(ctx, req.GetClientId()); err != nil {
+		return nil, err
+	}
+
+	l.Info("машинный клиент отозван",
+		slog.String("client_id", req.GetClientId()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return &apiAuthServices.RevokeServiceClientResponse{}, nil
+}
@@ -0,0 +1,120 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+)
+
+// ChangePassword меняет пароль текущего пользователя. Принимает и токен,
+// выданный при входе с истекшим паролем
+func (s *serverAPI) ChangePassword(
+	ctx context.Context,
+	req *apiAuthServices.ChangePasswordRequest,
+) (*apiAuthServices.ChangePasswordResponse, error) {
+	l := s.log.With("op", "api_change_password")
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	tokenInfo, err := s.authApp.VerifyPasswordChangeToken(ctx, token)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	if tokenInfo.IsService {
+		return nil, errServiceClientForbidden
+	}
+	if tokenInfo.IsApiKey {
+		return nil, errApiKeyForbidden
+	}
+	userID, err := uuid.Parse(tokenInfo.UserID)
+	if err != nil {
+		return nil, errInvalidTokenSubject
+	}
+	l = l.With("user_id", userID.String())
+
+	if err = s.validator.ValidateChangePasswordRequest(ctx, tokenInfo.Email, req.GetCurrentPassword(), req.GetNewPassword()); err != nil {
+		l.Debug("ошибка валидации", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	rsp, err := s.authApp.ChangePassword(ctx, userID, req.GetCurrentPassword(), req.GetNewPassword())
+	if err != nil {
+		l.Warn("неудачная попытка смены пароля", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("пароль изменен")
+	return &apiAuthServices.ChangePasswordResponse{
+		JwtToken: rsp.JWTToken,
+	}, nil
+}
+
+// ChangeEmail запрашивает смену email текущего пользователя
+func (s *serverAPI) ChangeEmail(
+	ctx context.Context,
+	req *apiAuthServices.ChangeEmailRequest,
+) (*apiAuthServices.ChangeEmailResponse, error) {
+	l := s.log.With("op", "api_change_email")
+
+	tokenInfo, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	if tokenInfo.IsApiKey {
+		return nil, errApiKeyForbidden
+	}
+	l = l.With("user_id", userID.String())
+
+	newEmail := s.authApp.NormalizeEmail(req.GetNewEmail())
+	if err = s.validator.ValidateChangeEmailRequest(ctx, req.GetPassword(), newEmail); err != nil {
+		l.Debug("ошибка валидации", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.ChangeEmail(ctx, userID, req.GetPassword(), newEmail); err != nil {
+		l.Warn("неудачная попытка смены email", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.ChangeEmailResponse{}, nil
+}
+
+// ConfirmEmailChange подтверждает новый email по токену из письма
+func (s *serverAPI) ConfirmEmailChange(
+	ctx context.Context,
+	req *apiAuthServices.ConfirmEmailChangeRequest,
+) (*apiAuthServices.ConfirmEmailChangeResponse, error) {
+	l := s.log.With("op", "api_confirm_email_change")
+
+	if req.GetToken() == "" {
+		l.Debug("ошибка валидации: пустой токен")
+		return nil, missingField("token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	rsp, err := s.authApp.ConfirmEmailChange(ctx, req.GetToken())
+	if err != nil {
+		l.Debug("ошибка подтверждения смены email", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("email изменен")
+	return &apiAuthServices.ConfirmEmailChangeResponse{
+		JwtToken:        rsp.JWTToken,
+		PasswordExpired: rsp.PasswordExpired,
+	}, nil
+}
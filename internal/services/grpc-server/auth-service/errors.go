@@ -0,0 +1,24 @@
+package auth_service
+
+import "auth-service/internal/errs"
+
+// Ошибки проверки запроса и прав вызывающего
+var (
+	errMissingToken            = errs.New(errs.KindUnauthenticated, "MISSING_TOKEN", "missing authorization token")
+	errInvalidAuthHeader       = errs.New(errs.KindUnauthenticated, "INVALID_AUTHORIZATION_HEADER", "invalid authorization header")
+	errInvalidTokenSubject     = errs.New(errs.KindUnauthenticated, "TOKEN_INVALID", "invalid user id in token")
+	errServiceClientForbidden  = errs.New(errs.KindPermissionDenied, "SERVICE_CLIENT_FORBIDDEN", "method is not available for service clients")
+	errApiKeyForbidden         = errs.New(errs.KindPermissionDenied, "API_KEY_FORBIDDEN", "method is not available for api keys")
+	errInsufficientPermissions = errs.New(errs.KindPermissionDenied, "INSUFFICIENT_PERMISSIONS", "insufficient permissions")
+	errCannotSuspendSelf       = errs.New(errs.KindInvalidArgument, "CANNOT_SUSPEND_SELF", "cannot suspend yourself")
+)
+
+// missingField ошибка пустого обязательного поля запроса
+func missingField(field string) error {
+	return errs.New(errs.KindInvalidArgument, "MISSING_FIELD", "empty "+field).With("field", field)
+}
+
+// invalidField ошибка поля запроса в неверном формате
+func invalidField(field string) error {
+	return errs.New(errs.KindInvalidArgument, "INVALID_FIELD", "invalid "+field).With("field", field)
+}
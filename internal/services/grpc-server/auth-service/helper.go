@@ -1 +1,124 @@
 package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/models"
+	"auth-service/internal/services/challenge"
+	"context"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	roleAdmin     = "admin"
+	roleModerator = "moderator"
+)
+
+// bearerToken извлекает токен из метаданных "authorization: Bearer <token>"
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", errMissingToken
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", errMissingToken
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return "", errInvalidAuthHeader
+	}
+
+	return token, nil
+}
+
+// authenticate проверяет токен из метаданных "authorization: Bearer <token>"
+func (s *serverAPI) authenticate(ctx context.Context) (*models.TokenInfo, error) {
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenInfo, err := s.authApp.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenInfo, nil
+}
+
+// authenticateUser проверяет, что вызывающий - пользователь (а не машинный клиент), и возвращает его ID
+func (s *serverAPI) authenticateUser(ctx context.Context) (*models.TokenInfo, uuid.UUID, error) {
+	tokenInfo, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	if tokenInfo.IsService {
+		return nil, uuid.Nil, errServiceClientForbidden
+	}
+
+	userID, err := uuid.Parse(tokenInfo.UserID)
+	if err != nil {
+		return nil, uuid.Nil, errInvalidTokenSubject
+	}
+
+	return tokenInfo, userID, nil
+}
+
+// authorize проверяет токен и наличие у вызывающего одной из ролей
+func (s *serverAPI) authorize(ctx context.Context, roles ...string) (*models.TokenInfo, error) {
+	tokenInfo, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if slices.Contains(tokenInfo.Roles, role) {
+			return tokenInfo, nil
+		}
+	}
+
+	return nil, errInsufficientPermissions
+}
+
+// authorizeUser проверяет, что вызывающий - пользователь с одной из ролей, и возвращает его ID.
+// Нужен там, где ID вызывающего сохраняется в базе: у машинного клиента его нет
+func (s *serverAPI) authorizeUser(ctx context.Context, roles ...string) (*models.TokenInfo, uuid.UUID, error) {
+	tokenInfo, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	for _, role := range roles {
+		if slices.Contains(tokenInfo.Roles, role) {
+			return tokenInfo, userID, nil
+		}
+	}
+
+	return nil, uuid.Nil, errInsufficientPermissions
+}
+
+// challengeSolution переводит решение испытания из запроса, добавляя адрес клиента для провайдера капчи
+func challengeSolution(ctx context.Context, req *apiAuthServices.ChallengeSolution) challenge.Solution {
+	solution := challenge.Solution{
+		CaptchaToken:         req.GetCaptchaToken(),
+		ProofOfWorkChallenge: req.GetProofOfWork().GetChallenge(),
+		ProofOfWorkNonce:     req.GetProofOfWork().GetNonce(),
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			solution.RemoteIP = host
+		}
+	}
+
+	return solution
+}
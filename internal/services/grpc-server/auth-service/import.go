@@ -0,0 +1,73 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"bytes"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// timeOutImport импорт хеширует открытые пароли, поэтому ему нужно больше времени
+const timeOutImport = 10 * time.Minute
+
+var importFormats = map[apiAuthServices.ImportUsersRequest_Format]string{
+	apiAuthServices.ImportUsersRequest_CSV:   auth.ImportFormatCSV,
+	apiAuthServices.ImportUsersRequest_JSONL: auth.ImportFormatJSONL,
+}
+
+// ImportUsers импортирует пользователей из CSV или JSONL
+func (s *serverAPI) ImportUsers(
+	ctx context.Context,
+	req *apiAuthServices.ImportUsersRequest,
+) (*apiAuthServices.ImportUsersResponse, error) {
+	l := s.log.With("op", "api_import_users")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutImport)
+	defer cancel()
+
+	opts := auth.ImportOptions{
+		DryRun:        req.GetDryRun(),
+		Overwrite:     req.GetOverwrite(),
+		CheckPassword: s.validator.ValidatePassword,
+	}
+	report, err := s.authApp.ImportUsers(ctx, bytes.NewReader(req.GetData()), importFormats[req.GetFormat()], opts)
+	if err != nil {
+		l.Warn("импорт пользователей прерван", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("импорт пользователей выполнен администратором",
+		slog.String("admin_id", caller.UserID),
+		slog.Bool("dry_run", report.DryRun),
+		slog.Bool("overwrite", req.GetOverwrite()),
+		slog.Int("created", report.Created),
+		slog.Int("updated", report.Updated),
+		slog.Int("failed", report.Failed),
+	)
+
+	resp := &apiAuthServices.ImportUsersResponse{
+		DryRun:    report.DryRun,
+		Total:     int32(report.Total),
+		Created:   int32(report.Created),
+		Updated:   int32(report.Updated),
+		Unchanged: int32(report.Unchanged),
+		Failed:    int32(report.Failed),
+	}
+	for _, e := range report.Errors {
+		resp.Errors = append(resp.Errors, &apiAuthServices.ImportRowError{
+			Line:  int32(e.Line),
+			Email: e.Email,
+			Error: e.Error,
+		})
+	}
+	return resp, nil
+}
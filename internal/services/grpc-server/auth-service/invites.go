@@ -0,0 +1,132 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// CreateInvite создает приглашение для регистрации (только admin)
+func (s *serverAPI) CreateInvite(
+	ctx context.Context,
+	req *apiAuthServices.CreateInviteRequest,
+) (*apiAuthServices.CreateInviteResponse, error) {
+	l := s.log.With("op", "api_create_invite")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetMaxUses() < 0 {
+		return nil, invalidField("max_uses")
+	}
+
+	var email string
+	if req.Email != nil {
+		email = s.authApp.NormalizeEmail(req.GetEmail())
+		if email == "" {
+			return nil, invalidField("email")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	invite, code, err := s.authApp.CreateInvite(ctx, caller.UserID, email, req.GetRoles(), int(req.GetMaxUses()), optionalTime(req.ExpiresAt))
+	if err != nil {
+		l.Debug("ошибка создания приглашения", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("приглашение создано",
+		slog.String("invite_id", invite.InviteId.String()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return &apiAuthServices.CreateInviteResponse{
+		Invite: inviteToProto(invite),
+		Code:   code,
+	}, nil
+}
+
+// ListInvites возвращает неотозванные приглашения (только admin)
+func (s *serverAPI) ListInvites(
+	ctx context.Context,
+	_ *apiAuthServices.ListInvitesRequest,
+) (*apiAuthServices.ListInvitesResponse, error) {
+	l := s.log.With("op", "api_list_invites")
+
+	if _, err := s.authorize(ctx, roleAdmin); err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	invites, err := s.authApp.ListInvites(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &apiAuthServices.ListInvitesResponse{Invites: make([]*apiAuthServices.Invite, 0, len(invites))}
+	for _, invite := range invites {
+		rsp.Invites = append(rsp.Invites, inviteToProto(invite))
+	}
+	return rsp, nil
+}
+
+// RevokeInvite отзывает приглашение (только admin)
+func (s *serverAPI) RevokeInvite(
+	ctx context.Context,
+	req *apiAuthServices.RevokeInviteRequest,
+) (*apiAuthServices.RevokeInviteResponse, error) {
+	l := s.log.With("op", "api_revoke_invite")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	inviteID, err := uuid.Parse(req.GetInviteId())
+	if err != nil {
+		return nil, invalidField("invite_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.RevokeInvite(ctx, inviteID); err != nil {
+		return nil, err
+	}
+
+	l.Info("приглашение отозвано",
+		slog.String("invite_id", inviteID.String()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return &apiAuthServices.RevokeInviteResponse{}, nil
+}
+
+func inviteToProto(invite *models.Invite) *apiAuthServices.Invite {
+	pb := &apiAuthServices.Invite{
+		InviteId:  invite.InviteId.String(),
+		Email:     invite.Email.String,
+		Roles:     invite.Roles,
+		MaxUses:   int32(invite.MaxUses),
+		Uses:      int32(invite.Uses),
+		CreatedAt: timestamppb.New(invite.CreatedAt),
+	}
+	if invite.CreatedBy.Valid {
+		pb.CreatedBy = invite.CreatedBy.UUID.String()
+	}
+	if invite.ExpiresAt.Valid {
+		pb.ExpiresAt = timestamppb.New(invite.ExpiresAt.Time)
+	}
+	return pb
+}
@@ -2,12 +2,14 @@ package auth_service
 
 import (
 	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/errs"
 	"auth-service/internal/models"
 	"auth-service/pkg/logger"
 	"context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
+	"strings"
 	"time"
 )
 
@@ -29,12 +31,21 @@ func (s *serverAPI) Register(
 	ctx context.Context,
 	req *apiAuthServices.RegisterRequest,
 ) (*apiAuthServices.RegisterResponse, error) {
-	// Создаем логгер с хешированным email для повторного использования
-	hashedEmail := s.authApp.HashEmail(req.Email)
+	// Приводим email к виду, в котором он хранится, и создаем логгер с его хешем
+	email := s.authApp.NormalizeEmail(req.GetEmail())
+	hashedEmail := s.authApp.HashEmail(email)
 	l := s.log.With("email_hash", hashedEmail, "op", "api_register")
 
+	// Без решения испытания или с поддельным решением proof-of-work запрос отклоняется до
+	// валидации: иначе валидатор с проверкой утечек пароля и MX записей был бы доступен ботам
+	solution := challengeSolution(ctx, req.GetChallenge())
+	if err := s.challenge.Require(solution); err != nil {
+		l.Debug("нет решения испытания или оно неверно", logger.Err(err))
+		return nil, err
+	}
+
 	// Валидация запроса
-	if err := s.validator.ValidateRegisterRequest(req.Email, req.Password); err != nil {
+	if err := s.validator.ValidateRegisterRequest(ctx, email, req.Password); err != nil {
 		l.Debug("ошибка валидации", logger.Err(err))
 		return nil, err
 	}
@@ -42,9 +53,16 @@ func (s *serverAPI) Register(
 	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
 	defer cancel()
 
+	// Решение проверяется после валидации: оно одноразовое и не должно сгорать на ошибке в форме
+	if err := s.challenge.Verify(ctx, solution); err != nil {
+		l.Debug("испытание не пройдено", logger.Err(err))
+		return nil, err
+	}
+
 	reqRegister := &models.AuthRequest{
-		Email:    req.GetEmail(),
-		Password: req.GetPassword(),
+		Email:      email,
+		Password:   req.GetPassword(),
+		InviteCode: strings.TrimSpace(req.GetInviteCode()),
 	}
 
 	// Выполнение регистрации через сервис
@@ -66,20 +84,21 @@ func (s *serverAPI) Login(
 	ctx context.Context,
 	req *apiAuthServices.LoginRequest,
 ) (*apiAuthServices.LoginResponse, error) {
-	// Создаем логгер с хешированным email для повторного использования
-	hashedEmail := s.authApp.HashEmail(req.Email)
+	// Приводим email к виду, в котором он хранится, и создаем логгер с его хешем
+	email := s.authApp.NormalizeEmail(req.GetEmail())
+	hashedEmail := s.authApp.HashEmail(email)
 	l := s.log.With("email_hash", hashedEmail, "op", "api_login")
 
 	l.Debug("попытка входа в систему")
 
 	// Валидация входных данных
-	if req.GetEmail() == "" {
+	if email == "" {
 		l.Debug("ошибка валидации: пустой email")
-		return nil, status.Error(codes.InvalidArgument, "empty email")
+		return nil, missingField("email")
 	}
 	if req.GetPassword() == "" {
 		l.Debug("ошибка валидации: пустой пароль")
-		return nil, status.Error(codes.InvalidArgument, "empty password")
+		return nil, missingField("password")
 	}
 
 	// Установка таймаута для контекста
@@ -87,8 +106,10 @@ func (s *serverAPI) Login(
 	defer cancel()
 
 	reqLogin := &models.AuthRequest{
-		Email:    req.GetEmail(),
+		Email:    email,
 		Password: req.GetPassword(),
+		ClientID: req.GetClientId(),
+		Nonce:    req.GetNonce(),
 	}
 
 	// Выполнение входа через сервис
@@ -101,11 +122,141 @@ func (s *serverAPI) Login(
 	}
 
 	l.Info("успешный вход в систему")
+	resp := &apiAuthServices.LoginResponse{
+		JwtToken:        rsp.JWTToken,
+		PasswordExpired: rsp.PasswordExpired,
+	}
+	if rsp.IDToken != "" {
+		resp.IdToken = &rsp.IDToken
+	}
+	return resp, nil
+}
+
+// LoginWithProvider логинет пользователя по ID токену внешнего провайдера
+func (s *serverAPI) LoginWithProvider(
+	ctx context.Context,
+	req *apiAuthServices.LoginWithProviderRequest,
+) (*apiAuthServices.LoginResponse, error) {
+	l := s.log.With("provider", req.GetProvider(), "op", "api_login_with_provider")
+
+	l.Debug("попытка входа через внешний провайдер")
+
+	// Валидация входных данных
+	if req.GetProvider() == "" {
+		l.Debug("ошибка валидации: пустой провайдер")
+		return nil, missingField("provider")
+	}
+	if req.GetIdToken() == "" {
+		l.Debug("ошибка валидации: пустой ID токен")
+		return nil, missingField("id_token")
+	}
+
+	// Установка таймаута для контекста
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	rsp, err := s.authApp.LoginWithProvider(ctx, req.GetProvider(), req.GetIdToken(), req.GetNonce(), s.validator.ValidateEmail)
+	if err != nil {
+		l.Warn("неудачная попытка входа через внешний провайдер", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("успешный вход через внешний провайдер")
 	return &apiAuthServices.LoginResponse{
-		JwtToken: rsp.JWTToken,
+		JwtToken:        rsp.JWTToken,
+		PasswordExpired: rsp.PasswordExpired,
 	}, nil
 }
 
+// RequestMagicLink отправляет ссылку для входа без пароля
+func (s *serverAPI) RequestMagicLink(
+	ctx context.Context,
+	req *apiAuthServices.RequestMagicLinkRequest,
+) (*apiAuthServices.RequestMagicLinkResponse, error) {
+	email := s.authApp.NormalizeEmail(req.GetEmail())
+	hashedEmail := s.authApp.HashEmail(email)
+	l := s.log.With("email_hash", hashedEmail, "op", "api_request_magic_link")
+
+	if email == "" {
+		l.Debug("ошибка валидации: пустой email")
+		return nil, missingField("email")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err := s.authApp.RequestMagicLink(ctx, email); err != nil {
+		l.Warn("ошибка запроса ссылки для входа", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.RequestMagicLinkResponse{}, nil
+}
+
+// ConsumeMagicLink логинет пользователя по токену из ссылки
+func (s *serverAPI) ConsumeMagicLink(
+	ctx context.Context,
+	req *apiAuthServices.ConsumeMagicLinkRequest,
+) (*apiAuthServices.LoginResponse, error) {
+	l := s.log.With("op", "api_consume_magic_link")
+
+	if req.GetToken() == "" {
+		l.Debug("ошибка валидации: пустой токен")
+		return nil, missingField("token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	rsp, err := s.authApp.ConsumeMagicLink(ctx, req.GetToken())
+	if err != nil {
+		l.Warn("неудачная попытка входа по ссылке", logger.Err(err))
+		return nil, err
+	}
+
+	l.Info("успешный вход по ссылке")
+	return &apiAuthServices.LoginResponse{
+		JwtToken:        rsp.JWTToken,
+		PasswordExpired: rsp.PasswordExpired,
+	}, nil
+}
+
+// Authorize выдает код авторизации OIDC пользователю, вошедшему на странице входа
+func (s *serverAPI) Authorize(
+	ctx context.Context,
+	req *apiAuthServices.AuthorizeRequest,
+) (*apiAuthServices.AuthorizeResponse, error) {
+	l := s.log.With("op", "api_authorize")
+
+	tokenInfo, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("ошибка аутентификации", logger.Err(err))
+		return nil, err
+	}
+	// Время входа для auth_time берется из JWT; API ключ не подтверждает вход пользователя
+	if tokenInfo.IsApiKey {
+		l.Debug("авторизация клиента по API ключу")
+		return nil, errApiKeyForbidden
+	}
+	l = l.With("user_id", userID.String())
+
+	if req.GetRequest() == "" {
+		l.Debug("ошибка валидации: пустой запрос авторизации")
+		return nil, missingField("request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	redirectURI, err := s.authApp.Authorize(ctx, userID, tokenInfo.AuthTime, req.GetRequest())
+	if err != nil {
+		l.Warn("ошибка выдачи кода авторизации", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.AuthorizeResponse{RedirectUri: redirectURI}, nil
+}
+
 // VerifyToken проверяет JWT токен и возвращает информацию о пользователе
 func (s *serverAPI) VerifyToken(
 	ctx context.Context,
@@ -117,7 +268,16 @@ func (s *serverAPI) VerifyToken(
 	// Валидация входных данных
 	if req.GetToken() == "" {
 		l.Debug("ошибка валидации: пустой токен")
-		return nil, status.Error(codes.InvalidArgument, "empty token")
+		return nil, missingField("token")
+	}
+
+	var orgID uuid.UUID
+	if req.OrganisationId != nil {
+		id, err := uuid.Parse(req.GetOrganisationId())
+		if err != nil {
+			return nil, invalidField("organisation_id")
+		}
+		orgID = id
 	}
 
 	// Установка таймаута для контекста
@@ -127,23 +287,85 @@ func (s *serverAPI) VerifyToken(
 	// Проверка токена через сервис
 	tokenInfo, err := s.authApp.VerifyToken(ctx, req.GetToken())
 	if err != nil {
+		// Сбой хранилища - не ответ "токен недействителен", а ошибка вызова
+		if errs.KindOf(err) == errs.KindInternal {
+			return nil, err
+		}
 		l.Debug("ошибка проверки токена", logger.Err(err))
 		return &apiAuthServices.VerifyTokenResponse{
 			Valid: false,
-			Error: status.New(codes.Unauthenticated, err.Error()).Proto(),
+			Error: errs.Status(err).Proto(),
 		}, nil
 	}
 
+	// Роли в организации не влияют на действительность токена: не участнику вернется пустой список
+	var orgRoles []string
+	if req.OrganisationId != nil {
+		if orgRoles, err = s.authApp.OrganisationRoles(ctx, tokenInfo, orgID); err != nil {
+			return nil, err
+		}
+	}
+
 	l.Info("токен успешно проверен",
 		slog.String("email", s.authApp.HashEmail(tokenInfo.Email)),
+		slog.Bool("is_service", tokenInfo.IsService),
 	)
 
 	// Формируем ответ с данными пользователя
 	return &apiAuthServices.VerifyTokenResponse{
-		Valid:  true,
-		UserId: tokenInfo.UserID,
-		Email:  tokenInfo.Email,
-		Roles:  tokenInfo.Roles,
-		Error:  nil,
+		Valid:             true,
+		UserId:            tokenInfo.UserID,
+		Email:             tokenInfo.Email,
+		Roles:             tokenInfo.Roles,
+		Error:             nil,
+		IsService:         tokenInfo.IsService,
+		TenantId:          tokenInfo.TenantID,
+		OrganisationRoles: orgRoles,
 	}, nil
 }
+
+// GetPasswordPolicy возвращает требования к паролю. Доступен без авторизации
+func (s *serverAPI) GetPasswordPolicy(
+	_ context.Context,
+	_ *apiAuthServices.GetPasswordPolicyRequest,
+) (*apiAuthServices.PasswordPolicy, error) {
+	p := s.validator.PasswordPolicy()
+
+	return &apiAuthServices.PasswordPolicy{
+		MinLength:           int32(p.MinLength),
+		MaxLength:           int32(p.MaxLength),
+		MinUniqueChars:      int32(p.MinUniqueChars),
+		RequireUpper:        p.RequireUpper,
+		RequireLower:        p.RequireLower,
+		RequireNumber:       p.RequireNumber,
+		RequireSpecial:      p.RequireSpecial,
+		DisallowedSequences: p.DisallowedSequences,
+		SequenceLength:      int32(p.SequenceLength),
+		HistoryDepth:        int32(p.HistoryDepth),
+		MaxAgeSeconds:       int64(p.MaxAge.Seconds()),
+	}, nil
+}
+
+// GetRegistrationChallenge возвращает способы пройти испытание перед Register и новую задачу
+// proof-of-work, если он включен. Доступен без авторизации
+func (s *serverAPI) GetRegistrationChallenge(
+	_ context.Context,
+	_ *apiAuthServices.GetRegistrationChallengeRequest,
+) (*apiAuthServices.RegistrationChallenge, error) {
+	info := s.challenge.Info()
+	rsp := &apiAuthServices.RegistrationChallenge{
+		Required:        info.Required,
+		CaptchaProvider: info.CaptchaProvider,
+		CaptchaSiteKey:  info.CaptchaSiteKey,
+	}
+
+	if puzzle, ok := s.challenge.Issue(); ok {
+		rsp.ProofOfWork = &apiAuthServices.ProofOfWorkChallenge{
+			Challenge:  puzzle.Challenge,
+			Difficulty: uint32(puzzle.Difficulty),
+			ExpiresAt:  timestamppb.New(puzzle.ExpiresAt),
+		}
+	}
+
+	return rsp, nil
+}
@@ -0,0 +1,368 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/models"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// orgActor проверяет токен и возвращает вызывающего для операций с организацией.
+// Машинному клиенту доступны только операции admin. API ключ с ограниченным набором ролей
+// не получает ролей владельца в организациях, поэтому ему тоже доступны только операции admin
+func (s *serverAPI) orgActor(ctx context.Context) (auth.OrgActor, error) {
+	tokenInfo, err := s.authenticate(ctx)
+	if err != nil {
+		return auth.OrgActor{}, err
+	}
+
+	actor := auth.OrgActor{Admin: slices.Contains(tokenInfo.Roles, roleAdmin)}
+	if tokenInfo.ApiKeyScoped && !actor.Admin {
+		return auth.OrgActor{}, errApiKeyForbidden
+	}
+	if tokenInfo.IsService {
+		if !actor.Admin {
+			return auth.OrgActor{}, errServiceClientForbidden
+		}
+		return actor, nil
+	}
+
+	if actor.UserID, err = uuid.Parse(tokenInfo.UserID); err != nil {
+		return auth.OrgActor{}, errInvalidTokenSubject
+	}
+	return actor, nil
+}
+
+// CreateOrganisation создает организацию (только admin)
+func (s *serverAPI) CreateOrganisation(
+	ctx context.Context,
+	req *apiAuthServices.CreateOrganisationRequest,
+) (*apiAuthServices.Organisation, error) {
+	l := s.log.With("op", "api_create_organisation")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, missingField("name")
+	}
+
+	// Без owner_id владельцем становится вызывающий; машинный клиент пользователем не является
+	ownerRef := req.GetOwnerId()
+	if req.OwnerId == nil {
+		if caller.IsService {
+			return nil, missingField("owner_id")
+		}
+		ownerRef = caller.UserID
+	}
+	ownerID, err := uuid.Parse(ownerRef)
+	if err != nil {
+		return nil, invalidField("owner_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	org, err := s.authApp.CreateOrganisation(ctx, caller.UserID, name, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("организация создана",
+		slog.String("org_id", org.OrgId.String()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return organisationToProto(org), nil
+}
+
+// ListMyOrganisations возвращает организации текущего пользователя с его ролями
+func (s *serverAPI) ListMyOrganisations(
+	ctx context.Context,
+	_ *apiAuthServices.ListMyOrganisationsRequest,
+) (*apiAuthServices.ListMyOrganisationsResponse, error) {
+	l := s.log.With("op", "api_list_my_organisations")
+
+	_, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	memberships, err := s.authApp.ListMyOrganisations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &apiAuthServices.ListMyOrganisationsResponse{
+		Organisations: make([]*apiAuthServices.OrganisationMembership, 0, len(memberships)),
+	}
+	for _, membership := range memberships {
+		rsp.Organisations = append(rsp.Organisations, membershipToProto(membership))
+	}
+	return rsp, nil
+}
+
+// ListOrganisationMembers возвращает участников организации
+func (s *serverAPI) ListOrganisationMembers(
+	ctx context.Context,
+	req *apiAuthServices.ListOrganisationMembersRequest,
+) (*apiAuthServices.ListOrganisationMembersResponse, error) {
+	l := s.log.With("op", "api_list_organisation_members")
+
+	actor, err := s.orgActor(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, invalidField("org_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	members, err := s.authApp.ListOrganisationMembers(ctx, actor, orgID)
+	if err != nil {
+		l.Debug("ошибка получения участников организации", logger.Err(err))
+		return nil, err
+	}
+
+	rsp := &apiAuthServices.ListOrganisationMembersResponse{
+		Members: make([]*apiAuthServices.OrganisationMember, 0, len(members)),
+	}
+	for _, member := range members {
+		rsp.Members = append(rsp.Members, memberToProto(member))
+	}
+	return rsp, nil
+}
+
+// InviteToOrganisation приглашает пользователя в организацию по email
+func (s *serverAPI) InviteToOrganisation(
+	ctx context.Context,
+	req *apiAuthServices.InviteToOrganisationRequest,
+) (*apiAuthServices.OrganisationInvitation, error) {
+	l := s.log.With("op", "api_invite_to_organisation")
+
+	actor, err := s.orgActor(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, invalidField("org_id")
+	}
+	if req.GetEmail() == "" {
+		return nil, missingField("email")
+	}
+	email := s.authApp.NormalizeEmail(req.GetEmail())
+	if email == "" {
+		return nil, invalidField("email")
+	}
+	if len(req.GetRoles()) == 0 {
+		return nil, missingField("roles")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	invitation, err := s.authApp.InviteToOrganisation(ctx, actor, orgID, email, req.GetRoles())
+	if err != nil {
+		l.Debug("ошибка приглашения в организацию", logger.Err(err))
+		return nil, err
+	}
+
+	return invitationToProto(invitation), nil
+}
+
+// RevokeOrganisationInvitation отзывает приглашение в организацию
+func (s *serverAPI) RevokeOrganisationInvitation(
+	ctx context.Context,
+	req *apiAuthServices.RevokeOrganisationInvitationRequest,
+) (*apiAuthServices.RevokeOrganisationInvitationResponse, error) {
+	l := s.log.With("op", "api_revoke_organisation_invitation")
+
+	actor, err := s.orgActor(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, invalidField("org_id")
+	}
+	invitationID, err := uuid.Parse(req.GetInvitationId())
+	if err != nil {
+		return nil, invalidField("invitation_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.RevokeOrganisationInvitation(ctx, actor, orgID, invitationID); err != nil {
+		return nil, err
+	}
+
+	return &apiAuthServices.RevokeOrganisationInvitationResponse{}, nil
+}
+
+// AcceptOrganisationInvitation принимает приглашение из письма текущим пользователем
+func (s *serverAPI) AcceptOrganisationInvitation(
+	ctx context.Context,
+	req *apiAuthServices.AcceptOrganisationInvitationRequest,
+) (*apiAuthServices.OrganisationMembership, error) {
+	l := s.log.With("op", "api_accept_organisation_invitation")
+
+	_, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetToken() == "" {
+		return nil, missingField("token")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	membership, err := s.authApp.AcceptOrganisationInvitation(ctx, userID, req.GetToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return membershipToProto(membership), nil
+}
+
+// UpdateOrganisationMember заменяет роли участника организации
+func (s *serverAPI) UpdateOrganisationMember(
+	ctx context.Context,
+	req *apiAuthServices.UpdateOrganisationMemberRequest,
+) (*apiAuthServices.OrganisationMember, error) {
+	l := s.log.With("op", "api_update_organisation_member")
+
+	actor, err := s.orgActor(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, invalidField("org_id")
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+	if len(req.GetRoles()) == 0 {
+		return nil, missingField("roles")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	member, err := s.authApp.UpdateOrganisationMember(ctx, actor, orgID, userID, req.GetRoles())
+	if err != nil {
+		l.Debug("ошибка изменения ролей участника", logger.Err(err))
+		return nil, err
+	}
+
+	return memberToProto(member), nil
+}
+
+// RemoveOrganisationMember исключает участника из организации или выводит из нее текущего пользователя
+func (s *serverAPI) RemoveOrganisationMember(
+	ctx context.Context,
+	req *apiAuthServices.RemoveOrganisationMemberRequest,
+) (*apiAuthServices.RemoveOrganisationMemberResponse, error) {
+	l := s.log.With("op", "api_remove_organisation_member")
+
+	actor, err := s.orgActor(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	orgID, err := uuid.Parse(req.GetOrgId())
+	if err != nil {
+		return nil, invalidField("org_id")
+	}
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	if err = s.authApp.RemoveOrganisationMember(ctx, actor, orgID, userID); err != nil {
+		l.Debug("ошибка исключения участника", logger.Err(err))
+		return nil, err
+	}
+
+	return &apiAuthServices.RemoveOrganisationMemberResponse{}, nil
+}
+
+func organisationToProto(org *models.Organisation) *apiAuthServices.Organisation {
+	pb := &apiAuthServices.Organisation{
+		OrgId:     org.OrgId.String(),
+		Name:      org.Name,
+		CreatedAt: timestamppb.New(org.CreatedAt),
+	}
+	if org.CreatedBy.Valid {
+		pb.CreatedBy = org.CreatedBy.UUID.String()
+	}
+	return pb
+}
+
+func membershipToProto(membership *models.OrganisationMembership) *apiAuthServices.OrganisationMembership {
+	return &apiAuthServices.OrganisationMembership{
+		Organisation: organisationToProto(&membership.Organisation),
+		Roles:        membership.Roles,
+		JoinedAt:     timestamppb.New(membership.JoinedAt),
+	}
+}
+
+func memberToProto(member *models.OrganisationMember) *apiAuthServices.OrganisationMember {
+	return &apiAuthServices.OrganisationMember{
+		UserId:    member.UserId.String(),
+		Email:     member.Email,
+		Username:  member.Username,
+		Roles:     member.Roles,
+		JoinedAt:  timestamppb.New(member.JoinedAt),
+		UpdatedAt: timestamppb.New(member.UpdatedAt),
+	}
+}
+
+func invitationToProto(invitation *models.OrganisationInvitation) *apiAuthServices.OrganisationInvitation {
+	pb := &apiAuthServices.OrganisationInvitation{
+		InvitationId: invitation.InvitationId.String(),
+		OrgId:        invitation.OrgId.String(),
+		Email:        invitation.Email,
+		Roles:        invitation.Roles,
+		CreatedAt:    timestamppb.New(invitation.CreatedAt),
+		ExpiresAt:    timestamppb.New(invitation.ExpiresAt),
+	}
+	if invitation.InvitedBy.Valid {
+		pb.InvitedBy = invitation.InvitedBy.UUID.String()
+	}
+	return pb
+}
@@ -0,0 +1,183 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/models"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"time"
+)
+
+// GetMe возвращает профиль текущего пользователя
+func (s *serverAPI) GetMe(
+	ctx context.Context,
+	_ *apiAuthServices.GetMeRequest,
+) (*apiAuthServices.UserProfile, error) {
+	l := s.log.With("op", "api_get_me")
+
+	_, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	user, err := s.authApp.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(user), nil
+}
+
+// GetUser возвращает профиль любого пользователя (только admin)
+func (s *serverAPI) GetUser(
+	ctx context.Context,
+	req *apiAuthServices.GetUserRequest,
+) (*apiAuthServices.UserProfile, error) {
+	l := s.log.With("op", "api_get_user")
+
+	caller, err := s.authorize(ctx, roleAdmin)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	user, err := s.authApp.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Info("профиль пользователя выдан администратору",
+		slog.String("user_id", userID.String()),
+		slog.String("admin_id", caller.UserID),
+	)
+	return userToProto(user), nil
+}
+
+// ListUsers ищет пользователей по фильтрам (только admin)
+func (s *serverAPI) ListUsers(
+	ctx context.Context,
+	req *apiAuthServices.ListUsersRequest,
+) (*apiAuthServices.ListUsersResponse, error) {
+	l := s.log.With("op", "api_list_users")
+
+	if _, err := s.authorize(ctx, roleAdmin); err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if req.GetPageSize() < 0 {
+		return nil, invalidField("page_size")
+	}
+
+	filter := models.ListUsersFilter{
+		EmailPrefix:    req.GetEmailPrefix(),
+		Role:           req.GetRole(),
+		Status:         req.GetStatus(),
+		CreatedFrom:    optionalTime(req.CreatedFrom),
+		CreatedTo:      optionalTime(req.CreatedTo),
+		LastLoginFrom:  optionalTime(req.LastLoginFrom),
+		LastLoginTo:    optionalTime(req.LastLoginTo),
+		SortBy:         userSortFields[req.GetSortBy()],
+		Descending:     req.GetDescending(),
+		PageSize:       int(req.GetPageSize()),
+		Cursor:         req.GetCursor(),
+		WithTotalCount: req.GetWithTotalCount(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	page, err := s.authApp.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &apiAuthServices.ListUsersResponse{
+		Users:      make([]*apiAuthServices.UserProfile, 0, len(page.Users)),
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+	}
+	for _, user := range page.Users {
+		rsp.Users = append(rsp.Users, userToProto(user))
+	}
+	return rsp, nil
+}
+
+// UpdateUsername меняет username текущего пользователя
+func (s *serverAPI) UpdateUsername(
+	ctx context.Context,
+	req *apiAuthServices.UpdateUsernameRequest,
+) (*apiAuthServices.UserProfile, error) {
+	l := s.log.With("op", "api_update_username")
+
+	_, userID, err := s.authenticateUser(ctx)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+
+	if err = s.validator.ValidateUsername(ctx, req.GetUsername()); err != nil {
+		l.Debug("ошибка валидации", logger.Err(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	user, err := s.authApp.UpdateUsername(ctx, userID, req.GetUsername())
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(user), nil
+}
+
+var userSortFields = map[apiAuthServices.ListUsersRequest_SortBy]string{
+	apiAuthServices.ListUsersRequest_CREATED_AT:    models.UserSortCreatedAt,
+	apiAuthServices.ListUsersRequest_EMAIL:         models.UserSortEmail,
+	apiAuthServices.ListUsersRequest_LAST_LOGIN_AT: models.UserSortLastLoginAt,
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func userToProto(user *models.User) *apiAuthServices.UserProfile {
+	pb := &apiAuthServices.UserProfile{
+		UserId:    user.UserId.String(),
+		Username:  user.Username,
+		Email:     user.Email,
+		Roles:     user.Roles,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		Status:    user.Status,
+	}
+	if user.StatusReason.Valid {
+		pb.StatusReason = &user.StatusReason.String
+	}
+	if user.SuspendedUntil.Valid {
+		pb.SuspendedUntil = timestamppb.New(user.SuspendedUntil.Time)
+	}
+	if user.LastLoginAt.Valid {
+		pb.LastLoginAt = timestamppb.New(user.LastLoginAt.Time)
+	}
+	return pb
+}
@@ -3,6 +3,7 @@ package auth_service
 import (
 	apiAuthServices "auth-service/generate/auth-service"
 	"auth-service/internal/services/auth"
+	"auth-service/internal/services/challenge"
 	"auth-service/internal/services/validator"
 	"google.golang.org/grpc"
 	"log/slog"
@@ -12,6 +13,7 @@ type serverAPI struct {
 	log       *slog.Logger
 	authApp   *auth.Service
 	validator *validator.Validator
+	challenge *challenge.Service
 	apiAuthServices.UnimplementedAuthServiceServer
 }
 
@@ -21,6 +23,12 @@ func Register(
 	log *slog.Logger,
 	authApp *auth.Service,
 	validator *validator.Validator,
+	challenge *challenge.Service,
 ) {
-	apiAuthServices.RegisterAuthServiceServer(gRPC, &serverAPI{log: log.With("proc", "gRPC server"), authApp: authApp, validator: validator})
+	apiAuthServices.RegisterAuthServiceServer(gRPC, &serverAPI{
+		log:       log.With("proc", "gRPC server"),
+		authApp:   authApp,
+		validator: validator,
+		challenge: challenge,
+	})
 }
@@ -0,0 +1,75 @@
+package auth_service
+
+import (
+	apiAuthServices "auth-service/generate/auth-service"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"context"
+	"github.com/google/uuid"
+)
+
+// SuspendUser блокирует аккаунт пользователя (admin или moderator)
+func (s *serverAPI) SuspendUser(
+	ctx context.Context,
+	req *apiAuthServices.SuspendUserRequest,
+) (*apiAuthServices.UserProfile, error) {
+	l := s.log.With("op", "api_suspend_user")
+
+	caller, moderatorID, err := s.authorizeUser(ctx, roleAdmin, roleModerator)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	moderator := auth.Moderator{UserID: moderatorID, Roles: caller.Roles}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+	if userID == moderatorID {
+		return nil, errCannotSuspendSelf
+	}
+	if req.GetReason() == "" {
+		return nil, missingField("reason")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	user, err := s.authApp.SuspendUser(ctx, userID, req.GetReason(), optionalTime(req.Until), req.GetDisable(), moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(user), nil
+}
+
+// ReactivateUser снимает блокировку с аккаунта (admin или moderator)
+func (s *serverAPI) ReactivateUser(
+	ctx context.Context,
+	req *apiAuthServices.ReactivateUserRequest,
+) (*apiAuthServices.UserProfile, error) {
+	l := s.log.With("op", "api_reactivate_user")
+
+	caller, moderatorID, err := s.authorizeUser(ctx, roleAdmin, roleModerator)
+	if err != nil {
+		l.Debug("отказ в доступе", logger.Err(err))
+		return nil, err
+	}
+	moderator := auth.Moderator{UserID: moderatorID, Roles: caller.Roles}
+
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, invalidField("user_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeOutAuth)
+	defer cancel()
+
+	user, err := s.authApp.ReactivateUser(ctx, userID, req.GetReason(), moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	return userToProto(user), nil
+}
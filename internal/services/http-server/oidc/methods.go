@@ -0,0 +1,268 @@
+package oidc
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/services/auth"
+	"auth-service/pkg/logger"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	timeOutUserInfo = 10 * time.Second
+	timeOutToken    = 10 * time.Second
+)
+
+// Discovery отдает документ /.well-known/openid-configuration
+func (s *serverAPI) Discovery(w http.ResponseWriter, r *http.Request) {
+	doc, err := s.authApp.Discovery()
+	if err != nil {
+		s.writeProviderError(w, r, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, doc)
+}
+
+// JWKS отдает публичные ключи для проверки подписи ID токенов
+func (s *serverAPI) JWKS(w http.ResponseWriter, r *http.Request) {
+	keys, err := s.authApp.JWKS()
+	if err != nil {
+		s.writeProviderError(w, r, err)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, keys)
+}
+
+// UserInfo отдает claims пользователя по Bearer access токену
+func (s *serverAPI) UserInfo(w http.ResponseWriter, r *http.Request) {
+	l := s.log.With("op", "http_userinfo")
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		// RFC 6750: без токена отвечаем 401 с challenge без кода ошибки
+		w.Header().Set("WWW-Authenticate", `Bearer`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeOutUserInfo)
+	defer cancel()
+
+	info, err := s.authApp.UserInfo(ctx, token)
+	if err != nil {
+		l.Debug("ошибка получения userinfo", logger.Err(err))
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, info)
+}
+
+// Authorize реализует authorization endpoint (RFC 6749 §4.1.1): проверяет запрос и перенаправляет
+// на страницу входа фронтенда. После входа фронтенд получает адрес возврата с кодом через RPC Authorize
+func (s *serverAPI) Authorize(w http.ResponseWriter, r *http.Request) {
+	l := s.log.With("op", "http_authorize")
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	req := &auth.AuthorizationRequest{
+		ResponseType:        r.Form.Get("response_type"),
+		ClientID:            r.Form.Get("client_id"),
+		RedirectURI:         r.Form.Get("redirect_uri"),
+		Scope:               r.Form.Get("scope"),
+		State:               r.Form.Get("state"),
+		Nonce:               r.Form.Get("nonce"),
+		CodeChallenge:       r.Form.Get("code_challenge"),
+		CodeChallengeMethod: r.Form.Get("code_challenge_method"),
+	}
+
+	loginURL, err := s.authApp.StartAuthorization(req)
+	if err != nil {
+		var authErr *auth.AuthorizationError
+		switch {
+		case errors.As(err, &authErr):
+			// Клиент и redirect_uri проверены - об ошибке сообщаем клиенту через redirect_uri
+			l.Debug("недействительный запрос авторизации", logger.Err(err))
+			s.redirectAuthorizationError(w, r, req, authErr)
+		case errors.Is(err, auth.ErrUnknownClient), errors.Is(err, auth.ErrInvalidRedirectURI):
+			// RFC 6749 §4.1.2.1: на непроверенный redirect_uri не перенаправляем
+			l.Debug("неизвестный клиент или redirect_uri", logger.Err(err))
+			http.Error(w, "invalid_request: "+err.Error(), http.StatusBadRequest)
+		default:
+			s.writeProviderError(w, r, err)
+		}
+		return
+	}
+
+	http.Redirect(w, r, loginURL, http.StatusFound)
+}
+
+// Token реализует OAuth2 token endpoint: grant_type=authorization_code с PKCE (RFC 6749 §4.1.3,
+// RFC 7636) для пользователей и grant_type=client_credentials (RFC 6749 §4.4) для машинных клиентов
+func (s *serverAPI) Token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		s.tokenAuthorizationCode(w, r)
+	case "client_credentials":
+		s.tokenClientCredentials(w, r)
+	default:
+		s.writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type")
+	}
+}
+
+// tokenAuthorizationCode обменивает код авторизации на токены. Клиенты публичные: вместо секрета
+// владение кодом подтверждает code_verifier
+func (s *serverAPI) tokenAuthorizationCode(w http.ResponseWriter, r *http.Request) {
+	l := s.log.With("op", "http_token_authorization_code")
+
+	clientID := r.PostForm.Get("client_id")
+	if id, _, ok := r.BasicAuth(); ok {
+		clientID = id
+	}
+	code := r.PostForm.Get("code")
+	if clientID == "" || code == "" || r.PostForm.Get("code_verifier") == "" {
+		s.writeOAuthError(w, http.StatusBadRequest, "invalid_request")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeOutToken)
+	defer cancel()
+
+	tokens, err := s.authApp.ExchangeAuthorizationCode(ctx, code, clientID,
+		r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidGrant) {
+			l.Warn("недействительный код авторизации", slog.String("client_id", clientID))
+			s.writeOAuthError(w, http.StatusBadRequest, "invalid_grant")
+			return
+		}
+		if errors.Is(err, auth.ErrOIDCDisabled) {
+			s.writeOAuthError(w, http.StatusBadRequest, "unsupported_grant_type")
+			return
+		}
+		l.Error("ошибка обмена кода авторизации", logger.Err(err))
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": tokens.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int64(tokens.ExpiresIn.Seconds()),
+		"id_token":     tokens.IDToken,
+	})
+}
+
+// tokenClientCredentials выдает токен машинному клиенту
+func (s *serverAPI) tokenClientCredentials(w http.ResponseWriter, r *http.Request) {
+	l := s.log.With("op", "http_token")
+
+	// Клиент аутентифицируется через HTTP Basic или параметрами формы
+	clientID, secret, ok := r.BasicAuth()
+	if !ok {
+		clientID, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID == "" || secret == "" {
+		s.writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeOutToken)
+	defer cancel()
+
+	// Машинный клиент ищется у арендатора из заголовка X-Tenant, без него - у арендатора по умолчанию
+	ctx, err := s.authApp.TenantContext(ctx, strings.TrimSpace(r.Header.Get("X-Tenant")), "")
+	if err != nil {
+		if errs.KindOf(err) == errs.KindNotFound {
+			s.writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+			return
+		}
+		l.Error("ошибка определения арендатора", logger.Err(err))
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	token, err := s.authApp.IssueServiceToken(ctx, clientID, secret)
+	if err != nil {
+		if errs.KindOf(err) == errs.KindUnauthenticated {
+			l.Warn("неудачная попытка получения токена сервиса", slog.String("client_id", clientID))
+			s.writeOAuthError(w, http.StatusUnauthorized, "invalid_client")
+			return
+		}
+		l.Error("ошибка выдачи токена сервиса", logger.Err(err))
+		s.writeOAuthError(w, http.StatusInternalServerError, "server_error")
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token.AccessToken,
+		"token_type":   "Bearer",
+		"expires_in":   int64(token.ExpiresIn.Seconds()),
+	})
+}
+
+// redirectAuthorizationError возвращает ошибку запроса авторизации на redirect_uri клиента
+func (s *serverAPI) redirectAuthorizationError(
+	w http.ResponseWriter,
+	r *http.Request,
+	req *auth.AuthorizationRequest,
+	authErr *auth.AuthorizationError,
+) {
+	u, err := url.Parse(req.RedirectURI)
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	q := u.Query()
+	q.Set("error", authErr.Code)
+	q.Set("error_description", authErr.Description)
+	if req.State != "" {
+		q.Set("state", req.State)
+	}
+	u.RawQuery = q.Encode()
+
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (s *serverAPI) writeOAuthError(w http.ResponseWriter, code int, oauthErr string) {
+	if code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="auth-service"`)
+	}
+	s.writeJSON(w, code, map[string]string{"error": oauthErr})
+}
+
+func (s *serverAPI) writeProviderError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, auth.ErrOIDCDisabled) {
+		http.NotFound(w, r)
+		return
+	}
+	s.log.Error("ошибка OIDC провайдера", logger.Err(err))
+	w.WriteHeader(http.StatusInternalServerError)
+}
+
+func (s *serverAPI) writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.log.Error("ошибка записи ответа", logger.Err(err))
+	}
+}
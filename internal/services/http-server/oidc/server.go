@@ -0,0 +1,29 @@
+package oidc
+
+import (
+	"auth-service/internal/services/auth"
+	"log/slog"
+	"net/http"
+)
+
+type serverAPI struct {
+	log     *slog.Logger
+	authApp *auth.Service
+}
+
+// Register регистрирует HTTP эндпоинты OpenID Connect
+func Register(
+	mux *http.ServeMux,
+	log *slog.Logger,
+	authApp *auth.Service,
+) {
+	s := &serverAPI{log: log.With("proc", "HTTP server"), authApp: authApp}
+
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.Discovery)
+	mux.HandleFunc("GET /.well-known/jwks.json", s.JWKS)
+	mux.HandleFunc("GET /authorize", s.Authorize)
+	mux.HandleFunc("POST /authorize", s.Authorize)
+	mux.HandleFunc("GET /userinfo", s.UserInfo)
+	mux.HandleFunc("POST /userinfo", s.UserInfo)
+	mux.HandleFunc("POST /token", s.Token)
+}
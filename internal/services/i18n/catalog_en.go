@@ -0,0 +1,32 @@
+package i18n
+
+var catalogEN = map[string]string{
+	"VALIDATION_FAILED": "Validation failed",
+
+	"EMAIL_REQUIRED":           "Email is required",
+	"EMAIL_INVALID":            "Invalid email format",
+	"EMAIL_DOMAIN_DENIED":      "Addresses at %s are not allowed",
+	"EMAIL_DOMAIN_NOT_ALLOWED": "Domain %s is not on the allowed list",
+	"EMAIL_DOMAIN_DISPOSABLE":  "Disposable email addresses at %s are not accepted",
+	"EMAIL_DOMAIN_NO_MX":       "Domain %s does not accept mail",
+
+	"PASSWORD_REQUIRED":         "Password is required",
+	"PASSWORD_UNCHANGED":        "New password must differ from the current one",
+	"PASSWORD_TOO_SHORT":        "Password must be at least %d characters long",
+	"PASSWORD_TOO_LONG":         "Password must be at most %d characters long",
+	"PASSWORD_TOO_MANY_BYTES":   "Password must not exceed %d bytes",
+	"PASSWORD_FEW_UNIQUE_CHARS": "Password must contain at least %d different characters",
+	"PASSWORD_NO_UPPER":         "Password must contain an uppercase letter",
+	"PASSWORD_NO_LOWER":         "Password must contain a lowercase letter",
+	"PASSWORD_NO_NUMBER":        "Password must contain a digit",
+	"PASSWORD_NO_SPECIAL":       "Password must contain a special character",
+	"PASSWORD_SEQUENCE":         "Password must not contain the sequence %q",
+	"PASSWORD_COMMON":           "Password is too common",
+	"PASSWORD_CONTAINS_EMAIL":   "Password must not contain the name from your email",
+	"PASSWORD_BREACHED":         "Password has appeared in a data breach, choose another one",
+	"PASSWORD_REUSED":           "Password matches one of the last %d passwords",
+
+	"USERNAME_REQUIRED": "Username is required",
+	"USERNAME_LENGTH":   "Username must be between %d and %d characters long",
+	"USERNAME_FORMAT":   "Username must start with a Latin letter and contain only Latin letters, digits, '_' and '.'",
+}
@@ -0,0 +1,32 @@
+package i18n
+
+var catalogRU = map[string]string{
+	"VALIDATION_FAILED": "Ошибка валидации",
+
+	"EMAIL_REQUIRED":           "Email обязателен",
+	"EMAIL_INVALID":            "Неверный формат email",
+	"EMAIL_DOMAIN_DENIED":      "Регистрация с адресами домена %s запрещена",
+	"EMAIL_DOMAIN_NOT_ALLOWED": "Домен %s не входит в список разрешенных",
+	"EMAIL_DOMAIN_DISPOSABLE":  "Адреса одноразовой почты %s не принимаются",
+	"EMAIL_DOMAIN_NO_MX":       "Домен %s не принимает почту",
+
+	"PASSWORD_REQUIRED":         "Пароль обязателен",
+	"PASSWORD_UNCHANGED":        "Новый пароль должен отличаться от текущего",
+	"PASSWORD_TOO_SHORT":        "Пароль должен содержать не менее %d символов",
+	"PASSWORD_TOO_LONG":         "Пароль должен содержать не более %d символов",
+	"PASSWORD_TOO_MANY_BYTES":   "Пароль не должен превышать %d байт",
+	"PASSWORD_FEW_UNIQUE_CHARS": "Пароль должен содержать не менее %d разных символов",
+	"PASSWORD_NO_UPPER":         "Пароль должен содержать заглавную букву",
+	"PASSWORD_NO_LOWER":         "Пароль должен содержать строчную букву",
+	"PASSWORD_NO_NUMBER":        "Пароль должен содержать цифру",
+	"PASSWORD_NO_SPECIAL":       "Пароль должен содержать специальный символ",
+	"PASSWORD_SEQUENCE":         "Пароль не должен содержать последовательность %q",
+	"PASSWORD_COMMON":           "Пароль слишком распространен",
+	"PASSWORD_CONTAINS_EMAIL":   "Пароль не должен содержать имя из email",
+	"PASSWORD_BREACHED":         "Пароль встречался в утечках данных, выберите другой",
+	"PASSWORD_REUSED":           "Пароль совпадает с одним из %d последних",
+
+	"USERNAME_REQUIRED": "Имя пользователя обязательно",
+	"USERNAME_LENGTH":   "Имя пользователя должно содержать от %d до %d символов",
+	"USERNAME_FORMAT":   "Имя пользователя должно начинаться с латинской буквы и содержать только латинские буквы, цифры, '_' и '.'",
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc/metadata"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Поддерживаемые языки сообщений об ошибках
+const (
+	LangRU = "ru"
+	LangEN = "en"
+
+	DefaultLang = LangRU
+)
+
+// catalogs тексты сообщений по языку и коду причины
+var catalogs = map[string]map[string]string{
+	LangRU: catalogRU,
+	LangEN: catalogEN,
+}
+
+// acceptLanguageKeys заголовок клиента; grpc-gateway передает его с префиксом
+var acceptLanguageKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// Lang выбирает язык ответа по заголовку accept-language из метаданных запроса
+func Lang(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return DefaultLang
+	}
+
+	for _, key := range acceptLanguageKeys {
+		if values := md.Get(key); len(values) > 0 {
+			return Negotiate(strings.Join(values, ","))
+		}
+	}
+	return DefaultLang
+}
+
+// Negotiate выбирает поддерживаемый язык с наибольшим весом q из значения Accept-Language
+func Negotiate(header string) string {
+	best, bestQ := DefaultLang, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if _, ok := catalogs[lang]; ok && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// Message возвращает текст сообщения на языке lang. Если перевода нет,
+// используется язык по умолчанию, если нет и его - сам код
+func Message(lang, key string, args ...any) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLang][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Languages возвращает поддерживаемые языки
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
@@ -0,0 +1,148 @@
+package mailer
+
+import (
+	"auth-service/config"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sendTimeout ограничивает отправку письма, если у контекста нет своего дедлайна
+const sendTimeout = 30 * time.Second
+
+// ErrNotConfigured SMTP не настроен в окружении, где письма должны уходить пользователям
+var ErrNotConfigured = errors.New("mailer: smtp host is required outside local and dev")
+
+// Message письмо для отправки пользователю
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender отправляет письма пользователям
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New возвращает SMTP отправителя. Без SMTP письма пишутся в лог, но только в local и dev:
+// в остальных окружениях пользователи иначе молча не получат ссылок и кодов
+func New(log *slog.Logger, env string, cfg config.Mail) (Sender, error) {
+	if cfg.Host == "" {
+		if env != "local" && env != "dev" {
+			return nil, ErrNotConfigured
+		}
+		return &logSender{log: log.With("proc", "mailer")}, nil
+	}
+	return &smtpSender{cfg: cfg}, nil
+}
+
+type smtpSender struct {
+	cfg config.Mail
+}
+
+// Send отправляет письмо через SMTP сервер из конфигурации. Отмена контекста прерывает разговор с сервером
+func (s *smtpSender) Send(ctx context.Context, msg Message) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sendTimeout)
+		defer cancel()
+	}
+
+	if err := s.send(ctx, msg); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return fmt.Errorf("mailer.Send: %w", err)
+	}
+	return nil
+}
+
+func (s *smtpSender) send(ctx context.Context, msg Message) error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	// net/smtp не знает о контексте: дедлайн соединения и закрытие при отмене
+	// не дают зависшему серверу держать запрос
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err = c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return err
+		}
+	}
+	if s.cfg.User != "" {
+		if err = c.Auth(smtp.PlainAuth("", s.cfg.User, s.cfg.Pass, s.cfg.Host)); err != nil {
+			return err
+		}
+	}
+	if err = c.Mail(s.cfg.From); err != nil {
+		return err
+	}
+	if err = c.Rcpt(msg.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(s.compose(msg)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (s *smtpSender) compose(msg Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return []byte(b.String())
+}
+
+type logSender struct {
+	log *slog.Logger
+}
+
+// Send пишет в лог только факт письма: в теле ссылки и коды, которые дают вход в аккаунт
+func (s *logSender) Send(_ context.Context, msg Message) error {
+	s.log.Debug("письмо не отправлено: SMTP не настроен",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.Int("body_len", len(msg.Body)),
+	)
+	return nil
+}
@@ -1,84 +1,167 @@
 package validator
 
 import (
-	"fmt"
+	"auth-service/internal/services/breach"
+	"auth-service/pkg/logger"
+	"context"
 	"github.com/asaskevich/govalidator"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"regexp"
+	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
-// DefaultPasswordPolicy содержит стандартные требования к паролю
-var DefaultPasswordPolicy = PasswordPolicy{
-	MinLength:      8,
-	RequireUpper:   true,
-	RequireLower:   true,
-	RequireNumber:  true,
-	RequireSpecial: true,
+const (
+	usernameMinLength = 3
+	usernameMaxLength = 32
+)
+
+var usernameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_.]*$`)
+
+// ValidateRegisterRequest проверяет email и пароль
+func (v *Validator) ValidateRegisterRequest(ctx context.Context, email, password string) error {
+	vs := newViolations(ctx)
+	v.validateEmail(ctx, vs, "email", email)
+	v.validatePassword(ctx, vs, "password", email, password)
+	return vs.err()
 }
 
-type PasswordPolicy struct {
-	MinLength      int
-	RequireUpper   bool
-	RequireLower   bool
-	RequireNumber  bool
-	RequireSpecial bool
+// ValidateEmail проверяет email нового аккаунта, созданного без формы регистрации (вход через провайдера)
+func (v *Validator) ValidateEmail(ctx context.Context, email string) error {
+	vs := newViolations(ctx)
+	v.validateEmail(ctx, vs, "email", email)
+	return vs.err()
 }
 
-// ValidateRegisterRequest проверяет email и пароль
-func (v *Validator) ValidateRegisterRequest(email, password string) error {
-	if err := v.validateEmail(email); err != nil {
-		return err
+// ValidatePassword проверяет пароль по политике паролей (импорт пользователей с открытым паролем)
+func (v *Validator) ValidatePassword(ctx context.Context, email, password string) error {
+	vs := newViolations(ctx)
+	v.validatePassword(ctx, vs, "password", email, password)
+	return vs.err()
+}
+
+// ValidateChangePasswordRequest проверяет новый пароль по политике паролей
+func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, email, current, next string) error {
+	vs := newViolations(ctx)
+	if current == "" {
+		vs.add("current_password", "PASSWORD_REQUIRED")
+	}
+	if current != "" && current == next {
+		vs.add("new_password", "PASSWORD_UNCHANGED")
+	}
+	v.validatePassword(ctx, vs, "new_password", email, next)
+	return vs.err()
+}
+
+// ValidateChangeEmailRequest проверяет новый email
+func (v *Validator) ValidateChangeEmailRequest(ctx context.Context, password, newEmail string) error {
+	vs := newViolations(ctx)
+	if password == "" {
+		vs.add("password", "PASSWORD_REQUIRED")
 	}
-	return v.validatePassword(password)
+	v.validateEmail(ctx, vs, "new_email", newEmail)
+	return vs.err()
 }
 
-func (v *Validator) validateEmail(email string) error {
+// ValidateUsername проверяет формат имени пользователя
+func (v *Validator) ValidateUsername(ctx context.Context, username string) error {
+	vs := newViolations(ctx)
+	switch {
+	case username == "":
+		vs.add("username", "USERNAME_REQUIRED")
+	case len(username) < usernameMinLength || len(username) > usernameMaxLength:
+		vs.add("username", "USERNAME_LENGTH", usernameMinLength, usernameMaxLength)
+	case !usernameRegexp.MatchString(username):
+		vs.add("username", "USERNAME_FORMAT")
+	}
+	return vs.err()
+}
+
+func (v *Validator) validateEmail(ctx context.Context, vs *violations, field, email string) {
 	if email == "" {
-		return v.createError("email", "Email обязателен")
+		vs.add(field, "EMAIL_REQUIRED")
+		return
 	}
 
 	if !govalidator.IsEmail(email) {
-		return v.createError("email", "Неверный формат email")
+		vs.add(field, "EMAIL_INVALID")
+		return
 	}
 
-	return nil
+	// Email к этому моменту нормализован, домен уже в нижнем регистре и punycode
+	domain := email[strings.LastIndexByte(email, '@')+1:]
+	if reason := v.domains.Check(ctx, domain); reason != "" {
+		vs.add(field, reason, domain)
+	}
 }
 
-func (v *Validator) validatePassword(password string) error {
-	if len(password) < v.passPolicy.MinLength {
-		return v.createError("password",
-			"Пароль должен содержать не менее %d символов", v.passPolicy.MinLength)
+// validatePassword проверяет все правила политики, чтобы клиент получил полный список нарушений
+func (v *Validator) validatePassword(ctx context.Context, vs *violations, field, email, password string) {
+	if password == "" {
+		vs.add(field, "PASSWORD_REQUIRED")
+		return
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < v.passPolicy.MinLength {
+		vs.add(field, "PASSWORD_TOO_SHORT", v.passPolicy.MinLength)
+	}
+
+	if v.passPolicy.MaxLength > 0 && length > v.passPolicy.MaxLength {
+		vs.add(field, "PASSWORD_TOO_LONG", v.passPolicy.MaxLength)
+	}
+
+	if v.maxPasswordBytes > 0 && len(password) > v.maxPasswordBytes {
+		vs.add(field, "PASSWORD_TOO_MANY_BYTES", v.maxPasswordBytes)
+	}
+
+	if countUnique(password) < v.passPolicy.MinUniqueChars {
+		vs.add(field, "PASSWORD_FEW_UNIQUE_CHARS", v.passPolicy.MinUniqueChars)
 	}
 
 	if v.passPolicy.RequireUpper && !govalidator.HasUpperCase(password) {
-		return v.createError("password", "Пароль должен содержать заглавную букву")
+		vs.add(field, "PASSWORD_NO_UPPER")
 	}
 
 	if v.passPolicy.RequireLower && !govalidator.HasLowerCase(password) {
-		return v.createError("password", "Пароль должен содержать строчную букву")
+		vs.add(field, "PASSWORD_NO_LOWER")
 	}
 
 	if v.passPolicy.RequireNumber && !hasNumber(password) {
-		return v.createError("password", "Пароль должен содержать цифру")
+		vs.add(field, "PASSWORD_NO_NUMBER")
 	}
 
 	if v.passPolicy.RequireSpecial && !hasSpecial(password) {
-		return v.createError("password", "Пароль должен содержать специальный символ")
+		vs.add(field, "PASSWORD_NO_SPECIAL")
 	}
 
-	return nil
+	if seq := v.findSequence(password); seq != "" {
+		vs.add(field, "PASSWORD_SEQUENCE", seq)
+	}
+
+	v.validatePasswordExposure(ctx, vs, field, email, password)
 }
 
-func (v *Validator) createError(field, format string, args ...interface{}) error {
-	st := status.New(codes.InvalidArgument, "Ошибка валидации")
-	desc := fmt.Sprintf(format, args...)
-	st, _ = st.WithDetails(&errdetails.BadRequest_FieldViolation{
-		Field:       field,
-		Description: desc,
-	})
-	return st.Err()
+// validatePasswordExposure отклоняет пароли, которые легко подобрать несмотря на политику:
+// частые, содержащие email и встречавшиеся в утечках
+func (v *Validator) validatePasswordExposure(ctx context.Context, vs *violations, field, email, password string) {
+	if breach.IsCommon(password) {
+		vs.add(field, "PASSWORD_COMMON")
+	}
+
+	if breach.ContainsEmailLocalPart(password, email) {
+		vs.add(field, "PASSWORD_CONTAINS_EMAIL")
+	}
+
+	breached, err := v.breached.Breached(ctx, password)
+	if err != nil {
+		// Недоступность базы утечек не должна блокировать регистрацию и смену пароля
+		v.log.Error("ошибка проверки пароля по базе утечек", logger.Err(err))
+		return
+	}
+	if breached {
+		vs.add(field, "PASSWORD_BREACHED")
+	}
 }
 
 // Вспомогательные функции для проверки пароля
@@ -91,6 +174,34 @@ func hasNumber(s string) bool {
 	return false
 }
 
+func countUnique(s string) int {
+	seen := make(map[rune]struct{})
+	for _, r := range s {
+		seen[r] = struct{}{}
+	}
+	return len(seen)
+}
+
+// findSequence ищет в пароле SequenceLength подряд идущих символов одной из запрещенных
+// последовательностей, в прямом или обратном порядке, без учета регистра
+func (v *Validator) findSequence(password string) string {
+	n := v.passPolicy.SequenceLength
+	if n <= 1 {
+		return ""
+	}
+
+	p := []rune(strings.ToLower(password))
+	for i := 0; i+n <= len(p); i++ {
+		window := string(p[i : i+n])
+		for _, seq := range v.sequences {
+			if strings.Contains(seq, window) {
+				return string([]rune(password)[i : i+n])
+			}
+		}
+	}
+	return ""
+}
+
 func hasSpecial(s string) bool {
 	for _, r := range s {
 		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsSpace(r) {
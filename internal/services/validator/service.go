@@ -1,11 +1,71 @@
 package validator
 
+import (
+	"auth-service/config"
+	"auth-service/internal/services/breach"
+	"auth-service/internal/services/emaildomain"
+	"auth-service/pkg/logger"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strings"
+)
+
+// bcryptMaxBytes bcrypt учитывает только первые 72 байта пароля
+const bcryptMaxBytes = 72
+
 type Validator struct {
-	passPolicy PasswordPolicy
+	log        *slog.Logger
+	passPolicy config.PasswordPolicy
+	sequences  []string            // запрещенные последовательности в нижнем регистре, прямые и обратные
+	breached   *breach.Checker     // nil, если база утечек не настроена
+	domains    *emaildomain.Policy // ограничения на домен email при регистрации и смене email
+
+	maxPasswordBytes int // ограничение алгоритма хеширования; 0 - без ограничения
 }
 
-func New() *Validator {
-	return &Validator{
-		passPolicy: DefaultPasswordPolicy,
+func New(log *slog.Logger, cfg *config.Config) *Validator {
+	breached, err := breach.New(cfg.BreachedPasswords)
+	if err != nil {
+		log.Warn("Failed to open breached passwords source. Check config.yaml!", logger.Err(err))
+		os.Exit(2)
+	}
+
+	domains, err := emaildomain.New(log, cfg.Email.Domains, net.DefaultResolver)
+	if err != nil {
+		log.Warn("Failed to load email domain lists. Check config.yaml!", logger.Err(err))
+		os.Exit(2)
+	}
+
+	v := &Validator{
+		log:        log.With("proc", "validator"),
+		passPolicy: cfg.PasswordPolicy,
+		breached:   breached,
+		domains:    domains,
+	}
+
+	for _, seq := range cfg.PasswordPolicy.DisallowedSequences {
+		seq = strings.ToLower(seq)
+		reversed := []rune(seq)
+		slices.Reverse(reversed)
+		v.sequences = append(v.sequences, seq, string(reversed))
+	}
+
+	if cfg.PasswordHashing.Algorithm == "bcrypt" {
+		v.maxPasswordBytes = bcryptMaxBytes
 	}
+
+	return v
+}
+
+// PasswordPolicy возвращает действующие требования к паролю
+func (v *Validator) PasswordPolicy() config.PasswordPolicy {
+	return v.passPolicy
+}
+
+// Close освобождает базу утечек и останавливает перечитывание списков доменов
+func (v *Validator) Close() error {
+	v.domains.Close()
+	return v.breached.Close()
 }
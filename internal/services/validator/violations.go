@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"auth-service/internal/errs"
+	"auth-service/internal/services/i18n"
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"strings"
+)
+
+// reasonValidationFailed причина ErrorInfo для любой ошибки валидации;
+// причины по полям перечислены в metadata и в FieldViolation.Reason
+const reasonValidationFailed = "VALIDATION_FAILED"
+
+// violations собирает нарушения всех правил, чтобы вернуть их одной ошибкой
+type violations struct {
+	lang  string
+	items []*errdetails.BadRequest_FieldViolation
+}
+
+// newViolations создает сборщик нарушений с языком сообщений из accept-language
+func newViolations(ctx context.Context) *violations {
+	return &violations{lang: i18n.Lang(ctx)}
+}
+
+// add добавляет нарушение: reason - код причины и ключ сообщения в каталогах i18n
+func (vs *violations) add(field, reason string, args ...any) {
+	msg := i18n.Message(vs.lang, reason, args...)
+	vs.items = append(vs.items, &errdetails.BadRequest_FieldViolation{
+		Field:            field,
+		Description:      msg,
+		Reason:           reason,
+		LocalizedMessage: &errdetails.LocalizedMessage{Locale: vs.lang, Message: msg},
+	})
+}
+
+// err возвращает ошибку InvalidArgument со всеми нарушениями в одном BadRequest, причинами
+// по полям в метаданных ErrorInfo и LocalizedMessage. Без нарушений возвращает nil
+func (vs *violations) err() error {
+	if len(vs.items) == 0 {
+		return nil
+	}
+	return vs.build()
+}
+
+// FieldError ошибка валидации одного поля в том же формате, что и у Validator. Нужна
+// проверкам вне валидатора, например повтору пароля, которую можно сделать только по хешам
+func FieldError(ctx context.Context, field, reason string, args ...any) *errs.Error {
+	vs := newViolations(ctx)
+	vs.add(field, reason, args...)
+	return vs.build()
+}
+
+// build собирает ошибку из накопленных нарушений
+func (vs *violations) build() *errs.Error {
+	summary := i18n.Message(vs.lang, reasonValidationFailed)
+	err := errs.New(errs.KindInvalidArgument, reasonValidationFailed, summary).WithDetails(
+		&errdetails.BadRequest{FieldViolations: vs.items},
+		&errdetails.LocalizedMessage{Locale: vs.lang, Message: summary},
+	)
+
+	reasons := make(map[string][]string)
+	for _, item := range vs.items {
+		reasons[item.Field] = append(reasons[item.Field], item.Reason)
+	}
+	for field, r := range reasons {
+		err = err.With(field, strings.Join(r, ","))
+	}
+	return err
+}
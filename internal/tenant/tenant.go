@@ -0,0 +1,35 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Default арендатор по умолчанию: все данные, созданные до появления арендаторов,
+// и запросы, в которых арендатор не указан
+var Default = uuid.Nil
+
+// MetadataKey ключ gRPC metadata с ID или slug арендатора
+const MetadataKey = "x-tenant"
+
+type ctxKey struct{}
+
+// With возвращает контекст с явно указанным арендатором
+func With(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext возвращает арендатора запроса; ok = false, если он не был указан явно
+func FromContext(ctx context.Context) (id uuid.UUID, ok bool) {
+	id, ok = ctx.Value(ctxKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// ID возвращает арендатора запроса или Default. Все запросы к хранилищу ограничиваются им
+func ID(ctx context.Context) uuid.UUID {
+	if id, ok := FromContext(ctx); ok {
+		return id
+	}
+	return Default
+}
@@ -0,0 +1,23 @@
+-- Машинные клиенты для межсервисного взаимодействия (OAuth2 client credentials)
+CREATE TABLE auth.service_clients
+(
+    client_id   VARCHAR(64) PRIMARY KEY,             -- Публичный идентификатор клиента (svc_...)
+    name        VARCHAR(128) NOT NULL,               -- Человекочитаемое название сервиса
+    secret_hash VARCHAR(255) NOT NULL,               -- SHA-256 хеш секрета клиента (секрет хранится только у клиента)
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP, -- Дата и время создания клиента
+    revoked_at  TIMESTAMP                            -- Дата и время отзыва клиента (NULL - активен)
+);
+
+-- Роли, назначенные машинному клиенту
+CREATE TABLE auth.service_client_roles
+(
+    client_id  VARCHAR(64) REFERENCES auth.service_clients (client_id) ON DELETE CASCADE, -- Ссылка на клиента
+    role_id    INT REFERENCES auth.roles (role_id),                                      -- Ссылка на роль
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                                      -- Дата и время назначения роли
+    PRIMARY KEY (client_id, role_id)
+);
+
+-- Роль администратора для управления клиентами и пользователями
+INSERT INTO auth.roles (role_name, role_description)
+VALUES ('admin', 'Администратор сервиса авторизации')
+ON CONFLICT (role_name) DO NOTHING;
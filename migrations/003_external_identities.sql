@@ -0,0 +1,13 @@
+-- Связь аккаунтов внешних OpenID Connect провайдеров (Google, Яндекс, VK) с пользователями
+CREATE TABLE auth.external_identities
+(
+    provider      VARCHAR(32)  NOT NULL,                                         -- Имя провайдера из конфигурации
+    subject       VARCHAR(255) NOT NULL,                                         -- Идентификатор пользователя у провайдера (sub)
+    user_id       UUID         NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE, -- Ссылка на пользователя
+    email         VARCHAR(255),                                                  -- Email, полученный от провайдера при привязке
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                           -- Дата и время привязки
+    last_login_at TIMESTAMP,                                                     -- Время последнего входа через провайдера
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX idx_external_identities_user_id ON auth.external_identities (user_id); -- Для поиска привязок пользователя
@@ -0,0 +1,11 @@
+-- Одноразовые ссылки для входа без пароля
+CREATE TABLE auth.magic_links
+(
+    token_hash  VARCHAR(64) PRIMARY KEY,                                     -- SHA-256 хеш токена из ссылки (сам токен не хранится)
+    user_id     UUID NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE, -- Ссылка на пользователя
+    expires_at  TIMESTAMP NOT NULL,                                          -- Время истечения срока действия ссылки
+    consumed_at TIMESTAMP,                                                   -- Время использования ссылки (NULL - не использована)
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP                          -- Дата и время создания ссылки
+);
+
+CREATE INDEX idx_magic_links_user_id ON auth.magic_links (user_id); -- Для очистки ссылок пользователя
@@ -0,0 +1,16 @@
+-- Персональные API ключи пользователей для скриптов и автоматизации
+CREATE TABLE auth.api_keys
+(
+    key_id       UUID PRIMARY KEY,                                               -- Уникальный идентификатор ключа
+    user_id      UUID         NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE, -- Владелец ключа
+    name         VARCHAR(128) NOT NULL,                                          -- Название ключа, заданное пользователем
+    prefix       VARCHAR(32)  NOT NULL UNIQUE,                                   -- Публичная часть ключа для поиска и отображения
+    secret_hash  VARCHAR(64)  NOT NULL,                                          -- SHA-256 хеш секретной части ключа
+    roles        TEXT[],                                                         -- Подмножество ролей владельца (NULL - все роли владельца)
+    expires_at   TIMESTAMP,                                                      -- Время истечения (NULL - бессрочный)
+    last_used_at TIMESTAMP,                                                      -- Время последнего использования
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                            -- Дата и время создания
+    revoked_at   TIMESTAMP                                                       -- Дата и время отзыва (NULL - активен)
+);
+
+CREATE INDEX idx_api_keys_user_id ON auth.api_keys (user_id); -- Для списка ключей пользователя
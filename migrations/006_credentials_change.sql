@@ -0,0 +1,16 @@
+-- Версия токенов пользователя: увеличивается при смене пароля или email и отзывает все выданные JWT
+ALTER TABLE auth.users
+    ADD COLUMN token_version INT NOT NULL DEFAULT 0;
+
+-- Запросы на смену email, ожидающие подтверждения нового адреса
+CREATE TABLE auth.email_change_requests
+(
+    token_hash  VARCHAR(64) PRIMARY KEY,                                         -- SHA-256 хеш токена подтверждения
+    user_id     UUID         NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE, -- Ссылка на пользователя
+    new_email   VARCHAR(255) NOT NULL,                                           -- Новый email, ожидающий подтверждения
+    expires_at  TIMESTAMP    NOT NULL,                                           -- Время истечения срока действия запроса
+    consumed_at TIMESTAMP,                                                       -- Время подтверждения (NULL - не подтвержден)
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP                              -- Дата и время создания запроса
+);
+
+CREATE INDEX idx_email_change_requests_user_id ON auth.email_change_requests (user_id); -- Для очистки запросов пользователя
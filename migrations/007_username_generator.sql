@@ -0,0 +1,12 @@
+-- username генерируется в сервисе (models.GenerateUsername) с повтором при коллизии,
+-- поэтому триггер больше не нужен: он перезаписывал бы имя, переданное из приложения
+DROP TRIGGER IF EXISTS set_username ON auth.users;
+DROP FUNCTION IF EXISTS generate_username();
+
+-- Пользователи, созданные до появления username, получают имя по старому правилу
+UPDATE auth.users
+SET username = 'user_' || SUBSTRING(REPLACE(user_id::TEXT, '-', ''), 1, 8)
+WHERE username IS NULL;
+
+ALTER TABLE auth.users
+    ALTER COLUMN username SET NOT NULL;
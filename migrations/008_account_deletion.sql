@@ -0,0 +1,22 @@
+-- Мягкое удаление аккаунта: пользователь скрывается сразу, данные удаляются после льготного периода
+ALTER TABLE auth.users
+    ADD COLUMN deletion_requested_at TIMESTAMP, -- Время запроса удаления (NULL - аккаунт не удаляется)
+    ADD COLUMN purge_after           TIMESTAMP; -- Время окончательного удаления данных
+
+CREATE INDEX idx_users_purge_after ON auth.users (purge_after) WHERE purge_after IS NOT NULL; -- Для фоновой очистки
+
+-- Связанные данные удаляются вместе с пользователем
+ALTER TABLE auth.user_roles
+    DROP CONSTRAINT user_roles_user_id_fkey,
+    ADD CONSTRAINT user_roles_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (user_id) ON DELETE CASCADE;
+
+ALTER TABLE auth.tokens
+    DROP CONSTRAINT tokens_user_id_fkey,
+    ADD CONSTRAINT tokens_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (user_id) ON DELETE CASCADE;
+
+ALTER TABLE auth.password_reset_requests
+    DROP CONSTRAINT password_reset_requests_user_id_fkey,
+    ADD CONSTRAINT password_reset_requests_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES auth.users (user_id) ON DELETE CASCADE;
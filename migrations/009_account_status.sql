@@ -0,0 +1,17 @@
+-- Статус аккаунта: блокировка модераторами и удаление
+ALTER TABLE auth.users
+    ADD COLUMN status          VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'suspended', 'disabled', 'pending_deletion')), -- Текущий статус аккаунта
+    ADD COLUMN status_reason   VARCHAR(255),                                       -- Причина блокировки, указанная модератором
+    ADD COLUMN suspended_until TIMESTAMP,                                          -- Окончание временной блокировки (NULL - бессрочно)
+    ADD COLUMN status_changed_by UUID;                                             -- Кто изменил статус (модератор)
+
+-- Аккаунты, удаление которых уже запрошено
+UPDATE auth.users
+SET status = 'pending_deletion'
+WHERE deletion_requested_at IS NOT NULL;
+
+-- Роль модератора для блокировки аккаунтов
+INSERT INTO auth.roles (role_name, role_description)
+VALUES ('moderator', 'Модератор: блокировка и разблокировка аккаунтов')
+ON CONFLICT (role_name) DO NOTHING;
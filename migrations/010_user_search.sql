@@ -0,0 +1,13 @@
+-- Время последнего входа для фильтрации и сортировки в админском списке пользователей
+ALTER TABLE auth.users
+    ADD COLUMN last_login_at TIMESTAMP;
+
+-- Индексы для ListUsers: каждый покрывает сортировку и keyset-пагинацию по (значение, user_id).
+-- Поиск по префиксу email идет диапазоном по lower(email) в сортировке "C", а не LIKE
+CREATE INDEX idx_users_email_lower ON auth.users ((lower(email) COLLATE "C"), user_id);
+CREATE INDEX idx_users_created_at ON auth.users (created_at, user_id);
+CREATE INDEX idx_users_last_login_at ON auth.users ((COALESCE(last_login_at, 'epoch'::TIMESTAMP)), user_id);
+CREATE INDEX idx_users_status ON auth.users (status) WHERE status <> 'active'; -- Неактивных мало, активные отбираются остальными индексами
+
+-- Для фильтра по роли: поиск пользователей с ролью, а не ролей пользователя
+CREATE INDEX idx_user_roles_role_id ON auth.user_roles (role_id, user_id);
@@ -0,0 +1,11 @@
+-- Массовый импорт пользователей: роли назначаются upsert-ом, поэтому связь пользователь-роль уникальна
+
+-- Удаляем дубликаты, оставляя самое раннее назначение
+DELETE FROM auth.user_roles a
+    USING auth.user_roles b
+WHERE a.user_id = b.user_id
+  AND a.role_id = b.role_id
+  AND a.user_role_id > b.user_role_id;
+
+ALTER TABLE auth.user_roles
+    ADD CONSTRAINT uq_user_roles_user_role UNIQUE (user_id, role_id);
@@ -0,0 +1,12 @@
+-- База утекших паролей для проверки новых паролей (breached_passwords.source: postgres).
+-- Хеши SHA-1 в верхнем регистре, разбитые на префикс диапазона и суффикс, как в Have I Been Pwned.
+-- Загрузка из файла PwnedPasswordsDownloader:
+--   sed -E 's/^(.{5})(.{35}):([0-9]+)\r?$/\1,\2,\3/' pwnedpasswords.txt \
+--     | psql -c "\copy auth.breached_password_hashes (prefix, suffix, count) FROM STDIN CSV"
+CREATE TABLE auth.breached_password_hashes
+(
+    prefix CHAR(5)  NOT NULL, -- Первые 5 hex символов SHA-1
+    suffix CHAR(35) NOT NULL, -- Остальные 35 hex символов
+    count  INT      NOT NULL DEFAULT 1, -- Сколько раз пароль встретился в утечках
+    PRIMARY KEY (prefix, suffix)
+);
@@ -0,0 +1,16 @@
+-- Срок действия и история паролей (password_policy.max_age и history_depth)
+
+-- Существующим пользователям отсчет срока начинается с момента миграции
+ALTER TABLE auth.users
+    ADD COLUMN password_changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- Прежние хеши паролей для запрета повторного использования
+CREATE TABLE auth.password_history
+(
+    history_id    BIGSERIAL PRIMARY KEY,
+    user_id       UUID         NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,                    -- Хеш пароля, действовавшего до смены
+    created_at    TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP -- Когда пароль был заменен
+);
+
+CREATE INDEX idx_password_history_user_id ON auth.password_history (user_id, created_at DESC);
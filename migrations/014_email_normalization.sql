@@ -0,0 +1,40 @@
+-- Email хранится в нормализованном виде (без пробелов, в нижнем регистре) и уникален без учета регистра.
+-- Перед изменениями миграция ищет адреса, совпадающие после нормализации, и при наличии таких
+-- прерывается со списком: дубликаты нужно объединить или переименовать вручную и повторить миграцию.
+-- Punycode и правила провайдеров (email.provider_rules) проверяет команда cmd/normalize-emails
+DO
+$$
+    DECLARE
+        dup   RECORD;
+        total INT := 0;
+    BEGIN
+        FOR dup IN
+            SELECT lower(btrim(email)) AS normalized,
+                   string_agg(user_id::TEXT || ' (' || email || ')', ', ' ORDER BY created_at) AS users
+            FROM auth.users
+            GROUP BY lower(btrim(email))
+            HAVING count(*) > 1
+            LOOP
+                RAISE WARNING 'дубликат email %: %', dup.normalized, dup.users;
+                total := total + 1;
+            END LOOP;
+
+        IF total > 0 THEN
+            RAISE EXCEPTION 'найдено адресов с дубликатами: %. Первым в списке указан самый ранний аккаунт', total;
+        END IF;
+    END
+$$;
+
+UPDATE auth.users
+SET email = lower(btrim(email))
+WHERE email <> lower(btrim(email));
+
+UPDATE auth.email_change_requests
+SET new_email = lower(btrim(new_email))
+WHERE new_email <> lower(btrim(new_email));
+
+-- Уникальность без учета регистра вместо уникальности по точному написанию
+ALTER TABLE auth.users
+    DROP CONSTRAINT users_email_key;
+DROP INDEX auth.idx_users_email;
+CREATE UNIQUE INDEX uq_users_email_lower ON auth.users (lower(email));
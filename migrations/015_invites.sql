@@ -0,0 +1,14 @@
+-- Приглашения для регистрации в режимах invite_only и domain_restricted
+CREATE TABLE auth.invites
+(
+    invite_id  UUID PRIMARY KEY,                                         -- Уникальный идентификатор приглашения
+    code_hash  VARCHAR(64) NOT NULL UNIQUE,                              -- SHA-256 хеш кода приглашения (сам код не хранится)
+    email      VARCHAR(255),                                             -- Приглашение только для этого email (NULL - для любого)
+    roles      TEXT[]      NOT NULL DEFAULT '{}',                        -- Роли, назначаемые при регистрации сверх роли по умолчанию
+    max_uses   INT         NOT NULL DEFAULT 1 CHECK (max_uses > 0),      -- Сколько регистраций допускает код
+    uses       INT         NOT NULL DEFAULT 0,                           -- Сколько регистраций уже выполнено
+    created_by UUID REFERENCES auth.users (user_id) ON DELETE SET NULL, -- Администратор, создавший приглашение
+    expires_at TIMESTAMP,                                                -- Время истечения (NULL - бессрочное)
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                      -- Дата и время создания
+    revoked_at TIMESTAMP                                                 -- Дата и время отзыва (NULL - активно)
+);
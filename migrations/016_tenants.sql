@@ -0,0 +1,50 @@
+-- Арендаторы (сети ресторанов): у каждого свои пользователи, роли, машинные клиенты и приглашения
+CREATE TABLE auth.tenants
+(
+    tenant_id   UUID PRIMARY KEY,                    -- Уникальный идентификатор арендатора
+    slug        VARCHAR(64)  NOT NULL UNIQUE,        -- Короткое имя для metadata x-tenant
+    name        VARCHAR(255) NOT NULL,               -- Название для администраторов
+    created_at  TIMESTAMP DEFAULT CURRENT_TIMESTAMP, -- Дата и время создания
+    disabled_at TIMESTAMP                            -- Дата и время отключения (NULL - активен)
+);
+
+-- Арендатор по умолчанию: все существующие данные и запросы без x-tenant
+INSERT INTO auth.tenants (tenant_id, slug, name)
+VALUES ('00000000-0000-0000-0000-000000000000', 'default', 'Default')
+ON CONFLICT (tenant_id) DO NOTHING;
+
+ALTER TABLE auth.users
+    ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES auth.tenants (tenant_id);
+ALTER TABLE auth.roles
+    ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES auth.tenants (tenant_id);
+ALTER TABLE auth.service_clients
+    ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES auth.tenants (tenant_id);
+ALTER TABLE auth.invites
+    ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES auth.tenants (tenant_id);
+ALTER TABLE auth.external_identities
+    ADD COLUMN tenant_id UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000' REFERENCES auth.tenants (tenant_id);
+
+-- Email, username и названия ролей уникальны в пределах арендатора
+DROP INDEX auth.uq_users_email_lower;
+CREATE UNIQUE INDEX uq_users_email_lower ON auth.users (tenant_id, lower(email));
+ALTER TABLE auth.users
+    DROP CONSTRAINT users_username_key,
+    ADD CONSTRAINT uq_users_tenant_username UNIQUE (tenant_id, username);
+ALTER TABLE auth.roles
+    DROP CONSTRAINT roles_role_name_key,
+    ADD CONSTRAINT uq_roles_tenant_role_name UNIQUE (tenant_id, role_name);
+
+-- Один и тот же аккаунт провайдера может быть привязан к пользователям разных арендаторов
+ALTER TABLE auth.external_identities
+    DROP CONSTRAINT external_identities_pkey,
+    ADD PRIMARY KEY (tenant_id, provider, subject);
+
+-- Индексы ListUsers с арендатором впереди: список всегда строится в пределах одного арендатора
+DROP INDEX auth.idx_users_email_lower;
+DROP INDEX auth.idx_users_created_at;
+DROP INDEX auth.idx_users_last_login_at;
+CREATE INDEX idx_users_email_lower ON auth.users (tenant_id, (lower(email) COLLATE "C"), user_id);
+CREATE INDEX idx_users_created_at ON auth.users (tenant_id, created_at, user_id);
+CREATE INDEX idx_users_last_login_at ON auth.users (tenant_id, (COALESCE(last_login_at, 'epoch'::TIMESTAMP)), user_id);
+CREATE INDEX idx_service_clients_tenant_id ON auth.service_clients (tenant_id);
+CREATE INDEX idx_invites_tenant_id ON auth.invites (tenant_id, created_at);
@@ -0,0 +1,42 @@
+-- Организации внутри арендатора (например, рестораны сети) и участие в них пользователей
+CREATE TABLE auth.organisations
+(
+    org_id     UUID PRIMARY KEY,                                                 -- Уникальный идентификатор организации
+    tenant_id  UUID         NOT NULL REFERENCES auth.tenants (tenant_id),        -- Арендатор, которому принадлежит организация
+    name       VARCHAR(255) NOT NULL,                                            -- Название
+    created_by UUID REFERENCES auth.users (user_id) ON DELETE SET NULL,          -- Кто создал организацию
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP                               -- Дата и время создания
+);
+
+CREATE INDEX idx_organisations_tenant ON auth.organisations (tenant_id, name);
+
+-- Участники организаций. Роли в организации не связаны с ролями auth.roles
+CREATE TABLE auth.organisation_members
+(
+    org_id     UUID   NOT NULL REFERENCES auth.organisations (org_id) ON DELETE CASCADE, -- Организация
+    user_id    UUID   NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE,        -- Участник
+    roles      TEXT[] NOT NULL DEFAULT '{}',                                             -- Роли в организации
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                                      -- Дата и время вступления
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                                      -- Дата и время изменения ролей
+    PRIMARY KEY (org_id, user_id)
+);
+
+-- ListMyOrganisations и роли для VerifyToken ищут по пользователю
+CREATE INDEX idx_organisation_members_user ON auth.organisation_members (user_id);
+
+-- Приглашения в организацию по email. Токен из письма хранится только в виде хеша
+CREATE TABLE auth.organisation_invitations
+(
+    invitation_id UUID PRIMARY KEY,                                                  -- Уникальный идентификатор приглашения
+    org_id        UUID         NOT NULL REFERENCES auth.organisations (org_id) ON DELETE CASCADE, -- Организация
+    email         VARCHAR(255) NOT NULL,                                             -- Кого пригласили
+    roles         TEXT[]       NOT NULL DEFAULT '{}',                                -- Роли, назначаемые при принятии
+    token_hash    VARCHAR(64)  NOT NULL UNIQUE,                                      -- SHA-256 хеш токена из письма
+    invited_by    UUID REFERENCES auth.users (user_id) ON DELETE SET NULL,           -- Кто пригласил
+    expires_at    TIMESTAMP    NOT NULL,                                             -- Время истечения
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,                               -- Дата и время создания
+    accepted_at   TIMESTAMP,                                                         -- Дата и время принятия (NULL - не принято)
+    revoked_at    TIMESTAMP                                                          -- Дата и время отзыва (NULL - активно)
+);
+
+CREATE INDEX idx_organisation_invitations_org ON auth.organisation_invitations (org_id) WHERE accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,9 @@
+-- Использованные задачи proof-of-work регистрации. Таблица общая для всех экземпляров сервиса,
+-- иначе одно решение можно было бы предъявить каждому экземпляру по разу
+CREATE TABLE auth.spent_challenges
+(
+    challenge_hash VARCHAR(64) PRIMARY KEY, -- SHA-256 хеш задачи
+    expires_at     TIMESTAMP NOT NULL       -- Срок задачи; после него запись не нужна, задачу отклонит проверка срока
+);
+
+CREATE INDEX idx_spent_challenges_expires_at ON auth.spent_challenges (expires_at); -- Для очистки истекших задач
@@ -0,0 +1,22 @@
+-- Канонический вид email (с правилами провайдеров из email.provider_rules) хранится отдельно
+-- от адреса пользователя: email остается в том написании, на которое уходят письма, а
+-- уникальность и поиск при входе идут по email_canonical. До запуска cmd/normalize-emails -apply
+-- канонический вид совпадает с адресом в нижнем регистре, поэтому миграция не находит новых дубликатов
+ALTER TABLE auth.users
+    ADD COLUMN email_canonical VARCHAR(255);
+UPDATE auth.users
+SET email_canonical = lower(email);
+ALTER TABLE auth.users
+    ALTER COLUMN email_canonical SET NOT NULL;
+
+DROP INDEX auth.uq_users_email_lower;
+CREATE UNIQUE INDEX uq_users_email_canonical ON auth.users (tenant_id, email_canonical);
+
+-- Новый адрес проверяется на занятость при подтверждении, поэтому его канонический вид
+-- сохраняется вместе с запросом
+ALTER TABLE auth.email_change_requests
+    ADD COLUMN new_email_canonical VARCHAR(255);
+UPDATE auth.email_change_requests
+SET new_email_canonical = lower(new_email);
+ALTER TABLE auth.email_change_requests
+    ALTER COLUMN new_email_canonical SET NOT NULL;
@@ -0,0 +1,17 @@
+-- Коды авторизации OIDC authorization code flow (RFC 6749 §4.1) с PKCE (RFC 7636)
+CREATE TABLE auth.authorization_codes
+(
+    code_hash      VARCHAR(64) PRIMARY KEY,                                         -- SHA-256 хеш кода (сам код не хранится)
+    tenant_id      UUID         NOT NULL REFERENCES auth.tenants (tenant_id),       -- Арендатор пользователя
+    user_id        UUID         NOT NULL REFERENCES auth.users (user_id) ON DELETE CASCADE, -- Пользователь, вошедший на странице входа
+    client_id      VARCHAR(255) NOT NULL,                                           -- Клиент, которому выдан код
+    redirect_uri   TEXT         NOT NULL,                                           -- redirect_uri запроса, должен совпасть при обмене
+    code_challenge VARCHAR(128) NOT NULL,                                           -- code_challenge (S256) из запроса авторизации
+    nonce          VARCHAR(255) NOT NULL DEFAULT '',                                -- nonce клиента для ID токена
+    auth_time      TIMESTAMP    NOT NULL,                                           -- Время входа пользователя
+    expires_at     TIMESTAMP    NOT NULL,                                           -- Время истечения срока действия кода
+    consumed_at    TIMESTAMP,                                                       -- Время обмена кода (NULL - не обменян)
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP                              -- Дата и время выдачи кода
+);
+
+CREATE INDEX idx_authorization_codes_user_id ON auth.authorization_codes (user_id); -- Для очистки кодов пользователя
@@ -0,0 +1,70 @@
+package emailnorm
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// ErrInvalid адрес нельзя привести к каноническому виду
+var ErrInvalid = errors.New("неверный формат email")
+
+// Normalizer приводит email к виду, в котором он хранится, и к каноническому виду,
+// по которому адреса сравниваются
+type Normalizer struct {
+	providerRules bool
+}
+
+// New создает нормализатор. providerRules включает в канонический вид правила почтовых
+// провайдеров (точки и +метки в Gmail и т.п.), при которых разные написания ведут в один ящик
+func New(providerRules bool) *Normalizer {
+	return &Normalizer{providerRules: providerRules}
+}
+
+// Normalize обрезает пробелы, приводит адрес к нижнему регистру, а интернациональный
+// домен - к punycode. Правила провайдеров не применяются: результат хранится как адрес
+// пользователя, и письма уходят на то написание, которое он указал
+func (n *Normalizer) Normalize(email string) (string, error) {
+	local, domain, err := split(email)
+	if err != nil {
+		return "", err
+	}
+	return local + "@" + domain, nil
+}
+
+// Canonical возвращает адрес для поиска и проверки уникальности: Normalize и, если
+// включены, правила провайдеров. В письмах канонический вид не используется
+func (n *Normalizer) Canonical(email string) (string, error) {
+	local, domain, err := split(email)
+	if err != nil {
+		return "", err
+	}
+
+	if n != nil && n.providerRules {
+		local, domain = applyProviderRules(local, domain)
+		if local == "" {
+			return "", ErrInvalid
+		}
+	}
+
+	return local + "@" + domain, nil
+}
+
+// split разбирает адрес на имя и домен в нижнем регистре, домен - в punycode
+func split(email string) (string, string, error) {
+	email = strings.TrimSpace(email)
+
+	at := strings.LastIndexByte(email, '@')
+	if at <= 0 || at == len(email)-1 {
+		return "", "", ErrInvalid
+	}
+	local := strings.ToLower(email[:at])
+	domain := strings.TrimSuffix(strings.ToLower(email[at+1:]), ".")
+
+	domain, err := idna.Lookup.ToASCII(domain)
+	if err != nil || domain == "" {
+		return "", "", ErrInvalid
+	}
+	return local, domain, nil
+}
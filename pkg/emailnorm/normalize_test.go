@@ -0,0 +1,83 @@
+package emailnorm_test
+
+import (
+	"auth-service/pkg/emailnorm"
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{email: "  User@Example.COM ", want: "user@example.com"},
+		{email: "John.Doe+news@Gmail.com", want: "john.doe+news@gmail.com"}, // правила провайдеров не применяются
+		{email: "user@example.com.", want: "user@example.com"},
+		{email: "user@Пример.РФ", want: "user@xn--e1afmkfd.xn--p1ai"},
+		{email: "user@münchen.de", want: "user@xn--mnchen-3ya.de"},
+		{email: `"a@b"@example.com`, want: `"a@b"@example.com`}, // домен отделяется по последней @
+	}
+	n := emailnorm.New(true)
+	for _, tt := range tests {
+		got, err := n.Normalize(tt.email)
+		if err != nil {
+			t.Errorf("Normalize(%q): %v", tt.email, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Normalize(%q) = %q, ожидалось %q", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerRules bool
+		email         string
+		want          string
+	}{
+		{name: "gmail точки и метка", providerRules: true, email: "John.Doe+news@Gmail.com", want: "johndoe@gmail.com"},
+		{name: "googlemail", providerRules: true, email: "j.doe@googlemail.com", want: "jdoe@gmail.com"},
+		{name: "яндекс точка как дефис", providerRules: true, email: "ivan.petrov+shop@ya.ru", want: "ivan-petrov@yandex.ru"},
+		{name: "яндекс домен страны", providerRules: true, email: "ivan-petrov@yandex.kz", want: "ivan-petrov@yandex.ru"},
+		{name: "outlook только метка", providerRules: true, email: "first.last+tag@outlook.com", want: "first.last@outlook.com"},
+		{name: "другой домен", providerRules: true, email: "first.last+tag@example.com", want: "first.last+tag@example.com"},
+		{name: "IDN", providerRules: true, email: "Иван@Почта.РФ", want: "иван@xn--80a1acny.xn--p1ai"},
+		{name: "правила выключены", email: "John.Doe+news@Gmail.com", want: "john.doe+news@gmail.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := emailnorm.New(tt.providerRules).Canonical(tt.email)
+			if err != nil {
+				t.Fatalf("Canonical(%q): %v", tt.email, err)
+			}
+			if got != tt.want {
+				t.Fatalf("Canonical(%q) = %q, ожидалось %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	emails := []string{
+		"",
+		"user",
+		"@example.com",
+		"user@",
+		"user@.",
+		"user@exa mple.com",
+	}
+	n := emailnorm.New(true)
+	for _, email := range emails {
+		if _, err := n.Normalize(email); !errors.Is(err, emailnorm.ErrInvalid) {
+			t.Errorf("Normalize(%q) = %v, ожидалась ErrInvalid", email, err)
+		}
+	}
+
+	// Адрес из одной метки: после правил провайдера имя пустое
+	if _, err := n.Canonical("+tag@gmail.com"); !errors.Is(err, emailnorm.ErrInvalid) {
+		t.Errorf("Canonical(+tag@gmail.com) = %v, ожидалась ErrInvalid", err)
+	}
+}
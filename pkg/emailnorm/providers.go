@@ -0,0 +1,67 @@
+package emailnorm
+
+import "strings"
+
+// providerRule как провайдер сопоставляет разные написания адреса одному ящику
+type providerRule struct {
+	domain     string // Основной домен, к которому приводятся псевдонимы
+	stripTag   bool   // Часть после "+" игнорируется
+	dropDots   bool   // Точки в имени игнорируются
+	dotsAsDash bool   // Точка и дефис в имени равнозначны
+}
+
+var (
+	gmail   = providerRule{domain: "gmail.com", stripTag: true, dropDots: true}
+	yandex  = providerRule{domain: "yandex.ru", stripTag: true, dotsAsDash: true}
+	outlook = providerRule{stripTag: true}
+	icloud  = providerRule{stripTag: true}
+	proton  = providerRule{stripTag: true}
+)
+
+// providerRules правила по домену почты
+var providerRules = map[string]providerRule{
+	"gmail.com":      gmail,
+	"googlemail.com": gmail,
+
+	"yandex.ru":  yandex,
+	"yandex.com": yandex,
+	"yandex.by":  yandex,
+	"yandex.kz":  yandex,
+	"yandex.ua":  yandex,
+	"ya.ru":      yandex,
+
+	"outlook.com": outlook,
+	"hotmail.com": outlook,
+	"live.com":    outlook,
+
+	"icloud.com": icloud,
+	"me.com":     icloud,
+	"mac.com":    icloud,
+
+	"proton.me":      proton,
+	"protonmail.com": proton,
+	"pm.me":          proton,
+}
+
+// applyProviderRules приводит адрес к ящику, в который он ведет. Результат идет только
+// в канонический вид: хранимый адрес остается в написании пользователя
+func applyProviderRules(local, domain string) (string, string) {
+	rule, ok := providerRules[domain]
+	if !ok {
+		return local, domain
+	}
+
+	if rule.stripTag {
+		local, _, _ = strings.Cut(local, "+")
+	}
+	if rule.dropDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	if rule.dotsAsDash {
+		local = strings.ReplaceAll(local, ".", "-")
+	}
+	if rule.domain != "" {
+		domain = rule.domain
+	}
+	return local, domain
+}
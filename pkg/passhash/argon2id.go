@@ -0,0 +1,96 @@
+package passhash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"strings"
+)
+
+const prefixArgon2id = "$argon2id$"
+
+// Argon2id хеши в формате PHC: $argon2id$v=19$m=<KiB>,t=<проходы>,p=<потоки>$<соль>$<хеш>
+type Argon2id struct {
+	Memory      uint32 // Память в KiB
+	Time        uint32 // Число проходов
+	Parallelism uint8
+	SaltLength  int
+	KeyLength   uint32
+}
+
+// DefaultArgon2id параметры из второй рекомендации RFC 9106 для систем с ограниченной памятью
+var DefaultArgon2id = Argon2id{Memory: 64 * 1024, Time: 3, Parallelism: 4, SaltLength: 16, KeyLength: 32}
+
+type argon2Params struct {
+	memory      uint32
+	time        uint32
+	parallelism uint8
+	salt        []byte
+	key         []byte
+}
+
+func (a Argon2id) Name() string { return "argon2id" }
+
+func (a Argon2id) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, prefixArgon2id)
+}
+
+func (a Argon2id) Hash(password string) (string, error) {
+	salt, err := randomSalt(a.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.Time, a.Memory, a.Parallelism, a.KeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", prefixArgon2id, argon2.Version, a.Memory, a.Time, a.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (a Argon2id) Verify(password, hash string) bool {
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(password), p.salt, p.time, p.memory, p.parallelism, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1
+}
+
+func (a Argon2id) Weaker(hash string) bool {
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return p.memory < a.Memory || p.time < a.Time || uint32(len(p.key)) < a.KeyLength
+}
+
+func parseArgon2id(hash string) (*argon2Params, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", соль, хеш
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrMalformedHash
+	}
+
+	p := new(argon2Params)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.parallelism); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if p.time == 0 || p.parallelism == 0 {
+		return nil, ErrMalformedHash
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(p.key) == 0 {
+		return nil, ErrMalformedHash
+	}
+	return p, nil
+}
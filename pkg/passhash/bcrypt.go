@@ -0,0 +1,31 @@
+package passhash
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+// Bcrypt хеши bcrypt ($2a$, $2b$, $2y$) любой стоимости
+type Bcrypt struct {
+	Cost int
+}
+
+func (b Bcrypt) Name() string { return "bcrypt" }
+
+func (b Bcrypt) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (b Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	return string(hash), err
+}
+
+func (b Bcrypt) Verify(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func (b Bcrypt) Weaker(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < b.Cost
+}
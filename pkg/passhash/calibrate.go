@@ -0,0 +1,44 @@
+package passhash
+
+import (
+	"slices"
+	"time"
+)
+
+// calibrationRuns замеров на каждое сочетание параметров, берется медиана
+const calibrationRuns = 3
+
+// CalibrateArgon2id подбирает параметры argon2id, при которых хеширование занимает не меньше target.
+// Память и параллелизм фиксированы, число проходов растет, пока время не достигнет цели.
+// Если даже один проход дольше цели, память уменьшается вдвое, но не ниже minMemory.
+// Возвращает параметры и измеренное время хеширования
+func CalibrateArgon2id(target time.Duration, memory, minMemory uint32, parallelism uint8) (Argon2id, time.Duration) {
+	a := DefaultArgon2id
+	a.Memory = memory
+	a.Parallelism = parallelism
+	a.Time = 1
+
+	elapsed := measure(a)
+	for elapsed > target && a.Memory/2 >= minMemory {
+		a.Memory /= 2
+		elapsed = measure(a)
+	}
+
+	for elapsed < target {
+		a.Time++
+		elapsed = measure(a)
+	}
+
+	return a, elapsed
+}
+
+func measure(h Hasher) time.Duration {
+	samples := make([]time.Duration, calibrationRuns)
+	for i := range samples {
+		start := time.Now()
+		_, _ = h.Hash("calibration")
+		samples[i] = time.Since(start)
+	}
+	slices.Sort(samples)
+	return samples[len(samples)/2]
+}
@@ -0,0 +1,72 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"errors"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("неизвестный алгоритм хеширования пароля")
+	ErrVerifyOnly       = errors.New("алгоритм поддерживает только проверку пароля")
+	ErrMalformedHash    = errors.New("неверный формат хеша пароля")
+)
+
+// Hasher алгоритм хеширования паролей
+type Hasher interface {
+	// Name название алгоритма, совпадает со значением в конфиге
+	Name() string
+	// Recognizes сообщает, записан ли хеш в формате этого алгоритма
+	Recognizes(hash string) bool
+	// Hash хеширует пароль с текущими параметрами
+	Hash(password string) (string, error)
+	// Verify проверяет пароль по хешу этого алгоритма
+	Verify(password, hash string) bool
+	// Weaker сообщает, что хеш получен с параметрами слабее текущих
+	Weaker(hash string) bool
+}
+
+// Registry выбирает алгоритм по формату сохраненного хеша. Новые хеши создаются
+// целевым алгоритмом, хеши остальных алгоритмов и с более слабыми параметрами
+// считаются устаревшими и подлежат перехешированию
+type Registry struct {
+	target  Hasher
+	hashers []Hasher
+}
+
+// NewRegistry создает реестр с целевым алгоритмом и алгоритмами, которые нужно только распознавать
+func NewRegistry(target Hasher, known ...Hasher) *Registry {
+	return &Registry{target: target, hashers: append([]Hasher{target}, known...)}
+}
+
+// Hash хеширует пароль целевым алгоритмом
+func (r *Registry) Hash(password string) (string, error) {
+	return r.target.Hash(password)
+}
+
+// Verify проверяет пароль. rehash = true, если пароль верный, а хеш не соответствует
+// текущей политике и его стоит пересчитать целевым алгоритмом
+func (r *Registry) Verify(password, hash string) (ok, rehash bool) {
+	for _, h := range r.hashers {
+		if !h.Recognizes(hash) {
+			continue
+		}
+		if !h.Verify(password, hash) {
+			return false, false
+		}
+		return true, h != r.target || h.Weaker(hash)
+	}
+	return false, false
+}
+
+// NeedsRehash сообщает, что хеш не соответствует текущей политике
+func (r *Registry) NeedsRehash(hash string) bool {
+	return !r.target.Recognizes(hash) || r.target.Weaker(hash)
+}
+
+func randomSalt(n int) ([]byte, error) {
+	salt := make([]byte, n)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
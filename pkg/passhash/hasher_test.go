@@ -0,0 +1,131 @@
+package passhash_test
+
+import (
+	"auth-service/pkg/passhash"
+	"strings"
+	"testing"
+)
+
+// Параметры ниже рабочих, чтобы тесты выполнялись быстро
+var (
+	testArgon2id = passhash.Argon2id{Memory: 1024, Time: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	testScrypt   = passhash.Scrypt{LogN: 4, R: 8, P: 1, SaltLength: 16, KeyLength: 32}
+)
+
+func TestHashRoundTrip(t *testing.T) {
+	for _, h := range []passhash.Hasher{testArgon2id, testScrypt, passhash.Bcrypt{Cost: 4}} {
+		t.Run(h.Name(), func(t *testing.T) {
+			hash, err := h.Hash("correct horse")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if !h.Recognizes(hash) {
+				t.Fatalf("хеш %s не распознан", hash)
+			}
+			if !h.Verify("correct horse", hash) {
+				t.Fatal("верный пароль не принят")
+			}
+			if h.Verify("correct horse!", hash) {
+				t.Fatal("неверный пароль принят")
+			}
+			if h.Weaker(hash) {
+				t.Fatal("хеш с текущими параметрами считается слабым")
+			}
+
+			// Соль случайная: одинаковые пароли дают разные хеши
+			again, err := h.Hash("correct horse")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again == hash {
+				t.Fatal("повторный хеш совпал с первым")
+			}
+		})
+	}
+}
+
+func TestMalformedPHC(t *testing.T) {
+	argonHash, err := testArgon2id.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scryptHash, err := testScrypt.Hash("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		hasher passhash.Hasher
+		hash   string
+	}{
+		{name: "argon2id другая версия", hasher: testArgon2id, hash: strings.Replace(argonHash, "v=19", "v=16", 1)},
+		{name: "argon2id нет параметров", hasher: testArgon2id, hash: strings.Replace(argonHash, "m=1024,t=1,p=1", "m=1024", 1)},
+		{name: "argon2id нулевые проходы", hasher: testArgon2id, hash: strings.Replace(argonHash, "t=1", "t=0", 1)},
+		{name: "argon2id лишняя часть", hasher: testArgon2id, hash: argonHash + "$x"},
+		{name: "argon2id соль не base64", hasher: testArgon2id, hash: replacePart(argonHash, 4, "!!!")},
+		{name: "argon2id пустой ключ", hasher: testArgon2id, hash: replacePart(argonHash, 5, "")},
+		{name: "scrypt ln=0", hasher: testScrypt, hash: strings.Replace(scryptHash, "ln=4", "ln=0", 1)},
+		{name: "scrypt ln>30", hasher: testScrypt, hash: strings.Replace(scryptHash, "ln=4", "ln=31", 1)},
+		{name: "scrypt r=0", hasher: testScrypt, hash: strings.Replace(scryptHash, "r=8", "r=0", 1)},
+		{name: "scrypt нет хеша", hasher: testScrypt, hash: scryptHash[:strings.LastIndexByte(scryptHash, '$')]},
+		{name: "scrypt пустой ключ", hasher: testScrypt, hash: replacePart(scryptHash, 4, "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.hasher.Verify("secret", tt.hash) {
+				t.Fatalf("Verify принял хеш %s", tt.hash)
+			}
+			// Неразборчивый хеш пересчитывается при следующем входе
+			if !tt.hasher.Weaker(tt.hash) {
+				t.Fatalf("Weaker(%s) = false", tt.hash)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := passhash.NewRegistry(testArgon2id, testScrypt, passhash.PHPass{})
+
+	argonHash, err := r.Hash("test12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scryptHash, err := testScrypt.Hash("test12345")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stronger := testArgon2id
+	stronger.Time = 2
+
+	tests := []struct {
+		name       string
+		registry   *passhash.Registry
+		password   string
+		hash       string
+		wantOK     bool
+		wantRehash bool
+	}{
+		{name: "целевой алгоритм", registry: r, password: "test12345", hash: argonHash, wantOK: true},
+		{name: "другой алгоритм", registry: r, password: "test12345", hash: scryptHash, wantOK: true, wantRehash: true},
+		{name: "phpass", registry: r, password: "test12345", hash: "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0", wantOK: true, wantRehash: true},
+		{name: "слабые параметры", registry: passhash.NewRegistry(stronger), password: "test12345", hash: argonHash, wantOK: true, wantRehash: true},
+		{name: "неверный пароль", registry: r, password: "wrong", hash: scryptHash},
+		{name: "неизвестный формат", registry: r, password: "test12345", hash: "!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, rehash := tt.registry.Verify(tt.password, tt.hash)
+			if ok != tt.wantOK || rehash != tt.wantRehash {
+				t.Fatalf("Verify = (%v, %v), ожидалось (%v, %v)", ok, rehash, tt.wantOK, tt.wantRehash)
+			}
+		})
+	}
+}
+
+// replacePart заменяет часть хеша PHC с номером i (части разделены '$')
+func replacePart(hash string, i int, value string) string {
+	parts := strings.Split(hash, "$")
+	parts[i] = value
+	return strings.Join(parts, "$")
+}
@@ -0,0 +1,124 @@
+package passhash
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+// Префиксы поддерживаемых форматов хешей
+const (
+	PrefixSaltedSHA256 = "$sha256s$" // $sha256s$<salt>$<hex(sha256(salt+password))> - формат старого PHP приложения
+	PrefixPHPass       = "$P$"       // Переносимые хеши PHPass (WordPress, phpBB)
+	PrefixPHPassPhpBB  = "$H$"
+)
+
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// FormatSaltedSHA256 приводит соль и hex-хеш из старой базы к хранимому формату
+func FormatSaltedSHA256(salt, hexHash string) string {
+	return PrefixSaltedSHA256 + salt + "$" + strings.ToLower(hexHash)
+}
+
+// VerifySaltedSHA256 проверяет пароль по хешу в формате FormatSaltedSHA256
+func VerifySaltedSHA256(password, stored string) bool {
+	rest, ok := strings.CutPrefix(stored, PrefixSaltedSHA256)
+	if !ok {
+		return false
+	}
+
+	// Соль может содержать '$', поэтому хеш отделяем по последнему разделителю
+	i := strings.LastIndexByte(rest, '$')
+	if i < 0 {
+		return false
+	}
+	salt, want := rest[:i], rest[i+1:]
+
+	sum := sha256.Sum256([]byte(salt + password))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(want)) == 1
+}
+
+// IsPHPass сообщает, является ли хеш переносимым хешем PHPass
+func IsPHPass(stored string) bool {
+	return len(stored) == 34 && (strings.HasPrefix(stored, PrefixPHPass) || strings.HasPrefix(stored, PrefixPHPassPhpBB))
+}
+
+// VerifyPHPass проверяет пароль по переносимому хешу PHPass
+func VerifyPHPass(password, stored string) bool {
+	if !IsPHPass(stored) {
+		return false
+	}
+
+	countLog2 := strings.IndexByte(itoa64, stored[3])
+	if countLog2 < 7 || countLog2 > 30 {
+		return false
+	}
+	salt := stored[4:12]
+
+	sum := md5.Sum([]byte(salt + password))
+	for count := 1 << countLog2; count > 0; count-- {
+		sum = md5.Sum(append(sum[:], password...))
+	}
+
+	computed := stored[:12] + encode64(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(stored)) == 1
+}
+
+// encode64 кодирование PHPass (little-endian группы по 6 бит алфавитом itoa64)
+func encode64(input []byte) string {
+	var b strings.Builder
+	count := len(input)
+
+	for i := 0; i < count; {
+		value := int(input[i])
+		i++
+		b.WriteByte(itoa64[value&0x3f])
+		if i < count {
+			value |= int(input[i]) << 8
+		}
+		b.WriteByte(itoa64[(value>>6)&0x3f])
+		if i >= count {
+			break
+		}
+		i++
+		if i < count {
+			value |= int(input[i]) << 16
+		}
+		b.WriteByte(itoa64[(value>>12)&0x3f])
+		if i >= count {
+			break
+		}
+		i++
+		b.WriteByte(itoa64[(value>>18)&0x3f])
+	}
+
+	return b.String()
+}
+
+// SaltedSHA256 хеши старого PHP приложения, только проверка
+type SaltedSHA256 struct{}
+
+func (SaltedSHA256) Name() string { return "sha256_salted" }
+
+func (SaltedSHA256) Recognizes(hash string) bool { return strings.HasPrefix(hash, PrefixSaltedSHA256) }
+
+func (SaltedSHA256) Hash(string) (string, error) { return "", ErrVerifyOnly }
+
+func (SaltedSHA256) Verify(password, hash string) bool { return VerifySaltedSHA256(password, hash) }
+
+func (SaltedSHA256) Weaker(string) bool { return true }
+
+// PHPass переносимые хеши PHPass, только проверка
+type PHPass struct{}
+
+func (PHPass) Name() string { return "phpass" }
+
+func (PHPass) Recognizes(hash string) bool { return IsPHPass(hash) }
+
+func (PHPass) Hash(string) (string, error) { return "", ErrVerifyOnly }
+
+func (PHPass) Verify(password, hash string) bool { return VerifyPHPass(password, hash) }
+
+func (PHPass) Weaker(string) bool { return true }
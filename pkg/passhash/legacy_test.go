@@ -0,0 +1,55 @@
+package passhash_test
+
+import (
+	"auth-service/pkg/passhash"
+	"testing"
+)
+
+func TestVerifyPHPass(t *testing.T) {
+	const stored = "$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0" // "test12345", эталон из тестов PHPass
+
+	tests := []struct {
+		name     string
+		password string
+		stored   string
+		want     bool
+	}{
+		{name: "верный пароль", password: "test12345", stored: stored, want: true},
+		{name: "неверный пароль", password: "test12346", stored: stored},
+		{name: "префикс phpBB", password: "test12345", stored: "$H$" + stored[3:], want: true},
+		{name: "обрезанный хеш", password: "test12345", stored: stored[:33]},
+		{name: "слишком мало итераций", password: "test12345", stored: "$P$1" + stored[4:]},
+		{name: "другой формат", password: "test12345", stored: "$2a$10$abcdefghijklmnopqrstuv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passhash.VerifyPHPass(tt.password, tt.stored); got != tt.want {
+				t.Fatalf("VerifyPHPass = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySaltedSHA256(t *testing.T) {
+	// sha256("abc$1" + "secret"); соль содержит '$'
+	stored := passhash.FormatSaltedSHA256("abc$1", "27ED680FC360A2FE316D42D49186ED118A25FDD817A3900336282A9EF7ECA841")
+
+	tests := []struct {
+		name     string
+		password string
+		stored   string
+		want     bool
+	}{
+		{name: "верный пароль", password: "secret", stored: stored, want: true},
+		{name: "неверный пароль", password: "Secret", stored: stored},
+		{name: "без префикса", password: "secret", stored: stored[len(passhash.PrefixSaltedSHA256):]},
+		{name: "без разделителя", password: "secret", stored: passhash.PrefixSaltedSHA256 + "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passhash.VerifySaltedSHA256(tt.password, tt.stored); got != tt.want {
+				t.Fatalf("VerifySaltedSHA256 = %v, ожидалось %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package passhash
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"strings"
+)
+
+const prefixScrypt = "$scrypt$"
+
+// Scrypt хеши в формате PHC: $scrypt$ln=<log2 N>,r=<r>,p=<p>$<соль>$<хеш>
+type Scrypt struct {
+	LogN       uint8 // log2 параметра N
+	R          int
+	P          int
+	SaltLength int
+	KeyLength  int
+}
+
+type scryptParams struct {
+	logN uint8
+	r    int
+	p    int
+	salt []byte
+	key  []byte
+}
+
+func (s Scrypt) Name() string { return "scrypt" }
+
+func (s Scrypt) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, prefixScrypt)
+}
+
+func (s Scrypt) Hash(password string) (string, error) {
+	salt, err := randomSalt(s.SaltLength)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, 1<<s.LogN, s.R, s.P, s.KeyLength)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s", prefixScrypt, s.LogN, s.R, s.P,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (s Scrypt) Verify(password, hash string) bool {
+	p, err := parseScrypt(hash)
+	if err != nil {
+		return false
+	}
+
+	key, err := scrypt.Key([]byte(password), p.salt, 1<<p.logN, p.r, p.p, len(p.key))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, p.key) == 1
+}
+
+func (s Scrypt) Weaker(hash string) bool {
+	p, err := parseScrypt(hash)
+	if err != nil {
+		return true
+	}
+	return p.logN < s.LogN || p.r < s.R || p.p < s.P || len(p.key) < s.KeyLength
+}
+
+func parseScrypt(hash string) (*scryptParams, error) {
+	// "", "scrypt", "ln=..,r=..,p=..", соль, хеш
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[1] != "scrypt" {
+		return nil, ErrMalformedHash
+	}
+
+	p := new(scryptParams)
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &p.logN, &p.r, &p.p); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if p.logN == 0 || p.logN > 30 || p.r <= 0 || p.p <= 0 {
+		return nil, ErrMalformedHash
+	}
+
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return nil, ErrMalformedHash
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil || len(p.key) == 0 {
+		return nil, ErrMalformedHash
+	}
+	return p, nil
+}
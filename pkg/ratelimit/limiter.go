@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery через сколько вызовов Allow удалять устаревшие ключи
+const sweepEvery = 1024
+
+// Limiter ограничивает число событий на ключ в скользящем окне
+type Limiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	hits   map[string][]time.Time
+	calls  int
+}
+
+// New создает лимитер на limit событий за window
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:  limit,
+		window: window,
+		hits:   make(map[string][]time.Time),
+	}
+}
+
+// Allow регистрирует событие для ключа и сообщает, укладывается ли оно в лимит
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.calls++
+	if l.calls%sweepEvery == 0 {
+		l.sweep(now)
+	}
+
+	hits := prune(l.hits[key], now.Add(-l.window))
+	if len(hits) >= l.limit {
+		l.hits[key] = hits
+		return false
+	}
+
+	l.hits[key] = append(hits, now)
+	return true
+}
+
+// sweep удаляет ключи, у которых не осталось событий в окне
+func (l *Limiter) sweep(now time.Time) {
+	since := now.Add(-l.window)
+	for key, hits := range l.hits {
+		if hits = prune(hits, since); len(hits) == 0 {
+			delete(l.hits, key)
+		} else {
+			l.hits[key] = hits
+		}
+	}
+}
+
+// prune отбрасывает события старше since (события хранятся по возрастанию времени)
+func prune(hits []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(since) {
+		i++
+	}
+	return hits[i:]
+}
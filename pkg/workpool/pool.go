@@ -0,0 +1,136 @@
+package workpool
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync/atomic"
+	"time"
+)
+
+var ErrQueueFull = errors.New("очередь переполнена")
+
+// waitBuckets верхние границы корзин гистограммы ожидания в очереди
+var waitBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 25 * time.Millisecond, 100 * time.Millisecond,
+	250 * time.Millisecond, 500 * time.Millisecond, time.Second, 2500 * time.Millisecond,
+}
+
+// Pool ограничивает число одновременно выполняемых задач. Задачи сверх concurrency ждут
+// в очереди; если очередь заполнена, задача сразу отклоняется с ErrQueueFull, чтобы под
+// нагрузкой отвечать отказом, а не копить запросы до таймаута
+type Pool struct {
+	slots    chan struct{}
+	maxQueue int64
+	queued   atomic.Int64
+
+	inFlight  expvar.Int
+	waiting   expvar.Int
+	completed expvar.Int
+	rejected  expvar.Int
+	cancelled expvar.Int
+	waitTotal expvar.Float // Суммарное ожидание в очереди, секунды
+	waitHist  *expvar.Map  // Число задач по корзинам ожидания: ключ - верхняя граница корзины
+}
+
+// New создает пул и публикует его метрики в expvar под именем name (/debug/vars)
+func New(name string, concurrency, queueDepth int) *Pool {
+	p := &Pool{
+		slots:    make(chan struct{}, concurrency),
+		maxQueue: int64(queueDepth),
+		waitHist: new(expvar.Map).Init(),
+	}
+	for _, b := range waitBuckets {
+		p.waitHist.Add(b.String(), 0)
+	}
+	p.waitHist.Add("inf", 0)
+
+	m := new(expvar.Map).Init()
+	m.Set("concurrency", expvarInt(int64(concurrency)))
+	m.Set("queue_depth", expvarInt(int64(queueDepth)))
+	m.Set("in_flight", &p.inFlight)
+	m.Set("waiting", &p.waiting)
+	m.Set("completed", &p.completed)
+	m.Set("rejected", &p.rejected)
+	m.Set("cancelled", &p.cancelled)
+	m.Set("queue_wait_seconds_total", &p.waitTotal)
+	m.Set("queue_wait", p.waitHist)
+
+	// expvar не позволяет публиковать имя дважды, повторно созданный пул заменяет метрики
+	if v, ok := expvar.Get(name).(*expvar.Map); ok {
+		m.Do(func(kv expvar.KeyValue) { v.Set(kv.Key, kv.Value) })
+	} else {
+		expvar.Publish(name, m)
+	}
+
+	return p
+}
+
+// Do выполняет fn, когда освободится слот. Возвращает ErrQueueFull, если очередь заполнена,
+// или ошибку контекста, если он отменен раньше, чем задача дождалась слота
+func (p *Pool) Do(ctx context.Context, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Свободный слот занимаем сразу, в очередь попадают только ожидающие задачи
+	select {
+	case p.slots <- struct{}{}:
+		p.observeWait(0)
+	default:
+		if err := p.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	p.inFlight.Add(1)
+	defer func() {
+		p.inFlight.Add(-1)
+		p.completed.Add(1)
+		<-p.slots
+	}()
+
+	fn()
+	return nil
+}
+
+// wait ставит задачу в очередь и ждет слота
+func (p *Pool) wait(ctx context.Context) error {
+	if p.queued.Add(1) > p.maxQueue {
+		p.queued.Add(-1)
+		p.rejected.Add(1)
+		return ErrQueueFull
+	}
+	p.waiting.Add(1)
+	defer func() {
+		p.queued.Add(-1)
+		p.waiting.Add(-1)
+	}()
+
+	start := time.Now()
+	select {
+	case p.slots <- struct{}{}:
+		p.observeWait(time.Since(start))
+		return nil
+	case <-ctx.Done():
+		p.cancelled.Add(1)
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) observeWait(d time.Duration) {
+	p.waitTotal.Add(d.Seconds())
+	for _, b := range waitBuckets {
+		if d <= b {
+			p.waitHist.Add(b.String(), 1)
+			return
+		}
+	}
+	p.waitHist.Add("inf", 1)
+}
+
+func expvarInt(v int64) *expvar.Int {
+	i := new(expvar.Int)
+	i.Set(v)
+	return i
+}